@@ -0,0 +1,11 @@
+// Package tunerv1 is the generated Go code of the tuner gRPC API defined in tuner.proto.
+// tunerservice.GRPCServer implements it over the same TunerService as the REST API.
+package tunerv1
+
+// The plugins must match the runtime versions in go.mod. Run from this module,
+//
+//	go install google.golang.org/protobuf/cmd/protoc-gen-go
+//	go install google.golang.org/grpc/cmd/protoc-gen-go-grpc@v1.5.1
+//
+// installs protoc-gen-go at the required google.golang.org/protobuf version.
+//go:generate protoc -I ../../.. --go_out=../../.. --go_opt=paths=source_relative --go-grpc_out=../../.. --go-grpc_opt=paths=source_relative api/tuner/v1/tuner.proto
@@ -0,0 +1,3432 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: api/tuner/v1/tuner.proto
+
+package tunerv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type TuneRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReplicaSpecs  []*ServerSpec          `protobuf:"bytes,1,rep,name=replica_specs,json=replicaSpecs,proto3" json:"replica_specs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TuneRequest) Reset() {
+	*x = TuneRequest{}
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TuneRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TuneRequest) ProtoMessage() {}
+
+func (x *TuneRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TuneRequest.ProtoReflect.Descriptor instead.
+func (*TuneRequest) Descriptor() ([]byte, []int) {
+	return file_api_tuner_v1_tuner_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *TuneRequest) GetReplicaSpecs() []*ServerSpec {
+	if x != nil {
+		return x.ReplicaSpecs
+	}
+	return nil
+}
+
+// TuneResponse is the tuned ModelData and the outcome of every group in the request. Its models
+// field is wire-compatible with ModelData.
+type TuneResponse struct {
+	state         protoimpl.MessageState      `protogen:"open.v1"`
+	Models        []*ModelAcceleratorPerfData `protobuf:"bytes,1,rep,name=models,proto3" json:"models,omitempty"`
+	Groups        []*GroupOutcome             `protobuf:"bytes,2,rep,name=groups,proto3" json:"groups,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TuneResponse) Reset() {
+	*x = TuneResponse{}
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TuneResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TuneResponse) ProtoMessage() {}
+
+func (x *TuneResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TuneResponse.ProtoReflect.Descriptor instead.
+func (*TuneResponse) Descriptor() ([]byte, []int) {
+	return file_api_tuner_v1_tuner_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *TuneResponse) GetModels() []*ModelAcceleratorPerfData {
+	if x != nil {
+		return x.Models
+	}
+	return nil
+}
+
+func (x *TuneResponse) GetGroups() []*GroupOutcome {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+// GroupOutcome reports what one tuning cycle did for one (model, accelerator) group
+// (service.GroupOutcome).
+type GroupOutcome struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Model         string                 `protobuf:"bytes,1,opt,name=model,proto3" json:"model,omitempty"`
+	Accelerator   string                 `protobuf:"bytes,2,opt,name=accelerator,proto3" json:"accelerator,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"` // e.g. "tuned", "collecting-init-observations", "nis-rejected"
+	Message       string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	Estimator     string                 `protobuf:"bytes,5,opt,name=estimator,proto3" json:"estimator,omitempty"` // "ekf" or "sliding-window"
+	EkfFallback   bool                   `protobuf:"varint,6,opt,name=ekf_fallback,json=ekfFallback,proto3" json:"ekf_fallback,omitempty"`
+	HasParams     bool                   `protobuf:"varint,7,opt,name=has_params,json=hasParams,proto3" json:"has_params,omitempty"`
+	Fresh         bool                   `protobuf:"varint,8,opt,name=fresh,proto3" json:"fresh,omitempty"`
+	UpdateCount   int32                  `protobuf:"varint,9,opt,name=update_count,json=updateCount,proto3" json:"update_count,omitempty"`
+	Replicas      int32                  `protobuf:"varint,10,opt,name=replicas,proto3" json:"replicas,omitempty"`
+	Accepted      int32                  `protobuf:"varint,11,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	Rejected      int32                  `protobuf:"varint,12,opt,name=rejected,proto3" json:"rejected,omitempty"`
+	ObsCount      int32                  `protobuf:"varint,13,opt,name=obs_count,json=obsCount,proto3" json:"obs_count,omitempty"`
+	ObsTarget     int32                  `protobuf:"varint,14,opt,name=obs_target,json=obsTarget,proto3" json:"obs_target,omitempty"`
+	WindowCount   int32                  `protobuf:"varint,15,opt,name=window_count,json=windowCount,proto3" json:"window_count,omitempty"`
+	WindowSize    int32                  `protobuf:"varint,16,opt,name=window_size,json=windowSize,proto3" json:"window_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GroupOutcome) Reset() {
+	*x = GroupOutcome{}
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GroupOutcome) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupOutcome) ProtoMessage() {}
+
+func (x *GroupOutcome) ProtoReflect() protoreflect.Message {
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupOutcome.ProtoReflect.Descriptor instead.
+func (*GroupOutcome) Descriptor() ([]byte, []int) {
+	return file_api_tuner_v1_tuner_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GroupOutcome) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *GroupOutcome) GetAccelerator() string {
+	if x != nil {
+		return x.Accelerator
+	}
+	return ""
+}
+
+func (x *GroupOutcome) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *GroupOutcome) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *GroupOutcome) GetEstimator() string {
+	if x != nil {
+		return x.Estimator
+	}
+	return ""
+}
+
+func (x *GroupOutcome) GetEkfFallback() bool {
+	if x != nil {
+		return x.EkfFallback
+	}
+	return false
+}
+
+func (x *GroupOutcome) GetHasParams() bool {
+	if x != nil {
+		return x.HasParams
+	}
+	return false
+}
+
+func (x *GroupOutcome) GetFresh() bool {
+	if x != nil {
+		return x.Fresh
+	}
+	return false
+}
+
+func (x *GroupOutcome) GetUpdateCount() int32 {
+	if x != nil {
+		return x.UpdateCount
+	}
+	return 0
+}
+
+func (x *GroupOutcome) GetReplicas() int32 {
+	if x != nil {
+		return x.Replicas
+	}
+	return 0
+}
+
+func (x *GroupOutcome) GetAccepted() int32 {
+	if x != nil {
+		return x.Accepted
+	}
+	return 0
+}
+
+func (x *GroupOutcome) GetRejected() int32 {
+	if x != nil {
+		return x.Rejected
+	}
+	return 0
+}
+
+func (x *GroupOutcome) GetObsCount() int32 {
+	if x != nil {
+		return x.ObsCount
+	}
+	return 0
+}
+
+func (x *GroupOutcome) GetObsTarget() int32 {
+	if x != nil {
+		return x.ObsTarget
+	}
+	return 0
+}
+
+func (x *GroupOutcome) GetWindowCount() int32 {
+	if x != nil {
+		return x.WindowCount
+	}
+	return 0
+}
+
+func (x *GroupOutcome) GetWindowSize() int32 {
+	if x != nil {
+		return x.WindowSize
+	}
+	return 0
+}
+
+type CalibrateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Specs         []*ServerSpec          `protobuf:"bytes,1,rep,name=specs,proto3" json:"specs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CalibrateRequest) Reset() {
+	*x = CalibrateRequest{}
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CalibrateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CalibrateRequest) ProtoMessage() {}
+
+func (x *CalibrateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CalibrateRequest.ProtoReflect.Descriptor instead.
+func (*CalibrateRequest) Descriptor() ([]byte, []int) {
+	return file_api_tuner_v1_tuner_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CalibrateRequest) GetSpecs() []*ServerSpec {
+	if x != nil {
+		return x.Specs
+	}
+	return nil
+}
+
+type GetParamsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Model         string                 `protobuf:"bytes,1,opt,name=model,proto3" json:"model,omitempty"`
+	Accelerator   string                 `protobuf:"bytes,2,opt,name=accelerator,proto3" json:"accelerator,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetParamsRequest) Reset() {
+	*x = GetParamsRequest{}
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetParamsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetParamsRequest) ProtoMessage() {}
+
+func (x *GetParamsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetParamsRequest.ProtoReflect.Descriptor instead.
+func (*GetParamsRequest) Descriptor() ([]byte, []int) {
+	return file_api_tuner_v1_tuner_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetParamsRequest) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *GetParamsRequest) GetAccelerator() string {
+	if x != nil {
+		return x.Accelerator
+	}
+	return ""
+}
+
+type WatchParamsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Model         string                 `protobuf:"bytes,1,opt,name=model,proto3" json:"model,omitempty"`
+	Accelerator   string                 `protobuf:"bytes,2,opt,name=accelerator,proto3" json:"accelerator,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchParamsRequest) Reset() {
+	*x = WatchParamsRequest{}
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchParamsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchParamsRequest) ProtoMessage() {}
+
+func (x *WatchParamsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchParamsRequest.ProtoReflect.Descriptor instead.
+func (*WatchParamsRequest) Descriptor() ([]byte, []int) {
+	return file_api_tuner_v1_tuner_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *WatchParamsRequest) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *WatchParamsRequest) GetAccelerator() string {
+	if x != nil {
+		return x.Accelerator
+	}
+	return ""
+}
+
+type PredictRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Model         string                 `protobuf:"bytes,1,opt,name=model,proto3" json:"model,omitempty"`
+	Accelerator   string                 `protobuf:"bytes,2,opt,name=accelerator,proto3" json:"accelerator,omitempty"`
+	Points        []*OperatingPoint      `protobuf:"bytes,3,rep,name=points,proto3" json:"points,omitempty"`
+	Confidence    float64                `protobuf:"fixed64,4,opt,name=confidence,proto3" json:"confidence,omitempty"` // e.g. 0.9 for 90% TTFT/ITL intervals; 0 for none
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PredictRequest) Reset() {
+	*x = PredictRequest{}
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PredictRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PredictRequest) ProtoMessage() {}
+
+func (x *PredictRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PredictRequest.ProtoReflect.Descriptor instead.
+func (*PredictRequest) Descriptor() ([]byte, []int) {
+	return file_api_tuner_v1_tuner_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *PredictRequest) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *PredictRequest) GetAccelerator() string {
+	if x != nil {
+		return x.Accelerator
+	}
+	return ""
+}
+
+func (x *PredictRequest) GetPoints() []*OperatingPoint {
+	if x != nil {
+		return x.Points
+	}
+	return nil
+}
+
+func (x *PredictRequest) GetConfidence() float64 {
+	if x != nil {
+		return x.Confidence
+	}
+	return 0
+}
+
+type PredictResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Model         string                 `protobuf:"bytes,1,opt,name=model,proto3" json:"model,omitempty"`
+	Accelerator   string                 `protobuf:"bytes,2,opt,name=accelerator,proto3" json:"accelerator,omitempty"`
+	Predictions   []*Prediction          `protobuf:"bytes,3,rep,name=predictions,proto3" json:"predictions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PredictResponse) Reset() {
+	*x = PredictResponse{}
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PredictResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PredictResponse) ProtoMessage() {}
+
+func (x *PredictResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PredictResponse.ProtoReflect.Descriptor instead.
+func (*PredictResponse) Descriptor() ([]byte, []int) {
+	return file_api_tuner_v1_tuner_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *PredictResponse) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *PredictResponse) GetAccelerator() string {
+	if x != nil {
+		return x.Accelerator
+	}
+	return ""
+}
+
+func (x *PredictResponse) GetPredictions() []*Prediction {
+	if x != nil {
+		return x.Predictions
+	}
+	return nil
+}
+
+// OperatingPoint is a load at which to evaluate a pair (service.OperatingPoint).
+type OperatingPoint struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ArrivalRate   float32                `protobuf:"fixed32,1,opt,name=arrival_rate,json=arrivalRate,proto3" json:"arrival_rate,omitempty"` // req/min
+	AvgInTokens   int32                  `protobuf:"varint,2,opt,name=avg_in_tokens,json=avgInTokens,proto3" json:"avg_in_tokens,omitempty"`
+	AvgOutTokens  int32                  `protobuf:"varint,3,opt,name=avg_out_tokens,json=avgOutTokens,proto3" json:"avg_out_tokens,omitempty"`
+	MaxBatchSize  int32                  `protobuf:"varint,4,opt,name=max_batch_size,json=maxBatchSize,proto3" json:"max_batch_size,omitempty"`
+	MaxQueueSize  int32                  `protobuf:"varint,5,opt,name=max_queue_size,json=maxQueueSize,proto3" json:"max_queue_size,omitempty"` // 0 = no external queue
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OperatingPoint) Reset() {
+	*x = OperatingPoint{}
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OperatingPoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OperatingPoint) ProtoMessage() {}
+
+func (x *OperatingPoint) ProtoReflect() protoreflect.Message {
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OperatingPoint.ProtoReflect.Descriptor instead.
+func (*OperatingPoint) Descriptor() ([]byte, []int) {
+	return file_api_tuner_v1_tuner_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *OperatingPoint) GetArrivalRate() float32 {
+	if x != nil {
+		return x.ArrivalRate
+	}
+	return 0
+}
+
+func (x *OperatingPoint) GetAvgInTokens() int32 {
+	if x != nil {
+		return x.AvgInTokens
+	}
+	return 0
+}
+
+func (x *OperatingPoint) GetAvgOutTokens() int32 {
+	if x != nil {
+		return x.AvgOutTokens
+	}
+	return 0
+}
+
+func (x *OperatingPoint) GetMaxBatchSize() int32 {
+	if x != nil {
+		return x.MaxBatchSize
+	}
+	return 0
+}
+
+func (x *OperatingPoint) GetMaxQueueSize() int32 {
+	if x != nil {
+		return x.MaxQueueSize
+	}
+	return 0
+}
+
+// Prediction is the queueing-model estimate at one operating point (service.Prediction).
+type Prediction struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Point         *OperatingPoint        `protobuf:"bytes,1,opt,name=point,proto3" json:"point,omitempty"`
+	Ttft          float32                `protobuf:"fixed32,2,opt,name=ttft,proto3" json:"ttft,omitempty"`                         // msec
+	Itl           float32                `protobuf:"fixed32,3,opt,name=itl,proto3" json:"itl,omitempty"`                           // msec
+	WaitTime      float32                `protobuf:"fixed32,4,opt,name=wait_time,json=waitTime,proto3" json:"wait_time,omitempty"` // msec
+	Concurrency   float32                `protobuf:"fixed32,5,opt,name=concurrency,proto3" json:"concurrency,omitempty"`
+	Utilization   float32                `protobuf:"fixed32,6,opt,name=utilization,proto3" json:"utilization,omitempty"`
+	Throughput    float32                `protobuf:"fixed32,7,opt,name=throughput,proto3" json:"throughput,omitempty"`          // req/min
+	MaxRate       float32                `protobuf:"fixed32,8,opt,name=max_rate,json=maxRate,proto3" json:"max_rate,omitempty"` // req/min
+	Overloaded    bool                   `protobuf:"varint,9,opt,name=overloaded,proto3" json:"overloaded,omitempty"`
+	Interval      *PredictionInterval    `protobuf:"bytes,10,opt,name=interval,proto3" json:"interval,omitempty"` // unset without a requested confidence or a covariance
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Prediction) Reset() {
+	*x = Prediction{}
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Prediction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Prediction) ProtoMessage() {}
+
+func (x *Prediction) ProtoReflect() protoreflect.Message {
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Prediction.ProtoReflect.Descriptor instead.
+func (*Prediction) Descriptor() ([]byte, []int) {
+	return file_api_tuner_v1_tuner_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Prediction) GetPoint() *OperatingPoint {
+	if x != nil {
+		return x.Point
+	}
+	return nil
+}
+
+func (x *Prediction) GetTtft() float32 {
+	if x != nil {
+		return x.Ttft
+	}
+	return 0
+}
+
+func (x *Prediction) GetItl() float32 {
+	if x != nil {
+		return x.Itl
+	}
+	return 0
+}
+
+func (x *Prediction) GetWaitTime() float32 {
+	if x != nil {
+		return x.WaitTime
+	}
+	return 0
+}
+
+func (x *Prediction) GetConcurrency() float32 {
+	if x != nil {
+		return x.Concurrency
+	}
+	return 0
+}
+
+func (x *Prediction) GetUtilization() float32 {
+	if x != nil {
+		return x.Utilization
+	}
+	return 0
+}
+
+func (x *Prediction) GetThroughput() float32 {
+	if x != nil {
+		return x.Throughput
+	}
+	return 0
+}
+
+func (x *Prediction) GetMaxRate() float32 {
+	if x != nil {
+		return x.MaxRate
+	}
+	return 0
+}
+
+func (x *Prediction) GetOverloaded() bool {
+	if x != nil {
+		return x.Overloaded
+	}
+	return false
+}
+
+func (x *Prediction) GetInterval() *PredictionInterval {
+	if x != nil {
+		return x.Interval
+	}
+	return nil
+}
+
+// PredictionInterval bounds TTFT and ITL over the parameter uncertainty
+// (service.PredictionInterval).
+type PredictionInterval struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Confidence    float64                `protobuf:"fixed64,1,opt,name=confidence,proto3" json:"confidence,omitempty"`
+	Source        string                 `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`                       // "ekf" or "fit"
+	TtftLow       float32                `protobuf:"fixed32,3,opt,name=ttft_low,json=ttftLow,proto3" json:"ttft_low,omitempty"`    // msec
+	TtftHigh      float32                `protobuf:"fixed32,4,opt,name=ttft_high,json=ttftHigh,proto3" json:"ttft_high,omitempty"` // msec
+	ItlLow        float32                `protobuf:"fixed32,5,opt,name=itl_low,json=itlLow,proto3" json:"itl_low,omitempty"`       // msec
+	ItlHigh       float32                `protobuf:"fixed32,6,opt,name=itl_high,json=itlHigh,proto3" json:"itl_high,omitempty"`    // msec
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PredictionInterval) Reset() {
+	*x = PredictionInterval{}
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PredictionInterval) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PredictionInterval) ProtoMessage() {}
+
+func (x *PredictionInterval) ProtoReflect() protoreflect.Message {
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PredictionInterval.ProtoReflect.Descriptor instead.
+func (*PredictionInterval) Descriptor() ([]byte, []int) {
+	return file_api_tuner_v1_tuner_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *PredictionInterval) GetConfidence() float64 {
+	if x != nil {
+		return x.Confidence
+	}
+	return 0
+}
+
+func (x *PredictionInterval) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *PredictionInterval) GetTtftLow() float32 {
+	if x != nil {
+		return x.TtftLow
+	}
+	return 0
+}
+
+func (x *PredictionInterval) GetTtftHigh() float32 {
+	if x != nil {
+		return x.TtftHigh
+	}
+	return 0
+}
+
+func (x *PredictionInterval) GetItlLow() float32 {
+	if x != nil {
+		return x.ItlLow
+	}
+	return 0
+}
+
+func (x *PredictionInterval) GetItlHigh() float32 {
+	if x != nil {
+		return x.ItlHigh
+	}
+	return 0
+}
+
+type CapacityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Model         string                 `protobuf:"bytes,1,opt,name=model,proto3" json:"model,omitempty"`
+	Accelerator   string                 `protobuf:"bytes,2,opt,name=accelerator,proto3" json:"accelerator,omitempty"`
+	AvgInTokens   int32                  `protobuf:"varint,3,opt,name=avg_in_tokens,json=avgInTokens,proto3" json:"avg_in_tokens,omitempty"`
+	AvgOutTokens  int32                  `protobuf:"varint,4,opt,name=avg_out_tokens,json=avgOutTokens,proto3" json:"avg_out_tokens,omitempty"`
+	MaxBatchSize  int32                  `protobuf:"varint,5,opt,name=max_batch_size,json=maxBatchSize,proto3" json:"max_batch_size,omitempty"`
+	MaxQueueSize  int32                  `protobuf:"varint,6,opt,name=max_queue_size,json=maxQueueSize,proto3" json:"max_queue_size,omitempty"` // 0 = no external queue
+	TargetTtft    float32                `protobuf:"fixed32,7,opt,name=target_ttft,json=targetTtft,proto3" json:"target_ttft,omitempty"`        // msec; 0 leaves TTFT unconstrained
+	TargetItl     float32                `protobuf:"fixed32,8,opt,name=target_itl,json=targetItl,proto3" json:"target_itl,omitempty"`           // msec; 0 leaves ITL unconstrained
+	Confidence    float64                `protobuf:"fixed64,9,opt,name=confidence,proto3" json:"confidence,omitempty"`                          // quantile of the mean latencies over the parameter uncertainty; 0 for the nominal parameters
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CapacityRequest) Reset() {
+	*x = CapacityRequest{}
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CapacityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CapacityRequest) ProtoMessage() {}
+
+func (x *CapacityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CapacityRequest.ProtoReflect.Descriptor instead.
+func (*CapacityRequest) Descriptor() ([]byte, []int) {
+	return file_api_tuner_v1_tuner_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CapacityRequest) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *CapacityRequest) GetAccelerator() string {
+	if x != nil {
+		return x.Accelerator
+	}
+	return ""
+}
+
+func (x *CapacityRequest) GetAvgInTokens() int32 {
+	if x != nil {
+		return x.AvgInTokens
+	}
+	return 0
+}
+
+func (x *CapacityRequest) GetAvgOutTokens() int32 {
+	if x != nil {
+		return x.AvgOutTokens
+	}
+	return 0
+}
+
+func (x *CapacityRequest) GetMaxBatchSize() int32 {
+	if x != nil {
+		return x.MaxBatchSize
+	}
+	return 0
+}
+
+func (x *CapacityRequest) GetMaxQueueSize() int32 {
+	if x != nil {
+		return x.MaxQueueSize
+	}
+	return 0
+}
+
+func (x *CapacityRequest) GetTargetTtft() float32 {
+	if x != nil {
+		return x.TargetTtft
+	}
+	return 0
+}
+
+func (x *CapacityRequest) GetTargetItl() float32 {
+	if x != nil {
+		return x.TargetItl
+	}
+	return 0
+}
+
+func (x *CapacityRequest) GetConfidence() float64 {
+	if x != nil {
+		return x.Confidence
+	}
+	return 0
+}
+
+// CapacityResponse is the capacity of one replica within the targets (service.Capacity).
+type CapacityResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Model         string                 `protobuf:"bytes,1,opt,name=model,proto3" json:"model,omitempty"`
+	Accelerator   string                 `protobuf:"bytes,2,opt,name=accelerator,proto3" json:"accelerator,omitempty"`
+	MaxRate       float32                `protobuf:"fixed32,3,opt,name=max_rate,json=maxRate,proto3" json:"max_rate,omitempty"`                   // req/min
+	Binding       string                 `protobuf:"bytes,4,opt,name=binding,proto3" json:"binding,omitempty"`                                    // "ttft", "itl" or "stability"
+	RateTtft      float32                `protobuf:"fixed32,5,opt,name=rate_ttft,json=rateTtft,proto3" json:"rate_ttft,omitempty"`                // req/min
+	RateItl       float32                `protobuf:"fixed32,6,opt,name=rate_itl,json=rateItl,proto3" json:"rate_itl,omitempty"`                   // req/min
+	RateStability float32                `protobuf:"fixed32,7,opt,name=rate_stability,json=rateStability,proto3" json:"rate_stability,omitempty"` // req/min
+	Ttft          float32                `protobuf:"fixed32,8,opt,name=ttft,proto3" json:"ttft,omitempty"`                                        // msec, at max_rate
+	Itl           float32                `protobuf:"fixed32,9,opt,name=itl,proto3" json:"itl,omitempty"`                                          // msec, at max_rate
+	Concurrency   float32                `protobuf:"fixed32,10,opt,name=concurrency,proto3" json:"concurrency,omitempty"`                         // at max_rate
+	Sensitivity   *ParameterSensitivity  `protobuf:"bytes,11,opt,name=sensitivity,proto3" json:"sensitivity,omitempty"`
+	Source        string                 `protobuf:"bytes,12,opt,name=source,proto3" json:"source,omitempty"` // covariance behind a confidence level: "ekf" or "fit"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CapacityResponse) Reset() {
+	*x = CapacityResponse{}
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CapacityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CapacityResponse) ProtoMessage() {}
+
+func (x *CapacityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CapacityResponse.ProtoReflect.Descriptor instead.
+func (*CapacityResponse) Descriptor() ([]byte, []int) {
+	return file_api_tuner_v1_tuner_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CapacityResponse) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *CapacityResponse) GetAccelerator() string {
+	if x != nil {
+		return x.Accelerator
+	}
+	return ""
+}
+
+func (x *CapacityResponse) GetMaxRate() float32 {
+	if x != nil {
+		return x.MaxRate
+	}
+	return 0
+}
+
+func (x *CapacityResponse) GetBinding() string {
+	if x != nil {
+		return x.Binding
+	}
+	return ""
+}
+
+func (x *CapacityResponse) GetRateTtft() float32 {
+	if x != nil {
+		return x.RateTtft
+	}
+	return 0
+}
+
+func (x *CapacityResponse) GetRateItl() float32 {
+	if x != nil {
+		return x.RateItl
+	}
+	return 0
+}
+
+func (x *CapacityResponse) GetRateStability() float32 {
+	if x != nil {
+		return x.RateStability
+	}
+	return 0
+}
+
+func (x *CapacityResponse) GetTtft() float32 {
+	if x != nil {
+		return x.Ttft
+	}
+	return 0
+}
+
+func (x *CapacityResponse) GetItl() float32 {
+	if x != nil {
+		return x.Itl
+	}
+	return 0
+}
+
+func (x *CapacityResponse) GetConcurrency() float32 {
+	if x != nil {
+		return x.Concurrency
+	}
+	return 0
+}
+
+func (x *CapacityResponse) GetSensitivity() *ParameterSensitivity {
+	if x != nil {
+		return x.Sensitivity
+	}
+	return nil
+}
+
+func (x *CapacityResponse) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+// ParameterSensitivity is the elasticity of the capacity to each parameter
+// (service.ParameterSensitivity).
+type ParameterSensitivity struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Alpha         float64                `protobuf:"fixed64,1,opt,name=alpha,proto3" json:"alpha,omitempty"`
+	Beta          float64                `protobuf:"fixed64,2,opt,name=beta,proto3" json:"beta,omitempty"`
+	Gamma         float64                `protobuf:"fixed64,3,opt,name=gamma,proto3" json:"gamma,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ParameterSensitivity) Reset() {
+	*x = ParameterSensitivity{}
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ParameterSensitivity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ParameterSensitivity) ProtoMessage() {}
+
+func (x *ParameterSensitivity) ProtoReflect() protoreflect.Message {
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ParameterSensitivity.ProtoReflect.Descriptor instead.
+func (*ParameterSensitivity) Descriptor() ([]byte, []int) {
+	return file_api_tuner_v1_tuner_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ParameterSensitivity) GetAlpha() float64 {
+	if x != nil {
+		return x.Alpha
+	}
+	return 0
+}
+
+func (x *ParameterSensitivity) GetBeta() float64 {
+	if x != nil {
+		return x.Beta
+	}
+	return 0
+}
+
+func (x *ParameterSensitivity) GetGamma() float64 {
+	if x != nil {
+		return x.Gamma
+	}
+	return 0
+}
+
+type CompareShadowsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompareShadowsRequest) Reset() {
+	*x = CompareShadowsRequest{}
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompareShadowsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompareShadowsRequest) ProtoMessage() {}
+
+func (x *CompareShadowsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompareShadowsRequest.ProtoReflect.Descriptor instead.
+func (*CompareShadowsRequest) Descriptor() ([]byte, []int) {
+	return file_api_tuner_v1_tuner_proto_rawDescGZIP(), []int{14}
+}
+
+type PromoteShadowRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PromoteShadowRequest) Reset() {
+	*x = PromoteShadowRequest{}
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromoteShadowRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromoteShadowRequest) ProtoMessage() {}
+
+func (x *PromoteShadowRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromoteShadowRequest.ProtoReflect.Descriptor instead.
+func (*PromoteShadowRequest) Descriptor() ([]byte, []int) {
+	return file_api_tuner_v1_tuner_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *PromoteShadowRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// ShadowComparison compares the primary estimator with its shadows (service.ShadowComparison).
+type ShadowComparison struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Window        int32                  `protobuf:"varint,1,opt,name=window,proto3" json:"window,omitempty"`        // cycles per pair in the rolling error
+	Estimators    []*EstimatorScore      `protobuf:"bytes,2,rep,name=estimators,proto3" json:"estimators,omitempty"` // the primary first
+	Pairs         []*PairComparison      `protobuf:"bytes,3,rep,name=pairs,proto3" json:"pairs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShadowComparison) Reset() {
+	*x = ShadowComparison{}
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShadowComparison) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShadowComparison) ProtoMessage() {}
+
+func (x *ShadowComparison) ProtoReflect() protoreflect.Message {
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShadowComparison.ProtoReflect.Descriptor instead.
+func (*ShadowComparison) Descriptor() ([]byte, []int) {
+	return file_api_tuner_v1_tuner_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ShadowComparison) GetWindow() int32 {
+	if x != nil {
+		return x.Window
+	}
+	return 0
+}
+
+func (x *ShadowComparison) GetEstimators() []*EstimatorScore {
+	if x != nil {
+		return x.Estimators
+	}
+	return nil
+}
+
+func (x *ShadowComparison) GetPairs() []*PairComparison {
+	if x != nil {
+		return x.Pairs
+	}
+	return nil
+}
+
+// EstimatorConfig configures one estimator (service.EstimatorConfig).
+type EstimatorConfig struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Name              string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Mode              string                 `protobuf:"bytes,2,opt,name=mode,proto3" json:"mode,omitempty"` // "ekf" or "sliding-window"
+	InitObs           int32                  `protobuf:"varint,3,opt,name=init_obs,json=initObs,proto3" json:"init_obs,omitempty"`
+	WindowSize        int32                  `protobuf:"varint,4,opt,name=window_size,json=windowSize,proto3" json:"window_size,omitempty"`
+	ResidualThreshold float64                `protobuf:"fixed64,5,opt,name=residual_threshold,json=residualThreshold,proto3" json:"residual_threshold,omitempty"`
+	InitFitThreshold  float64                `protobuf:"fixed64,6,opt,name=init_fit_threshold,json=initFitThreshold,proto3" json:"init_fit_threshold,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *EstimatorConfig) Reset() {
+	*x = EstimatorConfig{}
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EstimatorConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EstimatorConfig) ProtoMessage() {}
+
+func (x *EstimatorConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EstimatorConfig.ProtoReflect.Descriptor instead.
+func (*EstimatorConfig) Descriptor() ([]byte, []int) {
+	return file_api_tuner_v1_tuner_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *EstimatorConfig) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *EstimatorConfig) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *EstimatorConfig) GetInitObs() int32 {
+	if x != nil {
+		return x.InitObs
+	}
+	return 0
+}
+
+func (x *EstimatorConfig) GetWindowSize() int32 {
+	if x != nil {
+		return x.WindowSize
+	}
+	return 0
+}
+
+func (x *EstimatorConfig) GetResidualThreshold() float64 {
+	if x != nil {
+		return x.ResidualThreshold
+	}
+	return 0
+}
+
+func (x *EstimatorConfig) GetInitFitThreshold() float64 {
+	if x != nil {
+		return x.InitFitThreshold
+	}
+	return 0
+}
+
+// EstimatorScore is the rolling error of one estimator over all pairs (service.EstimatorScore).
+type EstimatorScore struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Config        *EstimatorConfig       `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
+	Primary       bool                   `protobuf:"varint,2,opt,name=primary,proto3" json:"primary,omitempty"`
+	Cycles        int32                  `protobuf:"varint,3,opt,name=cycles,proto3" json:"cycles,omitempty"`
+	RmsError      float64                `protobuf:"fixed64,4,opt,name=rms_error,json=rmsError,proto3" json:"rms_error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EstimatorScore) Reset() {
+	*x = EstimatorScore{}
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EstimatorScore) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EstimatorScore) ProtoMessage() {}
+
+func (x *EstimatorScore) ProtoReflect() protoreflect.Message {
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EstimatorScore.ProtoReflect.Descriptor instead.
+func (*EstimatorScore) Descriptor() ([]byte, []int) {
+	return file_api_tuner_v1_tuner_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *EstimatorScore) GetConfig() *EstimatorConfig {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+func (x *EstimatorScore) GetPrimary() bool {
+	if x != nil {
+		return x.Primary
+	}
+	return false
+}
+
+func (x *EstimatorScore) GetCycles() int32 {
+	if x != nil {
+		return x.Cycles
+	}
+	return 0
+}
+
+func (x *EstimatorScore) GetRmsError() float64 {
+	if x != nil {
+		return x.RmsError
+	}
+	return 0
+}
+
+// PairComparison holds the estimators' scores for one pair (service.PairComparison).
+type PairComparison struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Model         string                 `protobuf:"bytes,1,opt,name=model,proto3" json:"model,omitempty"`
+	Accelerator   string                 `protobuf:"bytes,2,opt,name=accelerator,proto3" json:"accelerator,omitempty"`
+	Estimators    []*PairScore           `protobuf:"bytes,3,rep,name=estimators,proto3" json:"estimators,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PairComparison) Reset() {
+	*x = PairComparison{}
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PairComparison) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PairComparison) ProtoMessage() {}
+
+func (x *PairComparison) ProtoReflect() protoreflect.Message {
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PairComparison.ProtoReflect.Descriptor instead.
+func (*PairComparison) Descriptor() ([]byte, []int) {
+	return file_api_tuner_v1_tuner_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *PairComparison) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *PairComparison) GetAccelerator() string {
+	if x != nil {
+		return x.Accelerator
+	}
+	return ""
+}
+
+func (x *PairComparison) GetEstimators() []*PairScore {
+	if x != nil {
+		return x.Estimators
+	}
+	return nil
+}
+
+// PairScore is one estimator's parameters and rolling error for one pair (service.PairScore).
+type PairScore struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	HasParams     bool                   `protobuf:"varint,2,opt,name=has_params,json=hasParams,proto3" json:"has_params,omitempty"`
+	Alpha         float32                `protobuf:"fixed32,3,opt,name=alpha,proto3" json:"alpha,omitempty"`
+	Beta          float32                `protobuf:"fixed32,4,opt,name=beta,proto3" json:"beta,omitempty"`
+	Gamma         float32                `protobuf:"fixed32,5,opt,name=gamma,proto3" json:"gamma,omitempty"`
+	Cycles        int32                  `protobuf:"varint,6,opt,name=cycles,proto3" json:"cycles,omitempty"`
+	RmsError      float64                `protobuf:"fixed64,7,opt,name=rms_error,json=rmsError,proto3" json:"rms_error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PairScore) Reset() {
+	*x = PairScore{}
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PairScore) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PairScore) ProtoMessage() {}
+
+func (x *PairScore) ProtoReflect() protoreflect.Message {
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PairScore.ProtoReflect.Descriptor instead.
+func (*PairScore) Descriptor() ([]byte, []int) {
+	return file_api_tuner_v1_tuner_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *PairScore) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PairScore) GetHasParams() bool {
+	if x != nil {
+		return x.HasParams
+	}
+	return false
+}
+
+func (x *PairScore) GetAlpha() float32 {
+	if x != nil {
+		return x.Alpha
+	}
+	return 0
+}
+
+func (x *PairScore) GetBeta() float32 {
+	if x != nil {
+		return x.Beta
+	}
+	return 0
+}
+
+func (x *PairScore) GetGamma() float32 {
+	if x != nil {
+		return x.Gamma
+	}
+	return 0
+}
+
+func (x *PairScore) GetCycles() int32 {
+	if x != nil {
+		return x.Cycles
+	}
+	return 0
+}
+
+func (x *PairScore) GetRmsError() float64 {
+	if x != nil {
+		return x.RmsError
+	}
+	return 0
+}
+
+type WarmUpRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WarmUpRequest) Reset() {
+	*x = WarmUpRequest{}
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WarmUpRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WarmUpRequest) ProtoMessage() {}
+
+func (x *WarmUpRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WarmUpRequest.ProtoReflect.Descriptor instead.
+func (*WarmUpRequest) Descriptor() ([]byte, []int) {
+	return file_api_tuner_v1_tuner_proto_rawDescGZIP(), []int{21}
+}
+
+type WarmUpResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WarmingUp     bool                   `protobuf:"varint,1,opt,name=warming_up,json=warmingUp,proto3" json:"warming_up,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WarmUpResponse) Reset() {
+	*x = WarmUpResponse{}
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WarmUpResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WarmUpResponse) ProtoMessage() {}
+
+func (x *WarmUpResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WarmUpResponse.ProtoReflect.Descriptor instead.
+func (*WarmUpResponse) Descriptor() ([]byte, []int) {
+	return file_api_tuner_v1_tuner_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *WarmUpResponse) GetWarmingUp() bool {
+	if x != nil {
+		return x.WarmingUp
+	}
+	return false
+}
+
+type CalibrationStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CalibrationStatusRequest) Reset() {
+	*x = CalibrationStatusRequest{}
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CalibrationStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CalibrationStatusRequest) ProtoMessage() {}
+
+func (x *CalibrationStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CalibrationStatusRequest.ProtoReflect.Descriptor instead.
+func (*CalibrationStatusRequest) Descriptor() ([]byte, []int) {
+	return file_api_tuner_v1_tuner_proto_rawDescGZIP(), []int{23}
+}
+
+type CalibrationStatusResponse struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Statuses      []*PairCalibrationStatus `protobuf:"bytes,1,rep,name=statuses,proto3" json:"statuses,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CalibrationStatusResponse) Reset() {
+	*x = CalibrationStatusResponse{}
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CalibrationStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CalibrationStatusResponse) ProtoMessage() {}
+
+func (x *CalibrationStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CalibrationStatusResponse.ProtoReflect.Descriptor instead.
+func (*CalibrationStatusResponse) Descriptor() ([]byte, []int) {
+	return file_api_tuner_v1_tuner_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *CalibrationStatusResponse) GetStatuses() []*PairCalibrationStatus {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+// ServerSpec is one replica observation (optimizer-light config.ServerSpec).
+type ServerSpec struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Name            string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Class           string                 `protobuf:"bytes,2,opt,name=class,proto3" json:"class,omitempty"`
+	Model           string                 `protobuf:"bytes,3,opt,name=model,proto3" json:"model,omitempty"`
+	KeepAccelerator bool                   `protobuf:"varint,4,opt,name=keep_accelerator,json=keepAccelerator,proto3" json:"keep_accelerator,omitempty"`
+	MinNumReplicas  int32                  `protobuf:"varint,5,opt,name=min_num_replicas,json=minNumReplicas,proto3" json:"min_num_replicas,omitempty"`
+	MaxBatchSize    int32                  `protobuf:"varint,6,opt,name=max_batch_size,json=maxBatchSize,proto3" json:"max_batch_size,omitempty"`
+	MaxQueueSize    int32                  `protobuf:"varint,7,opt,name=max_queue_size,json=maxQueueSize,proto3" json:"max_queue_size,omitempty"`
+	CurrentAlloc    *AllocationData        `protobuf:"bytes,8,opt,name=current_alloc,json=currentAlloc,proto3" json:"current_alloc,omitempty"`
+	DesiredAlloc    *AllocationData        `protobuf:"bytes,9,opt,name=desired_alloc,json=desiredAlloc,proto3" json:"desired_alloc,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ServerSpec) Reset() {
+	*x = ServerSpec{}
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServerSpec) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServerSpec) ProtoMessage() {}
+
+func (x *ServerSpec) ProtoReflect() protoreflect.Message {
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServerSpec.ProtoReflect.Descriptor instead.
+func (*ServerSpec) Descriptor() ([]byte, []int) {
+	return file_api_tuner_v1_tuner_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ServerSpec) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ServerSpec) GetClass() string {
+	if x != nil {
+		return x.Class
+	}
+	return ""
+}
+
+func (x *ServerSpec) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *ServerSpec) GetKeepAccelerator() bool {
+	if x != nil {
+		return x.KeepAccelerator
+	}
+	return false
+}
+
+func (x *ServerSpec) GetMinNumReplicas() int32 {
+	if x != nil {
+		return x.MinNumReplicas
+	}
+	return 0
+}
+
+func (x *ServerSpec) GetMaxBatchSize() int32 {
+	if x != nil {
+		return x.MaxBatchSize
+	}
+	return 0
+}
+
+func (x *ServerSpec) GetMaxQueueSize() int32 {
+	if x != nil {
+		return x.MaxQueueSize
+	}
+	return 0
+}
+
+func (x *ServerSpec) GetCurrentAlloc() *AllocationData {
+	if x != nil {
+		return x.CurrentAlloc
+	}
+	return nil
+}
+
+func (x *ServerSpec) GetDesiredAlloc() *AllocationData {
+	if x != nil {
+		return x.DesiredAlloc
+	}
+	return nil
+}
+
+type AllocationData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Accelerator   string                 `protobuf:"bytes,1,opt,name=accelerator,proto3" json:"accelerator,omitempty"`
+	NumReplicas   int32                  `protobuf:"varint,2,opt,name=num_replicas,json=numReplicas,proto3" json:"num_replicas,omitempty"`
+	MaxBatch      int32                  `protobuf:"varint,3,opt,name=max_batch,json=maxBatch,proto3" json:"max_batch,omitempty"`
+	Cost          float32                `protobuf:"fixed32,4,opt,name=cost,proto3" json:"cost,omitempty"`
+	ItlAverage    float32                `protobuf:"fixed32,5,opt,name=itl_average,json=itlAverage,proto3" json:"itl_average,omitempty"`
+	TtftAverage   float32                `protobuf:"fixed32,6,opt,name=ttft_average,json=ttftAverage,proto3" json:"ttft_average,omitempty"`
+	Load          *ServerLoadSpec        `protobuf:"bytes,7,opt,name=load,proto3" json:"load,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AllocationData) Reset() {
+	*x = AllocationData{}
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AllocationData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AllocationData) ProtoMessage() {}
+
+func (x *AllocationData) ProtoReflect() protoreflect.Message {
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AllocationData.ProtoReflect.Descriptor instead.
+func (*AllocationData) Descriptor() ([]byte, []int) {
+	return file_api_tuner_v1_tuner_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *AllocationData) GetAccelerator() string {
+	if x != nil {
+		return x.Accelerator
+	}
+	return ""
+}
+
+func (x *AllocationData) GetNumReplicas() int32 {
+	if x != nil {
+		return x.NumReplicas
+	}
+	return 0
+}
+
+func (x *AllocationData) GetMaxBatch() int32 {
+	if x != nil {
+		return x.MaxBatch
+	}
+	return 0
+}
+
+func (x *AllocationData) GetCost() float32 {
+	if x != nil {
+		return x.Cost
+	}
+	return 0
+}
+
+func (x *AllocationData) GetItlAverage() float32 {
+	if x != nil {
+		return x.ItlAverage
+	}
+	return 0
+}
+
+func (x *AllocationData) GetTtftAverage() float32 {
+	if x != nil {
+		return x.TtftAverage
+	}
+	return 0
+}
+
+func (x *AllocationData) GetLoad() *ServerLoadSpec {
+	if x != nil {
+		return x.Load
+	}
+	return nil
+}
+
+type ServerLoadSpec struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ArrivalRate   float32                `protobuf:"fixed32,1,opt,name=arrival_rate,json=arrivalRate,proto3" json:"arrival_rate,omitempty"` // req/min
+	Throughput    float32                `protobuf:"fixed32,2,opt,name=throughput,proto3" json:"throughput,omitempty"`                      // req/min
+	AvgInTokens   int32                  `protobuf:"varint,3,opt,name=avg_in_tokens,json=avgInTokens,proto3" json:"avg_in_tokens,omitempty"`
+	AvgOutTokens  int32                  `protobuf:"varint,4,opt,name=avg_out_tokens,json=avgOutTokens,proto3" json:"avg_out_tokens,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ServerLoadSpec) Reset() {
+	*x = ServerLoadSpec{}
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServerLoadSpec) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServerLoadSpec) ProtoMessage() {}
+
+func (x *ServerLoadSpec) ProtoReflect() protoreflect.Message {
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServerLoadSpec.ProtoReflect.Descriptor instead.
+func (*ServerLoadSpec) Descriptor() ([]byte, []int) {
+	return file_api_tuner_v1_tuner_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ServerLoadSpec) GetArrivalRate() float32 {
+	if x != nil {
+		return x.ArrivalRate
+	}
+	return 0
+}
+
+func (x *ServerLoadSpec) GetThroughput() float32 {
+	if x != nil {
+		return x.Throughput
+	}
+	return 0
+}
+
+func (x *ServerLoadSpec) GetAvgInTokens() int32 {
+	if x != nil {
+		return x.AvgInTokens
+	}
+	return 0
+}
+
+func (x *ServerLoadSpec) GetAvgOutTokens() int32 {
+	if x != nil {
+		return x.AvgOutTokens
+	}
+	return 0
+}
+
+// ModelData is the performance data of model/accelerator pairs (optimizer-light
+// config.ModelData).
+type ModelData struct {
+	state         protoimpl.MessageState      `protogen:"open.v1"`
+	Models        []*ModelAcceleratorPerfData `protobuf:"bytes,1,rep,name=models,proto3" json:"models,omitempty"`
+	Freshness     []*PairFreshness            `protobuf:"bytes,2,rep,name=freshness,proto3" json:"freshness,omitempty"` // set on Merge responses only
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModelData) Reset() {
+	*x = ModelData{}
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModelData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModelData) ProtoMessage() {}
+
+func (x *ModelData) ProtoReflect() protoreflect.Message {
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModelData.ProtoReflect.Descriptor instead.
+func (*ModelData) Descriptor() ([]byte, []int) {
+	return file_api_tuner_v1_tuner_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ModelData) GetModels() []*ModelAcceleratorPerfData {
+	if x != nil {
+		return x.Models
+	}
+	return nil
+}
+
+func (x *ModelData) GetFreshness() []*PairFreshness {
+	if x != nil {
+		return x.Freshness
+	}
+	return nil
+}
+
+// PairFreshness is the freshness of one tuned pair in a Merge (service.PairFreshness).
+type PairFreshness struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Model         string                 `protobuf:"bytes,1,opt,name=model,proto3" json:"model,omitempty"`
+	Accelerator   string                 `protobuf:"bytes,2,opt,name=accelerator,proto3" json:"accelerator,omitempty"`
+	Freshness     string                 `protobuf:"bytes,3,opt,name=freshness,proto3" json:"freshness,omitempty"` // "fresh" or "stale"
+	LastUpdated   *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=last_updated,json=lastUpdated,proto3" json:"last_updated,omitempty"`
+	Merged        bool                   `protobuf:"varint,5,opt,name=merged,proto3" json:"merged,omitempty"` // false if the stale parameters were left out
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PairFreshness) Reset() {
+	*x = PairFreshness{}
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PairFreshness) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PairFreshness) ProtoMessage() {}
+
+func (x *PairFreshness) ProtoReflect() protoreflect.Message {
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PairFreshness.ProtoReflect.Descriptor instead.
+func (*PairFreshness) Descriptor() ([]byte, []int) {
+	return file_api_tuner_v1_tuner_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *PairFreshness) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *PairFreshness) GetAccelerator() string {
+	if x != nil {
+		return x.Accelerator
+	}
+	return ""
+}
+
+func (x *PairFreshness) GetFreshness() string {
+	if x != nil {
+		return x.Freshness
+	}
+	return ""
+}
+
+func (x *PairFreshness) GetLastUpdated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUpdated
+	}
+	return nil
+}
+
+func (x *PairFreshness) GetMerged() bool {
+	if x != nil {
+		return x.Merged
+	}
+	return false
+}
+
+type ModelAcceleratorPerfData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Acc           string                 `protobuf:"bytes,2,opt,name=acc,proto3" json:"acc,omitempty"`
+	AccCount      int32                  `protobuf:"varint,3,opt,name=acc_count,json=accCount,proto3" json:"acc_count,omitempty"`
+	MaxBatchSize  int32                  `protobuf:"varint,4,opt,name=max_batch_size,json=maxBatchSize,proto3" json:"max_batch_size,omitempty"`
+	PerfParms     *PerfParms             `protobuf:"bytes,5,opt,name=perf_parms,json=perfParms,proto3" json:"perf_parms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModelAcceleratorPerfData) Reset() {
+	*x = ModelAcceleratorPerfData{}
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModelAcceleratorPerfData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModelAcceleratorPerfData) ProtoMessage() {}
+
+func (x *ModelAcceleratorPerfData) ProtoReflect() protoreflect.Message {
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModelAcceleratorPerfData.ProtoReflect.Descriptor instead.
+func (*ModelAcceleratorPerfData) Descriptor() ([]byte, []int) {
+	return file_api_tuner_v1_tuner_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ModelAcceleratorPerfData) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ModelAcceleratorPerfData) GetAcc() string {
+	if x != nil {
+		return x.Acc
+	}
+	return ""
+}
+
+func (x *ModelAcceleratorPerfData) GetAccCount() int32 {
+	if x != nil {
+		return x.AccCount
+	}
+	return 0
+}
+
+func (x *ModelAcceleratorPerfData) GetMaxBatchSize() int32 {
+	if x != nil {
+		return x.MaxBatchSize
+	}
+	return 0
+}
+
+func (x *ModelAcceleratorPerfData) GetPerfParms() *PerfParms {
+	if x != nil {
+		return x.PerfParms
+	}
+	return nil
+}
+
+type PerfParms struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Alpha         float32                `protobuf:"fixed32,1,opt,name=alpha,proto3" json:"alpha,omitempty"`
+	Beta          float32                `protobuf:"fixed32,2,opt,name=beta,proto3" json:"beta,omitempty"`
+	Gamma         float32                `protobuf:"fixed32,3,opt,name=gamma,proto3" json:"gamma,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PerfParms) Reset() {
+	*x = PerfParms{}
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PerfParms) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PerfParms) ProtoMessage() {}
+
+func (x *PerfParms) ProtoReflect() protoreflect.Message {
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PerfParms.ProtoReflect.Descriptor instead.
+func (*PerfParms) Descriptor() ([]byte, []int) {
+	return file_api_tuner_v1_tuner_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *PerfParms) GetAlpha() float32 {
+	if x != nil {
+		return x.Alpha
+	}
+	return 0
+}
+
+func (x *PerfParms) GetBeta() float32 {
+	if x != nil {
+		return x.Beta
+	}
+	return 0
+}
+
+func (x *PerfParms) GetGamma() float32 {
+	if x != nil {
+		return x.Gamma
+	}
+	return 0
+}
+
+// Parameters are the tuned parameters of one pair.
+type Parameters struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Model       string                 `protobuf:"bytes,1,opt,name=model,proto3" json:"model,omitempty"`
+	Accelerator string                 `protobuf:"bytes,2,opt,name=accelerator,proto3" json:"accelerator,omitempty"`
+	Alpha       float32                `protobuf:"fixed32,3,opt,name=alpha,proto3" json:"alpha,omitempty"`
+	Beta        float32                `protobuf:"fixed32,4,opt,name=beta,proto3" json:"beta,omitempty"`
+	Gamma       float32                `protobuf:"fixed32,5,opt,name=gamma,proto3" json:"gamma,omitempty"`
+	Nis         float64                `protobuf:"fixed64,6,opt,name=nis,proto3" json:"nis,omitempty"`
+	UpdateCount int32                  `protobuf:"varint,7,opt,name=update_count,json=updateCount,proto3" json:"update_count,omitempty"`
+	LastUpdated *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=last_updated,json=lastUpdated,proto3" json:"last_updated,omitempty"`
+	// The estimator's own fit, which the publication policy smoothed or limited into alpha, beta
+	// and gamma.
+	RawAlpha  float32 `protobuf:"fixed32,9,opt,name=raw_alpha,json=rawAlpha,proto3" json:"raw_alpha,omitempty"`
+	RawBeta   float32 `protobuf:"fixed32,10,opt,name=raw_beta,json=rawBeta,proto3" json:"raw_beta,omitempty"`
+	RawGamma  float32 `protobuf:"fixed32,11,opt,name=raw_gamma,json=rawGamma,proto3" json:"raw_gamma,omitempty"`
+	Freshness string  `protobuf:"bytes,12,opt,name=freshness,proto3" json:"freshness,omitempty"` // "fresh" or "stale"
+	// Where the pair's cold-start prior came from ("config", "catalog", "same-model" or
+	// "same-accelerator") and the matching catalog entry; empty if the pair has no prior.
+	PriorSource   string `protobuf:"bytes,13,opt,name=prior_source,json=priorSource,proto3" json:"prior_source,omitempty"`
+	CatalogEntry  string `protobuf:"bytes,14,opt,name=catalog_entry,json=catalogEntry,proto3" json:"catalog_entry,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Parameters) Reset() {
+	*x = Parameters{}
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Parameters) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Parameters) ProtoMessage() {}
+
+func (x *Parameters) ProtoReflect() protoreflect.Message {
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Parameters.ProtoReflect.Descriptor instead.
+func (*Parameters) Descriptor() ([]byte, []int) {
+	return file_api_tuner_v1_tuner_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *Parameters) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *Parameters) GetAccelerator() string {
+	if x != nil {
+		return x.Accelerator
+	}
+	return ""
+}
+
+func (x *Parameters) GetAlpha() float32 {
+	if x != nil {
+		return x.Alpha
+	}
+	return 0
+}
+
+func (x *Parameters) GetBeta() float32 {
+	if x != nil {
+		return x.Beta
+	}
+	return 0
+}
+
+func (x *Parameters) GetGamma() float32 {
+	if x != nil {
+		return x.Gamma
+	}
+	return 0
+}
+
+func (x *Parameters) GetNis() float64 {
+	if x != nil {
+		return x.Nis
+	}
+	return 0
+}
+
+func (x *Parameters) GetUpdateCount() int32 {
+	if x != nil {
+		return x.UpdateCount
+	}
+	return 0
+}
+
+func (x *Parameters) GetLastUpdated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUpdated
+	}
+	return nil
+}
+
+func (x *Parameters) GetRawAlpha() float32 {
+	if x != nil {
+		return x.RawAlpha
+	}
+	return 0
+}
+
+func (x *Parameters) GetRawBeta() float32 {
+	if x != nil {
+		return x.RawBeta
+	}
+	return 0
+}
+
+func (x *Parameters) GetRawGamma() float32 {
+	if x != nil {
+		return x.RawGamma
+	}
+	return 0
+}
+
+func (x *Parameters) GetFreshness() string {
+	if x != nil {
+		return x.Freshness
+	}
+	return ""
+}
+
+func (x *Parameters) GetPriorSource() string {
+	if x != nil {
+		return x.PriorSource
+	}
+	return ""
+}
+
+func (x *Parameters) GetCatalogEntry() string {
+	if x != nil {
+		return x.CatalogEntry
+	}
+	return ""
+}
+
+type DiagnosticsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Model         string                 `protobuf:"bytes,1,opt,name=model,proto3" json:"model,omitempty"`
+	Accelerator   string                 `protobuf:"bytes,2,opt,name=accelerator,proto3" json:"accelerator,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiagnosticsRequest) Reset() {
+	*x = DiagnosticsRequest{}
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiagnosticsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiagnosticsRequest) ProtoMessage() {}
+
+func (x *DiagnosticsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiagnosticsRequest.ProtoReflect.Descriptor instead.
+func (*DiagnosticsRequest) Descriptor() ([]byte, []int) {
+	return file_api_tuner_v1_tuner_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *DiagnosticsRequest) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *DiagnosticsRequest) GetAccelerator() string {
+	if x != nil {
+		return x.Accelerator
+	}
+	return ""
+}
+
+// DiagnosticsResponse is the goodness of fit of a pair's stored parameters (service.Diagnostics).
+type DiagnosticsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Model         string                 `protobuf:"bytes,1,opt,name=model,proto3" json:"model,omitempty"`
+	Accelerator   string                 `protobuf:"bytes,2,opt,name=accelerator,proto3" json:"accelerator,omitempty"`
+	Alpha         float32                `protobuf:"fixed32,3,opt,name=alpha,proto3" json:"alpha,omitempty"`
+	Beta          float32                `protobuf:"fixed32,4,opt,name=beta,proto3" json:"beta,omitempty"`
+	Gamma         float32                `protobuf:"fixed32,5,opt,name=gamma,proto3" json:"gamma,omitempty"`
+	Init          *FitDiagnostics        `protobuf:"bytes,6,opt,name=init,proto3" json:"init,omitempty"`       // unset if the tuner retains no init observations
+	Sliding       *FitDiagnostics        `protobuf:"bytes,7,opt,name=sliding,proto3" json:"sliding,omitempty"` // unset unless the pair is tuned by the sliding window
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiagnosticsResponse) Reset() {
+	*x = DiagnosticsResponse{}
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiagnosticsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiagnosticsResponse) ProtoMessage() {}
+
+func (x *DiagnosticsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiagnosticsResponse.ProtoReflect.Descriptor instead.
+func (*DiagnosticsResponse) Descriptor() ([]byte, []int) {
+	return file_api_tuner_v1_tuner_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *DiagnosticsResponse) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *DiagnosticsResponse) GetAccelerator() string {
+	if x != nil {
+		return x.Accelerator
+	}
+	return ""
+}
+
+func (x *DiagnosticsResponse) GetAlpha() float32 {
+	if x != nil {
+		return x.Alpha
+	}
+	return 0
+}
+
+func (x *DiagnosticsResponse) GetBeta() float32 {
+	if x != nil {
+		return x.Beta
+	}
+	return 0
+}
+
+func (x *DiagnosticsResponse) GetGamma() float32 {
+	if x != nil {
+		return x.Gamma
+	}
+	return 0
+}
+
+func (x *DiagnosticsResponse) GetInit() *FitDiagnostics {
+	if x != nil {
+		return x.Init
+	}
+	return nil
+}
+
+func (x *DiagnosticsResponse) GetSliding() *FitDiagnostics {
+	if x != nil {
+		return x.Sliding
+	}
+	return nil
+}
+
+// FitDiagnostics evaluates the parameters against one window (estimator.FitDiagnostics).
+type FitDiagnostics struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Observations    []*ObservationFit      `protobuf:"bytes,1,rep,name=observations,proto3" json:"observations,omitempty"` // oldest first
+	RmsResidual     float64                `protobuf:"fixed64,2,opt,name=rms_residual,json=rmsResidual,proto3" json:"rms_residual,omitempty"`
+	Identifiability *Identifiability       `protobuf:"bytes,3,opt,name=identifiability,proto3" json:"identifiability,omitempty"` // unset if underdetermined or not evaluable
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *FitDiagnostics) Reset() {
+	*x = FitDiagnostics{}
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FitDiagnostics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FitDiagnostics) ProtoMessage() {}
+
+func (x *FitDiagnostics) ProtoReflect() protoreflect.Message {
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FitDiagnostics.ProtoReflect.Descriptor instead.
+func (*FitDiagnostics) Descriptor() ([]byte, []int) {
+	return file_api_tuner_v1_tuner_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *FitDiagnostics) GetObservations() []*ObservationFit {
+	if x != nil {
+		return x.Observations
+	}
+	return nil
+}
+
+func (x *FitDiagnostics) GetRmsResidual() float64 {
+	if x != nil {
+		return x.RmsResidual
+	}
+	return 0
+}
+
+func (x *FitDiagnostics) GetIdentifiability() *Identifiability {
+	if x != nil {
+		return x.Identifiability
+	}
+	return nil
+}
+
+// ObservationFit compares one observation with the model (estimator.ObservationFit).
+type ObservationFit struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ArrivalRate   float64                `protobuf:"fixed64,1,opt,name=arrival_rate,json=arrivalRate,proto3" json:"arrival_rate,omitempty"` // req/min
+	AvgInTokens   float32                `protobuf:"fixed32,2,opt,name=avg_in_tokens,json=avgInTokens,proto3" json:"avg_in_tokens,omitempty"`
+	AvgOutTokens  float32                `protobuf:"fixed32,3,opt,name=avg_out_tokens,json=avgOutTokens,proto3" json:"avg_out_tokens,omitempty"`
+	MaxBatchSize  int32                  `protobuf:"varint,4,opt,name=max_batch_size,json=maxBatchSize,proto3" json:"max_batch_size,omitempty"`
+	MaxQueueSize  int32                  `protobuf:"varint,5,opt,name=max_queue_size,json=maxQueueSize,proto3" json:"max_queue_size,omitempty"`
+	ObservedTtft  float64                `protobuf:"fixed64,6,opt,name=observed_ttft,json=observedTtft,proto3" json:"observed_ttft,omitempty"`    // msec
+	PredictedTtft float64                `protobuf:"fixed64,7,opt,name=predicted_ttft,json=predictedTtft,proto3" json:"predicted_ttft,omitempty"` // msec
+	ObservedItl   float64                `protobuf:"fixed64,8,opt,name=observed_itl,json=observedItl,proto3" json:"observed_itl,omitempty"`       // msec
+	PredictedItl  float64                `protobuf:"fixed64,9,opt,name=predicted_itl,json=predictedItl,proto3" json:"predicted_itl,omitempty"`    // msec
+	ResidualTtft  float64                `protobuf:"fixed64,10,opt,name=residual_ttft,json=residualTtft,proto3" json:"residual_ttft,omitempty"`   // (predicted - observed) / observed
+	ResidualItl   float64                `protobuf:"fixed64,11,opt,name=residual_itl,json=residualItl,proto3" json:"residual_itl,omitempty"`      // (predicted - observed) / observed
+	Evaluated     bool                   `protobuf:"varint,12,opt,name=evaluated,proto3" json:"evaluated,omitempty"`
+	Outlier       bool                   `protobuf:"varint,13,opt,name=outlier,proto3" json:"outlier,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ObservationFit) Reset() {
+	*x = ObservationFit{}
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ObservationFit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ObservationFit) ProtoMessage() {}
+
+func (x *ObservationFit) ProtoReflect() protoreflect.Message {
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ObservationFit.ProtoReflect.Descriptor instead.
+func (*ObservationFit) Descriptor() ([]byte, []int) {
+	return file_api_tuner_v1_tuner_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ObservationFit) GetArrivalRate() float64 {
+	if x != nil {
+		return x.ArrivalRate
+	}
+	return 0
+}
+
+func (x *ObservationFit) GetAvgInTokens() float32 {
+	if x != nil {
+		return x.AvgInTokens
+	}
+	return 0
+}
+
+func (x *ObservationFit) GetAvgOutTokens() float32 {
+	if x != nil {
+		return x.AvgOutTokens
+	}
+	return 0
+}
+
+func (x *ObservationFit) GetMaxBatchSize() int32 {
+	if x != nil {
+		return x.MaxBatchSize
+	}
+	return 0
+}
+
+func (x *ObservationFit) GetMaxQueueSize() int32 {
+	if x != nil {
+		return x.MaxQueueSize
+	}
+	return 0
+}
+
+func (x *ObservationFit) GetObservedTtft() float64 {
+	if x != nil {
+		return x.ObservedTtft
+	}
+	return 0
+}
+
+func (x *ObservationFit) GetPredictedTtft() float64 {
+	if x != nil {
+		return x.PredictedTtft
+	}
+	return 0
+}
+
+func (x *ObservationFit) GetObservedItl() float64 {
+	if x != nil {
+		return x.ObservedItl
+	}
+	return 0
+}
+
+func (x *ObservationFit) GetPredictedItl() float64 {
+	if x != nil {
+		return x.PredictedItl
+	}
+	return 0
+}
+
+func (x *ObservationFit) GetResidualTtft() float64 {
+	if x != nil {
+		return x.ResidualTtft
+	}
+	return 0
+}
+
+func (x *ObservationFit) GetResidualItl() float64 {
+	if x != nil {
+		return x.ResidualItl
+	}
+	return 0
+}
+
+func (x *ObservationFit) GetEvaluated() bool {
+	if x != nil {
+		return x.Evaluated
+	}
+	return false
+}
+
+func (x *ObservationFit) GetOutlier() bool {
+	if x != nil {
+		return x.Outlier
+	}
+	return false
+}
+
+// Identifiability is the SVD of the log-parameter residual Jacobian
+// (estimator.Identifiability).
+type Identifiability struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	SingularValues  []float64              `protobuf:"fixed64,1,rep,packed,name=singular_values,json=singularValues,proto3" json:"singular_values,omitempty"` // descending
+	SingularVectors []*SingularVector      `protobuf:"bytes,2,rep,name=singular_vectors,json=singularVectors,proto3" json:"singular_vectors,omitempty"`       // one per singular value
+	ConditionNumber float64                `protobuf:"fixed64,3,opt,name=condition_number,json=conditionNumber,proto3" json:"condition_number,omitempty"`     // 0 if the smallest singular value vanishes
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Identifiability) Reset() {
+	*x = Identifiability{}
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Identifiability) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Identifiability) ProtoMessage() {}
+
+func (x *Identifiability) ProtoReflect() protoreflect.Message {
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Identifiability.ProtoReflect.Descriptor instead.
+func (*Identifiability) Descriptor() ([]byte, []int) {
+	return file_api_tuner_v1_tuner_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *Identifiability) GetSingularValues() []float64 {
+	if x != nil {
+		return x.SingularValues
+	}
+	return nil
+}
+
+func (x *Identifiability) GetSingularVectors() []*SingularVector {
+	if x != nil {
+		return x.SingularVectors
+	}
+	return nil
+}
+
+func (x *Identifiability) GetConditionNumber() float64 {
+	if x != nil {
+		return x.ConditionNumber
+	}
+	return 0
+}
+
+// SingularVector is a direction over (ln alpha, ln beta, ln gamma).
+type SingularVector struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Components    []float64              `protobuf:"fixed64,1,rep,packed,name=components,proto3" json:"components,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SingularVector) Reset() {
+	*x = SingularVector{}
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SingularVector) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SingularVector) ProtoMessage() {}
+
+func (x *SingularVector) ProtoReflect() protoreflect.Message {
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SingularVector.ProtoReflect.Descriptor instead.
+func (*SingularVector) Descriptor() ([]byte, []int) {
+	return file_api_tuner_v1_tuner_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *SingularVector) GetComponents() []float64 {
+	if x != nil {
+		return x.Components
+	}
+	return nil
+}
+
+type PairCalibrationStatus struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Model            string                 `protobuf:"bytes,1,opt,name=model,proto3" json:"model,omitempty"`
+	Accelerator      string                 `protobuf:"bytes,2,opt,name=accelerator,proto3" json:"accelerator,omitempty"`
+	StorePresent     bool                   `protobuf:"varint,3,opt,name=store_present,json=storePresent,proto3" json:"store_present,omitempty"`
+	Calibrated       bool                   `protobuf:"varint,4,opt,name=calibrated,proto3" json:"calibrated,omitempty"`
+	ObsCount         int32                  `protobuf:"varint,5,opt,name=obs_count,json=obsCount,proto3" json:"obs_count,omitempty"`
+	ObsTarget        int32                  `protobuf:"varint,6,opt,name=obs_target,json=obsTarget,proto3" json:"obs_target,omitempty"`
+	ConditionNumber  float64                `protobuf:"fixed64,7,opt,name=condition_number,json=conditionNumber,proto3" json:"condition_number,omitempty"`
+	IllConditioned   bool                   `protobuf:"varint,8,opt,name=ill_conditioned,json=illConditioned,proto3" json:"ill_conditioned,omitempty"`
+	NeedsCalibration bool                   `protobuf:"varint,9,opt,name=needs_calibration,json=needsCalibration,proto3" json:"needs_calibration,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *PairCalibrationStatus) Reset() {
+	*x = PairCalibrationStatus{}
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PairCalibrationStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PairCalibrationStatus) ProtoMessage() {}
+
+func (x *PairCalibrationStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_api_tuner_v1_tuner_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PairCalibrationStatus.ProtoReflect.Descriptor instead.
+func (*PairCalibrationStatus) Descriptor() ([]byte, []int) {
+	return file_api_tuner_v1_tuner_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *PairCalibrationStatus) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *PairCalibrationStatus) GetAccelerator() string {
+	if x != nil {
+		return x.Accelerator
+	}
+	return ""
+}
+
+func (x *PairCalibrationStatus) GetStorePresent() bool {
+	if x != nil {
+		return x.StorePresent
+	}
+	return false
+}
+
+func (x *PairCalibrationStatus) GetCalibrated() bool {
+	if x != nil {
+		return x.Calibrated
+	}
+	return false
+}
+
+func (x *PairCalibrationStatus) GetObsCount() int32 {
+	if x != nil {
+		return x.ObsCount
+	}
+	return 0
+}
+
+func (x *PairCalibrationStatus) GetObsTarget() int32 {
+	if x != nil {
+		return x.ObsTarget
+	}
+	return 0
+}
+
+func (x *PairCalibrationStatus) GetConditionNumber() float64 {
+	if x != nil {
+		return x.ConditionNumber
+	}
+	return 0
+}
+
+func (x *PairCalibrationStatus) GetIllConditioned() bool {
+	if x != nil {
+		return x.IllConditioned
+	}
+	return false
+}
+
+func (x *PairCalibrationStatus) GetNeedsCalibration() bool {
+	if x != nil {
+		return x.NeedsCalibration
+	}
+	return false
+}
+
+var File_api_tuner_v1_tuner_proto protoreflect.FileDescriptor
+
+const file_api_tuner_v1_tuner_proto_rawDesc = "" +
+	"\n" +
+	"\x18api/tuner/v1/tuner.proto\x12\btuner.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"H\n" +
+	"\vTuneRequest\x129\n" +
+	"\rreplica_specs\x18\x01 \x03(\v2\x14.tuner.v1.ServerSpecR\freplicaSpecs\"z\n" +
+	"\fTuneResponse\x12:\n" +
+	"\x06models\x18\x01 \x03(\v2\".tuner.v1.ModelAcceleratorPerfDataR\x06models\x12.\n" +
+	"\x06groups\x18\x02 \x03(\v2\x16.tuner.v1.GroupOutcomeR\x06groups\"\xe5\x03\n" +
+	"\fGroupOutcome\x12\x14\n" +
+	"\x05model\x18\x01 \x01(\tR\x05model\x12 \n" +
+	"\vaccelerator\x18\x02 \x01(\tR\vaccelerator\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\x12\x1c\n" +
+	"\testimator\x18\x05 \x01(\tR\testimator\x12!\n" +
+	"\fekf_fallback\x18\x06 \x01(\bR\vekfFallback\x12\x1d\n" +
+	"\n" +
+	"has_params\x18\a \x01(\bR\thasParams\x12\x14\n" +
+	"\x05fresh\x18\b \x01(\bR\x05fresh\x12!\n" +
+	"\fupdate_count\x18\t \x01(\x05R\vupdateCount\x12\x1a\n" +
+	"\breplicas\x18\n" +
+	" \x01(\x05R\breplicas\x12\x1a\n" +
+	"\baccepted\x18\v \x01(\x05R\baccepted\x12\x1a\n" +
+	"\brejected\x18\f \x01(\x05R\brejected\x12\x1b\n" +
+	"\tobs_count\x18\r \x01(\x05R\bobsCount\x12\x1d\n" +
+	"\n" +
+	"obs_target\x18\x0e \x01(\x05R\tobsTarget\x12!\n" +
+	"\fwindow_count\x18\x0f \x01(\x05R\vwindowCount\x12\x1f\n" +
+	"\vwindow_size\x18\x10 \x01(\x05R\n" +
+	"windowSize\">\n" +
+	"\x10CalibrateRequest\x12*\n" +
+	"\x05specs\x18\x01 \x03(\v2\x14.tuner.v1.ServerSpecR\x05specs\"J\n" +
+	"\x10GetParamsRequest\x12\x14\n" +
+	"\x05model\x18\x01 \x01(\tR\x05model\x12 \n" +
+	"\vaccelerator\x18\x02 \x01(\tR\vaccelerator\"L\n" +
+	"\x12WatchParamsRequest\x12\x14\n" +
+	"\x05model\x18\x01 \x01(\tR\x05model\x12 \n" +
+	"\vaccelerator\x18\x02 \x01(\tR\vaccelerator\"\x9a\x01\n" +
+	"\x0ePredictRequest\x12\x14\n" +
+	"\x05model\x18\x01 \x01(\tR\x05model\x12 \n" +
+	"\vaccelerator\x18\x02 \x01(\tR\vaccelerator\x120\n" +
+	"\x06points\x18\x03 \x03(\v2\x18.tuner.v1.OperatingPointR\x06points\x12\x1e\n" +
+	"\n" +
+	"confidence\x18\x04 \x01(\x01R\n" +
+	"confidence\"\x81\x01\n" +
+	"\x0fPredictResponse\x12\x14\n" +
+	"\x05model\x18\x01 \x01(\tR\x05model\x12 \n" +
+	"\vaccelerator\x18\x02 \x01(\tR\vaccelerator\x126\n" +
+	"\vpredictions\x18\x03 \x03(\v2\x14.tuner.v1.PredictionR\vpredictions\"\xc9\x01\n" +
+	"\x0eOperatingPoint\x12!\n" +
+	"\farrival_rate\x18\x01 \x01(\x02R\varrivalRate\x12\"\n" +
+	"\ravg_in_tokens\x18\x02 \x01(\x05R\vavgInTokens\x12$\n" +
+	"\x0eavg_out_tokens\x18\x03 \x01(\x05R\favgOutTokens\x12$\n" +
+	"\x0emax_batch_size\x18\x04 \x01(\x05R\fmaxBatchSize\x12$\n" +
+	"\x0emax_queue_size\x18\x05 \x01(\x05R\fmaxQueueSize\"\xd8\x02\n" +
+	"\n" +
+	"Prediction\x12.\n" +
+	"\x05point\x18\x01 \x01(\v2\x18.tuner.v1.OperatingPointR\x05point\x12\x12\n" +
+	"\x04ttft\x18\x02 \x01(\x02R\x04ttft\x12\x10\n" +
+	"\x03itl\x18\x03 \x01(\x02R\x03itl\x12\x1b\n" +
+	"\twait_time\x18\x04 \x01(\x02R\bwaitTime\x12 \n" +
+	"\vconcurrency\x18\x05 \x01(\x02R\vconcurrency\x12 \n" +
+	"\vutilization\x18\x06 \x01(\x02R\vutilization\x12\x1e\n" +
+	"\n" +
+	"throughput\x18\a \x01(\x02R\n" +
+	"throughput\x12\x19\n" +
+	"\bmax_rate\x18\b \x01(\x02R\amaxRate\x12\x1e\n" +
+	"\n" +
+	"overloaded\x18\t \x01(\bR\n" +
+	"overloaded\x128\n" +
+	"\binterval\x18\n" +
+	" \x01(\v2\x1c.tuner.v1.PredictionIntervalR\binterval\"\xb8\x01\n" +
+	"\x12PredictionInterval\x12\x1e\n" +
+	"\n" +
+	"confidence\x18\x01 \x01(\x01R\n" +
+	"confidence\x12\x16\n" +
+	"\x06source\x18\x02 \x01(\tR\x06source\x12\x19\n" +
+	"\bttft_low\x18\x03 \x01(\x02R\attftLow\x12\x1b\n" +
+	"\tttft_high\x18\x04 \x01(\x02R\bttftHigh\x12\x17\n" +
+	"\aitl_low\x18\x05 \x01(\x02R\x06itlLow\x12\x19\n" +
+	"\bitl_high\x18\x06 \x01(\x02R\aitlHigh\"\xbf\x02\n" +
+	"\x0fCapacityRequest\x12\x14\n" +
+	"\x05model\x18\x01 \x01(\tR\x05model\x12 \n" +
+	"\vaccelerator\x18\x02 \x01(\tR\vaccelerator\x12\"\n" +
+	"\ravg_in_tokens\x18\x03 \x01(\x05R\vavgInTokens\x12$\n" +
+	"\x0eavg_out_tokens\x18\x04 \x01(\x05R\favgOutTokens\x12$\n" +
+	"\x0emax_batch_size\x18\x05 \x01(\x05R\fmaxBatchSize\x12$\n" +
+	"\x0emax_queue_size\x18\x06 \x01(\x05R\fmaxQueueSize\x12\x1f\n" +
+	"\vtarget_ttft\x18\a \x01(\x02R\n" +
+	"targetTtft\x12\x1d\n" +
+	"\n" +
+	"target_itl\x18\b \x01(\x02R\ttargetItl\x12\x1e\n" +
+	"\n" +
+	"confidence\x18\t \x01(\x01R\n" +
+	"confidence\"\x80\x03\n" +
+	"\x10CapacityResponse\x12\x14\n" +
+	"\x05model\x18\x01 \x01(\tR\x05model\x12 \n" +
+	"\vaccelerator\x18\x02 \x01(\tR\vaccelerator\x12\x19\n" +
+	"\bmax_rate\x18\x03 \x01(\x02R\amaxRate\x12\x18\n" +
+	"\abinding\x18\x04 \x01(\tR\abinding\x12\x1b\n" +
+	"\trate_ttft\x18\x05 \x01(\x02R\brateTtft\x12\x19\n" +
+	"\brate_itl\x18\x06 \x01(\x02R\arateItl\x12%\n" +
+	"\x0erate_stability\x18\a \x01(\x02R\rrateStability\x12\x12\n" +
+	"\x04ttft\x18\b \x01(\x02R\x04ttft\x12\x10\n" +
+	"\x03itl\x18\t \x01(\x02R\x03itl\x12 \n" +
+	"\vconcurrency\x18\n" +
+	" \x01(\x02R\vconcurrency\x12@\n" +
+	"\vsensitivity\x18\v \x01(\v2\x1e.tuner.v1.ParameterSensitivityR\vsensitivity\x12\x16\n" +
+	"\x06source\x18\f \x01(\tR\x06source\"V\n" +
+	"\x14ParameterSensitivity\x12\x14\n" +
+	"\x05alpha\x18\x01 \x01(\x01R\x05alpha\x12\x12\n" +
+	"\x04beta\x18\x02 \x01(\x01R\x04beta\x12\x14\n" +
+	"\x05gamma\x18\x03 \x01(\x01R\x05gamma\"\x17\n" +
+	"\x15CompareShadowsRequest\"*\n" +
+	"\x14PromoteShadowRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"\x94\x01\n" +
+	"\x10ShadowComparison\x12\x16\n" +
+	"\x06window\x18\x01 \x01(\x05R\x06window\x128\n" +
+	"\n" +
+	"estimators\x18\x02 \x03(\v2\x18.tuner.v1.EstimatorScoreR\n" +
+	"estimators\x12.\n" +
+	"\x05pairs\x18\x03 \x03(\v2\x18.tuner.v1.PairComparisonR\x05pairs\"\xd2\x01\n" +
+	"\x0fEstimatorConfig\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04mode\x18\x02 \x01(\tR\x04mode\x12\x19\n" +
+	"\binit_obs\x18\x03 \x01(\x05R\ainitObs\x12\x1f\n" +
+	"\vwindow_size\x18\x04 \x01(\x05R\n" +
+	"windowSize\x12-\n" +
+	"\x12residual_threshold\x18\x05 \x01(\x01R\x11residualThreshold\x12,\n" +
+	"\x12init_fit_threshold\x18\x06 \x01(\x01R\x10initFitThreshold\"\x92\x01\n" +
+	"\x0eEstimatorScore\x121\n" +
+	"\x06config\x18\x01 \x01(\v2\x19.tuner.v1.EstimatorConfigR\x06config\x12\x18\n" +
+	"\aprimary\x18\x02 \x01(\bR\aprimary\x12\x16\n" +
+	"\x06cycles\x18\x03 \x01(\x05R\x06cycles\x12\x1b\n" +
+	"\trms_error\x18\x04 \x01(\x01R\brmsError\"}\n" +
+	"\x0ePairComparison\x12\x14\n" +
+	"\x05model\x18\x01 \x01(\tR\x05model\x12 \n" +
+	"\vaccelerator\x18\x02 \x01(\tR\vaccelerator\x123\n" +
+	"\n" +
+	"estimators\x18\x03 \x03(\v2\x13.tuner.v1.PairScoreR\n" +
+	"estimators\"\xb3\x01\n" +
+	"\tPairScore\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"has_params\x18\x02 \x01(\bR\thasParams\x12\x14\n" +
+	"\x05alpha\x18\x03 \x01(\x02R\x05alpha\x12\x12\n" +
+	"\x04beta\x18\x04 \x01(\x02R\x04beta\x12\x14\n" +
+	"\x05gamma\x18\x05 \x01(\x02R\x05gamma\x12\x16\n" +
+	"\x06cycles\x18\x06 \x01(\x05R\x06cycles\x12\x1b\n" +
+	"\trms_error\x18\a \x01(\x01R\brmsError\"\x0f\n" +
+	"\rWarmUpRequest\"/\n" +
+	"\x0eWarmUpResponse\x12\x1d\n" +
+	"\n" +
+	"warming_up\x18\x01 \x01(\bR\twarmingUp\"\x1a\n" +
+	"\x18CalibrationStatusRequest\"X\n" +
+	"\x19CalibrationStatusResponse\x12;\n" +
+	"\bstatuses\x18\x01 \x03(\v2\x1f.tuner.v1.PairCalibrationStatusR\bstatuses\"\xeb\x02\n" +
+	"\n" +
+	"ServerSpec\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05class\x18\x02 \x01(\tR\x05class\x12\x14\n" +
+	"\x05model\x18\x03 \x01(\tR\x05model\x12)\n" +
+	"\x10keep_accelerator\x18\x04 \x01(\bR\x0fkeepAccelerator\x12(\n" +
+	"\x10min_num_replicas\x18\x05 \x01(\x05R\x0eminNumReplicas\x12$\n" +
+	"\x0emax_batch_size\x18\x06 \x01(\x05R\fmaxBatchSize\x12$\n" +
+	"\x0emax_queue_size\x18\a \x01(\x05R\fmaxQueueSize\x12=\n" +
+	"\rcurrent_alloc\x18\b \x01(\v2\x18.tuner.v1.AllocationDataR\fcurrentAlloc\x12=\n" +
+	"\rdesired_alloc\x18\t \x01(\v2\x18.tuner.v1.AllocationDataR\fdesiredAlloc\"\xf8\x01\n" +
+	"\x0eAllocationData\x12 \n" +
+	"\vaccelerator\x18\x01 \x01(\tR\vaccelerator\x12!\n" +
+	"\fnum_replicas\x18\x02 \x01(\x05R\vnumReplicas\x12\x1b\n" +
+	"\tmax_batch\x18\x03 \x01(\x05R\bmaxBatch\x12\x12\n" +
+	"\x04cost\x18\x04 \x01(\x02R\x04cost\x12\x1f\n" +
+	"\vitl_average\x18\x05 \x01(\x02R\n" +
+	"itlAverage\x12!\n" +
+	"\fttft_average\x18\x06 \x01(\x02R\vttftAverage\x12,\n" +
+	"\x04load\x18\a \x01(\v2\x18.tuner.v1.ServerLoadSpecR\x04load\"\x9d\x01\n" +
+	"\x0eServerLoadSpec\x12!\n" +
+	"\farrival_rate\x18\x01 \x01(\x02R\varrivalRate\x12\x1e\n" +
+	"\n" +
+	"throughput\x18\x02 \x01(\x02R\n" +
+	"throughput\x12\"\n" +
+	"\ravg_in_tokens\x18\x03 \x01(\x05R\vavgInTokens\x12$\n" +
+	"\x0eavg_out_tokens\x18\x04 \x01(\x05R\favgOutTokens\"~\n" +
+	"\tModelData\x12:\n" +
+	"\x06models\x18\x01 \x03(\v2\".tuner.v1.ModelAcceleratorPerfDataR\x06models\x125\n" +
+	"\tfreshness\x18\x02 \x03(\v2\x17.tuner.v1.PairFreshnessR\tfreshness\"\xbc\x01\n" +
+	"\rPairFreshness\x12\x14\n" +
+	"\x05model\x18\x01 \x01(\tR\x05model\x12 \n" +
+	"\vaccelerator\x18\x02 \x01(\tR\vaccelerator\x12\x1c\n" +
+	"\tfreshness\x18\x03 \x01(\tR\tfreshness\x12=\n" +
+	"\flast_updated\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\vlastUpdated\x12\x16\n" +
+	"\x06merged\x18\x05 \x01(\bR\x06merged\"\xb7\x01\n" +
+	"\x18ModelAcceleratorPerfData\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x10\n" +
+	"\x03acc\x18\x02 \x01(\tR\x03acc\x12\x1b\n" +
+	"\tacc_count\x18\x03 \x01(\x05R\baccCount\x12$\n" +
+	"\x0emax_batch_size\x18\x04 \x01(\x05R\fmaxBatchSize\x122\n" +
+	"\n" +
+	"perf_parms\x18\x05 \x01(\v2\x13.tuner.v1.PerfParmsR\tperfParms\"K\n" +
+	"\tPerfParms\x12\x14\n" +
+	"\x05alpha\x18\x01 \x01(\x02R\x05alpha\x12\x12\n" +
+	"\x04beta\x18\x02 \x01(\x02R\x04beta\x12\x14\n" +
+	"\x05gamma\x18\x03 \x01(\x02R\x05gamma\"\xb3\x03\n" +
+	"\n" +
+	"Parameters\x12\x14\n" +
+	"\x05model\x18\x01 \x01(\tR\x05model\x12 \n" +
+	"\vaccelerator\x18\x02 \x01(\tR\vaccelerator\x12\x14\n" +
+	"\x05alpha\x18\x03 \x01(\x02R\x05alpha\x12\x12\n" +
+	"\x04beta\x18\x04 \x01(\x02R\x04beta\x12\x14\n" +
+	"\x05gamma\x18\x05 \x01(\x02R\x05gamma\x12\x10\n" +
+	"\x03nis\x18\x06 \x01(\x01R\x03nis\x12!\n" +
+	"\fupdate_count\x18\a \x01(\x05R\vupdateCount\x12=\n" +
+	"\flast_updated\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\vlastUpdated\x12\x1b\n" +
+	"\traw_alpha\x18\t \x01(\x02R\brawAlpha\x12\x19\n" +
+	"\braw_beta\x18\n" +
+	" \x01(\x02R\arawBeta\x12\x1b\n" +
+	"\traw_gamma\x18\v \x01(\x02R\brawGamma\x12\x1c\n" +
+	"\tfreshness\x18\f \x01(\tR\tfreshness\x12!\n" +
+	"\fprior_source\x18\r \x01(\tR\vpriorSource\x12#\n" +
+	"\rcatalog_entry\x18\x0e \x01(\tR\fcatalogEntry\"L\n" +
+	"\x12DiagnosticsRequest\x12\x14\n" +
+	"\x05model\x18\x01 \x01(\tR\x05model\x12 \n" +
+	"\vaccelerator\x18\x02 \x01(\tR\vaccelerator\"\xef\x01\n" +
+	"\x13DiagnosticsResponse\x12\x14\n" +
+	"\x05model\x18\x01 \x01(\tR\x05model\x12 \n" +
+	"\vaccelerator\x18\x02 \x01(\tR\vaccelerator\x12\x14\n" +
+	"\x05alpha\x18\x03 \x01(\x02R\x05alpha\x12\x12\n" +
+	"\x04beta\x18\x04 \x01(\x02R\x04beta\x12\x14\n" +
+	"\x05gamma\x18\x05 \x01(\x02R\x05gamma\x12,\n" +
+	"\x04init\x18\x06 \x01(\v2\x18.tuner.v1.FitDiagnosticsR\x04init\x122\n" +
+	"\asliding\x18\a \x01(\v2\x18.tuner.v1.FitDiagnosticsR\asliding\"\xb6\x01\n" +
+	"\x0eFitDiagnostics\x12<\n" +
+	"\fobservations\x18\x01 \x03(\v2\x18.tuner.v1.ObservationFitR\fobservations\x12!\n" +
+	"\frms_residual\x18\x02 \x01(\x01R\vrmsResidual\x12C\n" +
+	"\x0fidentifiability\x18\x03 \x01(\v2\x19.tuner.v1.IdentifiabilityR\x0fidentifiability\"\xdd\x03\n" +
+	"\x0eObservationFit\x12!\n" +
+	"\farrival_rate\x18\x01 \x01(\x01R\varrivalRate\x12\"\n" +
+	"\ravg_in_tokens\x18\x02 \x01(\x02R\vavgInTokens\x12$\n" +
+	"\x0eavg_out_tokens\x18\x03 \x01(\x02R\favgOutTokens\x12$\n" +
+	"\x0emax_batch_size\x18\x04 \x01(\x05R\fmaxBatchSize\x12$\n" +
+	"\x0emax_queue_size\x18\x05 \x01(\x05R\fmaxQueueSize\x12#\n" +
+	"\robserved_ttft\x18\x06 \x01(\x01R\fobservedTtft\x12%\n" +
+	"\x0epredicted_ttft\x18\a \x01(\x01R\rpredictedTtft\x12!\n" +
+	"\fobserved_itl\x18\b \x01(\x01R\vobservedItl\x12#\n" +
+	"\rpredicted_itl\x18\t \x01(\x01R\fpredictedItl\x12#\n" +
+	"\rresidual_ttft\x18\n" +
+	" \x01(\x01R\fresidualTtft\x12!\n" +
+	"\fresidual_itl\x18\v \x01(\x01R\vresidualItl\x12\x1c\n" +
+	"\tevaluated\x18\f \x01(\bR\tevaluated\x12\x18\n" +
+	"\aoutlier\x18\r \x01(\bR\aoutlier\"\xaa\x01\n" +
+	"\x0fIdentifiability\x12'\n" +
+	"\x0fsingular_values\x18\x01 \x03(\x01R\x0esingularValues\x12C\n" +
+	"\x10singular_vectors\x18\x02 \x03(\v2\x18.tuner.v1.SingularVectorR\x0fsingularVectors\x12)\n" +
+	"\x10condition_number\x18\x03 \x01(\x01R\x0fconditionNumber\"0\n" +
+	"\x0eSingularVector\x12\x1e\n" +
+	"\n" +
+	"components\x18\x01 \x03(\x01R\n" +
+	"components\"\xd1\x02\n" +
+	"\x15PairCalibrationStatus\x12\x14\n" +
+	"\x05model\x18\x01 \x01(\tR\x05model\x12 \n" +
+	"\vaccelerator\x18\x02 \x01(\tR\vaccelerator\x12#\n" +
+	"\rstore_present\x18\x03 \x01(\bR\fstorePresent\x12\x1e\n" +
+	"\n" +
+	"calibrated\x18\x04 \x01(\bR\n" +
+	"calibrated\x12\x1b\n" +
+	"\tobs_count\x18\x05 \x01(\x05R\bobsCount\x12\x1d\n" +
+	"\n" +
+	"obs_target\x18\x06 \x01(\x05R\tobsTarget\x12)\n" +
+	"\x10condition_number\x18\a \x01(\x01R\x0fconditionNumber\x12'\n" +
+	"\x0fill_conditioned\x18\b \x01(\bR\x0eillConditioned\x12+\n" +
+	"\x11needs_calibration\x18\t \x01(\bR\x10needsCalibration2\xb9\x06\n" +
+	"\x05Tuner\x125\n" +
+	"\x04Tune\x12\x15.tuner.v1.TuneRequest\x1a\x16.tuner.v1.TuneResponse\x121\n" +
+	"\x05Merge\x12\x13.tuner.v1.ModelData\x1a\x13.tuner.v1.ModelData\x12=\n" +
+	"\tGetParams\x12\x1a.tuner.v1.GetParamsRequest\x1a\x14.tuner.v1.Parameters\x12J\n" +
+	"\vDiagnostics\x12\x1c.tuner.v1.DiagnosticsRequest\x1a\x1d.tuner.v1.DiagnosticsResponse\x12;\n" +
+	"\x06WarmUp\x12\x17.tuner.v1.WarmUpRequest\x1a\x18.tuner.v1.WarmUpResponse\x12<\n" +
+	"\tCalibrate\x12\x1a.tuner.v1.CalibrateRequest\x1a\x13.tuner.v1.ModelData\x12\\\n" +
+	"\x11CalibrationStatus\x12\".tuner.v1.CalibrationStatusRequest\x1a#.tuner.v1.CalibrationStatusResponse\x12>\n" +
+	"\aPredict\x12\x18.tuner.v1.PredictRequest\x1a\x19.tuner.v1.PredictResponse\x12A\n" +
+	"\bCapacity\x12\x19.tuner.v1.CapacityRequest\x1a\x1a.tuner.v1.CapacityResponse\x12M\n" +
+	"\x0eCompareShadows\x12\x1f.tuner.v1.CompareShadowsRequest\x1a\x1a.tuner.v1.ShadowComparison\x12K\n" +
+	"\rPromoteShadow\x12\x1e.tuner.v1.PromoteShadowRequest\x1a\x1a.tuner.v1.ShadowComparison\x12C\n" +
+	"\vWatchParams\x12\x1c.tuner.v1.WatchParamsRequest\x1a\x14.tuner.v1.Parameters0\x01B9Z7github.com/llm-inferno/model-tuner/api/tuner/v1;tunerv1b\x06proto3"
+
+var (
+	file_api_tuner_v1_tuner_proto_rawDescOnce sync.Once
+	file_api_tuner_v1_tuner_proto_rawDescData []byte
+)
+
+func file_api_tuner_v1_tuner_proto_rawDescGZIP() []byte {
+	file_api_tuner_v1_tuner_proto_rawDescOnce.Do(func() {
+		file_api_tuner_v1_tuner_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_tuner_v1_tuner_proto_rawDesc), len(file_api_tuner_v1_tuner_proto_rawDesc)))
+	})
+	return file_api_tuner_v1_tuner_proto_rawDescData
+}
+
+var file_api_tuner_v1_tuner_proto_msgTypes = make([]protoimpl.MessageInfo, 40)
+var file_api_tuner_v1_tuner_proto_goTypes = []any{
+	(*TuneRequest)(nil),               // 0: tuner.v1.TuneRequest
+	(*TuneResponse)(nil),              // 1: tuner.v1.TuneResponse
+	(*GroupOutcome)(nil),              // 2: tuner.v1.GroupOutcome
+	(*CalibrateRequest)(nil),          // 3: tuner.v1.CalibrateRequest
+	(*GetParamsRequest)(nil),          // 4: tuner.v1.GetParamsRequest
+	(*WatchParamsRequest)(nil),        // 5: tuner.v1.WatchParamsRequest
+	(*PredictRequest)(nil),            // 6: tuner.v1.PredictRequest
+	(*PredictResponse)(nil),           // 7: tuner.v1.PredictResponse
+	(*OperatingPoint)(nil),            // 8: tuner.v1.OperatingPoint
+	(*Prediction)(nil),                // 9: tuner.v1.Prediction
+	(*PredictionInterval)(nil),        // 10: tuner.v1.PredictionInterval
+	(*CapacityRequest)(nil),           // 11: tuner.v1.CapacityRequest
+	(*CapacityResponse)(nil),          // 12: tuner.v1.CapacityResponse
+	(*ParameterSensitivity)(nil),      // 13: tuner.v1.ParameterSensitivity
+	(*CompareShadowsRequest)(nil),     // 14: tuner.v1.CompareShadowsRequest
+	(*PromoteShadowRequest)(nil),      // 15: tuner.v1.PromoteShadowRequest
+	(*ShadowComparison)(nil),          // 16: tuner.v1.ShadowComparison
+	(*EstimatorConfig)(nil),           // 17: tuner.v1.EstimatorConfig
+	(*EstimatorScore)(nil),            // 18: tuner.v1.EstimatorScore
+	(*PairComparison)(nil),            // 19: tuner.v1.PairComparison
+	(*PairScore)(nil),                 // 20: tuner.v1.PairScore
+	(*WarmUpRequest)(nil),             // 21: tuner.v1.WarmUpRequest
+	(*WarmUpResponse)(nil),            // 22: tuner.v1.WarmUpResponse
+	(*CalibrationStatusRequest)(nil),  // 23: tuner.v1.CalibrationStatusRequest
+	(*CalibrationStatusResponse)(nil), // 24: tuner.v1.CalibrationStatusResponse
+	(*ServerSpec)(nil),                // 25: tuner.v1.ServerSpec
+	(*AllocationData)(nil),            // 26: tuner.v1.AllocationData
+	(*ServerLoadSpec)(nil),            // 27: tuner.v1.ServerLoadSpec
+	(*ModelData)(nil),                 // 28: tuner.v1.ModelData
+	(*PairFreshness)(nil),             // 29: tuner.v1.PairFreshness
+	(*ModelAcceleratorPerfData)(nil),  // 30: tuner.v1.ModelAcceleratorPerfData
+	(*PerfParms)(nil),                 // 31: tuner.v1.PerfParms
+	(*Parameters)(nil),                // 32: tuner.v1.Parameters
+	(*DiagnosticsRequest)(nil),        // 33: tuner.v1.DiagnosticsRequest
+	(*DiagnosticsResponse)(nil),       // 34: tuner.v1.DiagnosticsResponse
+	(*FitDiagnostics)(nil),            // 35: tuner.v1.FitDiagnostics
+	(*ObservationFit)(nil),            // 36: tuner.v1.ObservationFit
+	(*Identifiability)(nil),           // 37: tuner.v1.Identifiability
+	(*SingularVector)(nil),            // 38: tuner.v1.SingularVector
+	(*PairCalibrationStatus)(nil),     // 39: tuner.v1.PairCalibrationStatus
+	(*timestamppb.Timestamp)(nil),     // 40: google.protobuf.Timestamp
+}
+var file_api_tuner_v1_tuner_proto_depIdxs = []int32{
+	25, // 0: tuner.v1.TuneRequest.replica_specs:type_name -> tuner.v1.ServerSpec
+	30, // 1: tuner.v1.TuneResponse.models:type_name -> tuner.v1.ModelAcceleratorPerfData
+	2,  // 2: tuner.v1.TuneResponse.groups:type_name -> tuner.v1.GroupOutcome
+	25, // 3: tuner.v1.CalibrateRequest.specs:type_name -> tuner.v1.ServerSpec
+	8,  // 4: tuner.v1.PredictRequest.points:type_name -> tuner.v1.OperatingPoint
+	9,  // 5: tuner.v1.PredictResponse.predictions:type_name -> tuner.v1.Prediction
+	8,  // 6: tuner.v1.Prediction.point:type_name -> tuner.v1.OperatingPoint
+	10, // 7: tuner.v1.Prediction.interval:type_name -> tuner.v1.PredictionInterval
+	13, // 8: tuner.v1.CapacityResponse.sensitivity:type_name -> tuner.v1.ParameterSensitivity
+	18, // 9: tuner.v1.ShadowComparison.estimators:type_name -> tuner.v1.EstimatorScore
+	19, // 10: tuner.v1.ShadowComparison.pairs:type_name -> tuner.v1.PairComparison
+	17, // 11: tuner.v1.EstimatorScore.config:type_name -> tuner.v1.EstimatorConfig
+	20, // 12: tuner.v1.PairComparison.estimators:type_name -> tuner.v1.PairScore
+	39, // 13: tuner.v1.CalibrationStatusResponse.statuses:type_name -> tuner.v1.PairCalibrationStatus
+	26, // 14: tuner.v1.ServerSpec.current_alloc:type_name -> tuner.v1.AllocationData
+	26, // 15: tuner.v1.ServerSpec.desired_alloc:type_name -> tuner.v1.AllocationData
+	27, // 16: tuner.v1.AllocationData.load:type_name -> tuner.v1.ServerLoadSpec
+	30, // 17: tuner.v1.ModelData.models:type_name -> tuner.v1.ModelAcceleratorPerfData
+	29, // 18: tuner.v1.ModelData.freshness:type_name -> tuner.v1.PairFreshness
+	40, // 19: tuner.v1.PairFreshness.last_updated:type_name -> google.protobuf.Timestamp
+	31, // 20: tuner.v1.ModelAcceleratorPerfData.perf_parms:type_name -> tuner.v1.PerfParms
+	40, // 21: tuner.v1.Parameters.last_updated:type_name -> google.protobuf.Timestamp
+	35, // 22: tuner.v1.DiagnosticsResponse.init:type_name -> tuner.v1.FitDiagnostics
+	35, // 23: tuner.v1.DiagnosticsResponse.sliding:type_name -> tuner.v1.FitDiagnostics
+	36, // 24: tuner.v1.FitDiagnostics.observations:type_name -> tuner.v1.ObservationFit
+	37, // 25: tuner.v1.FitDiagnostics.identifiability:type_name -> tuner.v1.Identifiability
+	38, // 26: tuner.v1.Identifiability.singular_vectors:type_name -> tuner.v1.SingularVector
+	0,  // 27: tuner.v1.Tuner.Tune:input_type -> tuner.v1.TuneRequest
+	28, // 28: tuner.v1.Tuner.Merge:input_type -> tuner.v1.ModelData
+	4,  // 29: tuner.v1.Tuner.GetParams:input_type -> tuner.v1.GetParamsRequest
+	33, // 30: tuner.v1.Tuner.Diagnostics:input_type -> tuner.v1.DiagnosticsRequest
+	21, // 31: tuner.v1.Tuner.WarmUp:input_type -> tuner.v1.WarmUpRequest
+	3,  // 32: tuner.v1.Tuner.Calibrate:input_type -> tuner.v1.CalibrateRequest
+	23, // 33: tuner.v1.Tuner.CalibrationStatus:input_type -> tuner.v1.CalibrationStatusRequest
+	6,  // 34: tuner.v1.Tuner.Predict:input_type -> tuner.v1.PredictRequest
+	11, // 35: tuner.v1.Tuner.Capacity:input_type -> tuner.v1.CapacityRequest
+	14, // 36: tuner.v1.Tuner.CompareShadows:input_type -> tuner.v1.CompareShadowsRequest
+	15, // 37: tuner.v1.Tuner.PromoteShadow:input_type -> tuner.v1.PromoteShadowRequest
+	5,  // 38: tuner.v1.Tuner.WatchParams:input_type -> tuner.v1.WatchParamsRequest
+	1,  // 39: tuner.v1.Tuner.Tune:output_type -> tuner.v1.TuneResponse
+	28, // 40: tuner.v1.Tuner.Merge:output_type -> tuner.v1.ModelData
+	32, // 41: tuner.v1.Tuner.GetParams:output_type -> tuner.v1.Parameters
+	34, // 42: tuner.v1.Tuner.Diagnostics:output_type -> tuner.v1.DiagnosticsResponse
+	22, // 43: tuner.v1.Tuner.WarmUp:output_type -> tuner.v1.WarmUpResponse
+	28, // 44: tuner.v1.Tuner.Calibrate:output_type -> tuner.v1.ModelData
+	24, // 45: tuner.v1.Tuner.CalibrationStatus:output_type -> tuner.v1.CalibrationStatusResponse
+	7,  // 46: tuner.v1.Tuner.Predict:output_type -> tuner.v1.PredictResponse
+	12, // 47: tuner.v1.Tuner.Capacity:output_type -> tuner.v1.CapacityResponse
+	16, // 48: tuner.v1.Tuner.CompareShadows:output_type -> tuner.v1.ShadowComparison
+	16, // 49: tuner.v1.Tuner.PromoteShadow:output_type -> tuner.v1.ShadowComparison
+	32, // 50: tuner.v1.Tuner.WatchParams:output_type -> tuner.v1.Parameters
+	39, // [39:51] is the sub-list for method output_type
+	27, // [27:39] is the sub-list for method input_type
+	27, // [27:27] is the sub-list for extension type_name
+	27, // [27:27] is the sub-list for extension extendee
+	0,  // [0:27] is the sub-list for field type_name
+}
+
+func init() { file_api_tuner_v1_tuner_proto_init() }
+func file_api_tuner_v1_tuner_proto_init() {
+	if File_api_tuner_v1_tuner_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_tuner_v1_tuner_proto_rawDesc), len(file_api_tuner_v1_tuner_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   40,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_api_tuner_v1_tuner_proto_goTypes,
+		DependencyIndexes: file_api_tuner_v1_tuner_proto_depIdxs,
+		MessageInfos:      file_api_tuner_v1_tuner_proto_msgTypes,
+	}.Build()
+	File_api_tuner_v1_tuner_proto = out.File
+	file_api_tuner_v1_tuner_proto_goTypes = nil
+	file_api_tuner_v1_tuner_proto_depIdxs = nil
+}
@@ -0,0 +1,593 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: api/tuner/v1/tuner.proto
+
+package tunerv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Tuner_Tune_FullMethodName              = "/tuner.v1.Tuner/Tune"
+	Tuner_Merge_FullMethodName             = "/tuner.v1.Tuner/Merge"
+	Tuner_GetParams_FullMethodName         = "/tuner.v1.Tuner/GetParams"
+	Tuner_Diagnostics_FullMethodName       = "/tuner.v1.Tuner/Diagnostics"
+	Tuner_WarmUp_FullMethodName            = "/tuner.v1.Tuner/WarmUp"
+	Tuner_Calibrate_FullMethodName         = "/tuner.v1.Tuner/Calibrate"
+	Tuner_CalibrationStatus_FullMethodName = "/tuner.v1.Tuner/CalibrationStatus"
+	Tuner_Predict_FullMethodName           = "/tuner.v1.Tuner/Predict"
+	Tuner_Capacity_FullMethodName          = "/tuner.v1.Tuner/Capacity"
+	Tuner_CompareShadows_FullMethodName    = "/tuner.v1.Tuner/CompareShadows"
+	Tuner_PromoteShadow_FullMethodName     = "/tuner.v1.Tuner/PromoteShadow"
+	Tuner_WatchParams_FullMethodName       = "/tuner.v1.Tuner/WatchParams"
+)
+
+// TunerClient is the client API for Tuner service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Tuner estimates the performance parameters (alpha, beta, gamma) of (model, accelerator) pairs
+// from replica observations.
+type TunerClient interface {
+	// Tune runs one tuning cycle over replica observations (POST /tune). When no group has
+	// parameters yet it fails with FAILED_PRECONDITION, with a TuneResponse carrying the group
+	// outcomes in the status details.
+	Tune(ctx context.Context, in *TuneRequest, opts ...grpc.CallOption) (*TuneResponse, error)
+	// Merge overlays the tuned parameters onto the caller's ModelData (POST /merge), reporting the
+	// freshness of each tuned pair.
+	Merge(ctx context.Context, in *ModelData, opts ...grpc.CallOption) (*ModelData, error)
+	// GetParams returns the tuned parameters of one pair (GET /getparams); NOT_FOUND if none.
+	GetParams(ctx context.Context, in *GetParamsRequest, opts ...grpc.CallOption) (*Parameters, error)
+	// Diagnostics evaluates the stored parameters of a pair against its retained observations
+	// (GET /diagnostics); NOT_FOUND if the pair has no parameters.
+	Diagnostics(ctx context.Context, in *DiagnosticsRequest, opts ...grpc.CallOption) (*DiagnosticsResponse, error)
+	// WarmUp reports whether any pair is still warming up (GET /warmup).
+	WarmUp(ctx context.Context, in *WarmUpRequest, opts ...grpc.CallOption) (*WarmUpResponse, error)
+	// Calibrate fits pairs from a load sweep (POST /calibrate).
+	Calibrate(ctx context.Context, in *CalibrateRequest, opts ...grpc.CallOption) (*ModelData, error)
+	// CalibrationStatus reports per pair whether a calibration sweep is needed
+	// (GET /calibration-status).
+	CalibrationStatus(ctx context.Context, in *CalibrationStatusRequest, opts ...grpc.CallOption) (*CalibrationStatusResponse, error)
+	// Predict evaluates the stored parameters of a pair at operating points (POST /predict);
+	// NOT_FOUND if the pair has no parameters.
+	Predict(ctx context.Context, in *PredictRequest, opts ...grpc.CallOption) (*PredictResponse, error)
+	// Capacity searches the max arrival rate per replica within SLO targets (POST /capacity);
+	// NOT_FOUND if the pair has no parameters.
+	Capacity(ctx context.Context, in *CapacityRequest, opts ...grpc.CallOption) (*CapacityResponse, error)
+	// CompareShadows reports the rolling one-step-ahead error of the primary estimator and its
+	// shadows (GET /shadows).
+	CompareShadows(ctx context.Context, in *CompareShadowsRequest, opts ...grpc.CallOption) (*ShadowComparison, error)
+	// PromoteShadow makes a shadow estimator the primary (POST /shadows/promote); NOT_FOUND for an
+	// unknown shadow, FAILED_PRECONDITION if it has not tuned every pair the primary has.
+	PromoteShadow(ctx context.Context, in *PromoteShadowRequest, opts ...grpc.CallOption) (*ShadowComparison, error)
+	// WatchParams streams the current parameters of the matching pairs, then every update as it
+	// is stored. Empty model or accelerator match all.
+	WatchParams(ctx context.Context, in *WatchParamsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Parameters], error)
+}
+
+type tunerClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTunerClient(cc grpc.ClientConnInterface) TunerClient {
+	return &tunerClient{cc}
+}
+
+func (c *tunerClient) Tune(ctx context.Context, in *TuneRequest, opts ...grpc.CallOption) (*TuneResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TuneResponse)
+	err := c.cc.Invoke(ctx, Tuner_Tune_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tunerClient) Merge(ctx context.Context, in *ModelData, opts ...grpc.CallOption) (*ModelData, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ModelData)
+	err := c.cc.Invoke(ctx, Tuner_Merge_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tunerClient) GetParams(ctx context.Context, in *GetParamsRequest, opts ...grpc.CallOption) (*Parameters, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Parameters)
+	err := c.cc.Invoke(ctx, Tuner_GetParams_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tunerClient) Diagnostics(ctx context.Context, in *DiagnosticsRequest, opts ...grpc.CallOption) (*DiagnosticsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DiagnosticsResponse)
+	err := c.cc.Invoke(ctx, Tuner_Diagnostics_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tunerClient) WarmUp(ctx context.Context, in *WarmUpRequest, opts ...grpc.CallOption) (*WarmUpResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WarmUpResponse)
+	err := c.cc.Invoke(ctx, Tuner_WarmUp_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tunerClient) Calibrate(ctx context.Context, in *CalibrateRequest, opts ...grpc.CallOption) (*ModelData, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ModelData)
+	err := c.cc.Invoke(ctx, Tuner_Calibrate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tunerClient) CalibrationStatus(ctx context.Context, in *CalibrationStatusRequest, opts ...grpc.CallOption) (*CalibrationStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CalibrationStatusResponse)
+	err := c.cc.Invoke(ctx, Tuner_CalibrationStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tunerClient) Predict(ctx context.Context, in *PredictRequest, opts ...grpc.CallOption) (*PredictResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PredictResponse)
+	err := c.cc.Invoke(ctx, Tuner_Predict_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tunerClient) Capacity(ctx context.Context, in *CapacityRequest, opts ...grpc.CallOption) (*CapacityResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CapacityResponse)
+	err := c.cc.Invoke(ctx, Tuner_Capacity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tunerClient) CompareShadows(ctx context.Context, in *CompareShadowsRequest, opts ...grpc.CallOption) (*ShadowComparison, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ShadowComparison)
+	err := c.cc.Invoke(ctx, Tuner_CompareShadows_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tunerClient) PromoteShadow(ctx context.Context, in *PromoteShadowRequest, opts ...grpc.CallOption) (*ShadowComparison, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ShadowComparison)
+	err := c.cc.Invoke(ctx, Tuner_PromoteShadow_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tunerClient) WatchParams(ctx context.Context, in *WatchParamsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Parameters], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Tuner_ServiceDesc.Streams[0], Tuner_WatchParams_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchParamsRequest, Parameters]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Tuner_WatchParamsClient = grpc.ServerStreamingClient[Parameters]
+
+// TunerServer is the server API for Tuner service.
+// All implementations must embed UnimplementedTunerServer
+// for forward compatibility.
+//
+// Tuner estimates the performance parameters (alpha, beta, gamma) of (model, accelerator) pairs
+// from replica observations.
+type TunerServer interface {
+	// Tune runs one tuning cycle over replica observations (POST /tune). When no group has
+	// parameters yet it fails with FAILED_PRECONDITION, with a TuneResponse carrying the group
+	// outcomes in the status details.
+	Tune(context.Context, *TuneRequest) (*TuneResponse, error)
+	// Merge overlays the tuned parameters onto the caller's ModelData (POST /merge), reporting the
+	// freshness of each tuned pair.
+	Merge(context.Context, *ModelData) (*ModelData, error)
+	// GetParams returns the tuned parameters of one pair (GET /getparams); NOT_FOUND if none.
+	GetParams(context.Context, *GetParamsRequest) (*Parameters, error)
+	// Diagnostics evaluates the stored parameters of a pair against its retained observations
+	// (GET /diagnostics); NOT_FOUND if the pair has no parameters.
+	Diagnostics(context.Context, *DiagnosticsRequest) (*DiagnosticsResponse, error)
+	// WarmUp reports whether any pair is still warming up (GET /warmup).
+	WarmUp(context.Context, *WarmUpRequest) (*WarmUpResponse, error)
+	// Calibrate fits pairs from a load sweep (POST /calibrate).
+	Calibrate(context.Context, *CalibrateRequest) (*ModelData, error)
+	// CalibrationStatus reports per pair whether a calibration sweep is needed
+	// (GET /calibration-status).
+	CalibrationStatus(context.Context, *CalibrationStatusRequest) (*CalibrationStatusResponse, error)
+	// Predict evaluates the stored parameters of a pair at operating points (POST /predict);
+	// NOT_FOUND if the pair has no parameters.
+	Predict(context.Context, *PredictRequest) (*PredictResponse, error)
+	// Capacity searches the max arrival rate per replica within SLO targets (POST /capacity);
+	// NOT_FOUND if the pair has no parameters.
+	Capacity(context.Context, *CapacityRequest) (*CapacityResponse, error)
+	// CompareShadows reports the rolling one-step-ahead error of the primary estimator and its
+	// shadows (GET /shadows).
+	CompareShadows(context.Context, *CompareShadowsRequest) (*ShadowComparison, error)
+	// PromoteShadow makes a shadow estimator the primary (POST /shadows/promote); NOT_FOUND for an
+	// unknown shadow, FAILED_PRECONDITION if it has not tuned every pair the primary has.
+	PromoteShadow(context.Context, *PromoteShadowRequest) (*ShadowComparison, error)
+	// WatchParams streams the current parameters of the matching pairs, then every update as it
+	// is stored. Empty model or accelerator match all.
+	WatchParams(*WatchParamsRequest, grpc.ServerStreamingServer[Parameters]) error
+	mustEmbedUnimplementedTunerServer()
+}
+
+// UnimplementedTunerServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedTunerServer struct{}
+
+func (UnimplementedTunerServer) Tune(context.Context, *TuneRequest) (*TuneResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Tune not implemented")
+}
+func (UnimplementedTunerServer) Merge(context.Context, *ModelData) (*ModelData, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Merge not implemented")
+}
+func (UnimplementedTunerServer) GetParams(context.Context, *GetParamsRequest) (*Parameters, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetParams not implemented")
+}
+func (UnimplementedTunerServer) Diagnostics(context.Context, *DiagnosticsRequest) (*DiagnosticsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Diagnostics not implemented")
+}
+func (UnimplementedTunerServer) WarmUp(context.Context, *WarmUpRequest) (*WarmUpResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WarmUp not implemented")
+}
+func (UnimplementedTunerServer) Calibrate(context.Context, *CalibrateRequest) (*ModelData, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Calibrate not implemented")
+}
+func (UnimplementedTunerServer) CalibrationStatus(context.Context, *CalibrationStatusRequest) (*CalibrationStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CalibrationStatus not implemented")
+}
+func (UnimplementedTunerServer) Predict(context.Context, *PredictRequest) (*PredictResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Predict not implemented")
+}
+func (UnimplementedTunerServer) Capacity(context.Context, *CapacityRequest) (*CapacityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Capacity not implemented")
+}
+func (UnimplementedTunerServer) CompareShadows(context.Context, *CompareShadowsRequest) (*ShadowComparison, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompareShadows not implemented")
+}
+func (UnimplementedTunerServer) PromoteShadow(context.Context, *PromoteShadowRequest) (*ShadowComparison, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PromoteShadow not implemented")
+}
+func (UnimplementedTunerServer) WatchParams(*WatchParamsRequest, grpc.ServerStreamingServer[Parameters]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchParams not implemented")
+}
+func (UnimplementedTunerServer) mustEmbedUnimplementedTunerServer() {}
+func (UnimplementedTunerServer) testEmbeddedByValue()               {}
+
+// UnsafeTunerServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TunerServer will
+// result in compilation errors.
+type UnsafeTunerServer interface {
+	mustEmbedUnimplementedTunerServer()
+}
+
+func RegisterTunerServer(s grpc.ServiceRegistrar, srv TunerServer) {
+	// If the following call pancis, it indicates UnimplementedTunerServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Tuner_ServiceDesc, srv)
+}
+
+func _Tuner_Tune_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TuneRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TunerServer).Tune(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Tuner_Tune_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TunerServer).Tune(ctx, req.(*TuneRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tuner_Merge_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ModelData)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TunerServer).Merge(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Tuner_Merge_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TunerServer).Merge(ctx, req.(*ModelData))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tuner_GetParams_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetParamsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TunerServer).GetParams(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Tuner_GetParams_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TunerServer).GetParams(ctx, req.(*GetParamsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tuner_Diagnostics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiagnosticsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TunerServer).Diagnostics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Tuner_Diagnostics_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TunerServer).Diagnostics(ctx, req.(*DiagnosticsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tuner_WarmUp_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WarmUpRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TunerServer).WarmUp(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Tuner_WarmUp_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TunerServer).WarmUp(ctx, req.(*WarmUpRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tuner_Calibrate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CalibrateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TunerServer).Calibrate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Tuner_Calibrate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TunerServer).Calibrate(ctx, req.(*CalibrateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tuner_CalibrationStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CalibrationStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TunerServer).CalibrationStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Tuner_CalibrationStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TunerServer).CalibrationStatus(ctx, req.(*CalibrationStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tuner_Predict_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PredictRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TunerServer).Predict(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Tuner_Predict_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TunerServer).Predict(ctx, req.(*PredictRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tuner_Capacity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CapacityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TunerServer).Capacity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Tuner_Capacity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TunerServer).Capacity(ctx, req.(*CapacityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tuner_CompareShadows_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompareShadowsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TunerServer).CompareShadows(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Tuner_CompareShadows_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TunerServer).CompareShadows(ctx, req.(*CompareShadowsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tuner_PromoteShadow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PromoteShadowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TunerServer).PromoteShadow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Tuner_PromoteShadow_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TunerServer).PromoteShadow(ctx, req.(*PromoteShadowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tuner_WatchParams_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchParamsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TunerServer).WatchParams(m, &grpc.GenericServerStream[WatchParamsRequest, Parameters]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Tuner_WatchParamsServer = grpc.ServerStreamingServer[Parameters]
+
+// Tuner_ServiceDesc is the grpc.ServiceDesc for Tuner service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Tuner_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "tuner.v1.Tuner",
+	HandlerType: (*TunerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Tune",
+			Handler:    _Tuner_Tune_Handler,
+		},
+		{
+			MethodName: "Merge",
+			Handler:    _Tuner_Merge_Handler,
+		},
+		{
+			MethodName: "GetParams",
+			Handler:    _Tuner_GetParams_Handler,
+		},
+		{
+			MethodName: "Diagnostics",
+			Handler:    _Tuner_Diagnostics_Handler,
+		},
+		{
+			MethodName: "WarmUp",
+			Handler:    _Tuner_WarmUp_Handler,
+		},
+		{
+			MethodName: "Calibrate",
+			Handler:    _Tuner_Calibrate_Handler,
+		},
+		{
+			MethodName: "CalibrationStatus",
+			Handler:    _Tuner_CalibrationStatus_Handler,
+		},
+		{
+			MethodName: "Predict",
+			Handler:    _Tuner_Predict_Handler,
+		},
+		{
+			MethodName: "Capacity",
+			Handler:    _Tuner_Capacity_Handler,
+		},
+		{
+			MethodName: "CompareShadows",
+			Handler:    _Tuner_CompareShadows_Handler,
+		},
+		{
+			MethodName: "PromoteShadow",
+			Handler:    _Tuner_PromoteShadow_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchParams",
+			Handler:       _Tuner_WatchParams_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "api/tuner/v1/tuner.proto",
+}
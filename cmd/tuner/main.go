@@ -1,12 +1,22 @@
 package main
 
 import (
+	"context"
+	"fmt"
 	"log"
 	"log/slog"
+	"net/http"
 	"os"
+	"os/signal"
 	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
 
 	pkgconfig "github.com/llm-inferno/model-tuner/pkg/config"
+	"github.com/llm-inferno/model-tuner/pkg/metrics"
+	"github.com/llm-inferno/model-tuner/pkg/observer"
 	pkgsvc "github.com/llm-inferno/model-tuner/pkg/service"
 	"github.com/llm-inferno/model-tuner/tunerservice"
 )
@@ -20,6 +30,10 @@ func main() {
 	if port == "" {
 		port = tunerservice.DefaultTunerPort
 	}
+	grpcPort := os.Getenv(tunerservice.TunerGRPCPortEnvName)
+	if grpcPort == "" {
+		grpcPort = tunerservice.DefaultTunerGRPCPort
+	}
 
 	warmUpCycles := pkgconfig.DefaultWarmUpCycles
 	if v := os.Getenv(pkgsvc.WarmUpCyclesEnvName); v != "" {
@@ -77,14 +91,295 @@ func main() {
 
 	service := pkgsvc.NewTunerService(warmUpCycles, initObs, holdBack, useSliding, windowSize, residualThreshold, initFitThreshold)
 	service.SetMaxConditionNumber(maxConditionNumber)
+
+	transferPrior := pkgsvc.DefaultTransferPrior
+	if v := os.Getenv(pkgsvc.TransferPriorEnvName); v != "" {
+		transferPrior = v == "true" || v == "1"
+	}
+	service.SetTransferPrior(transferPrior)
+
+	catalogPath := os.Getenv(pkgsvc.CatalogPathEnvName)
+	if catalogPath != "" {
+		catalog, err := pkgsvc.LoadCatalog(catalogPath)
+		if err != nil {
+			log.Fatalf("parameter catalog error: %v", err)
+		}
+		service.SetCatalog(catalog)
+		slog.Info("seeding matching pairs from parameter catalog", "path", catalogPath, "entries", len(catalog.Entries))
+	}
+
+	publication := pkgsvc.DefaultPublicationPolicy
+	if v := os.Getenv(pkgsvc.PublishSmoothingEnvName); v != "" {
+		publication.Smoothing = v
+	}
+	if v := os.Getenv(pkgsvc.PublishEMAWeightEnvName); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			publication.EMAWeight = f
+		} else {
+			log.Fatalf("invalid %s: %v", pkgsvc.PublishEMAWeightEnvName, err)
+		}
+	}
+	if v := os.Getenv(pkgsvc.PublishMedianWindowEnvName); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			publication.MedianWindow = n
+		} else {
+			log.Fatalf("invalid %s: %v", pkgsvc.PublishMedianWindowEnvName, err)
+		}
+	}
+	if v := os.Getenv(pkgsvc.PublishMaxChangeEnvName); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			publication.MaxRelativeChange = f
+		} else {
+			log.Fatalf("invalid %s: %v", pkgsvc.PublishMaxChangeEnvName, err)
+		}
+	}
+	if err := service.SetPublicationPolicy(publication); err != nil {
+		log.Fatalf("invalid publication policy: %v", err)
+	}
+	if publication != pkgsvc.DefaultPublicationPolicy {
+		slog.Info("publishing smoothed parameters", "smoothing", publication.Smoothing,
+			"emaWeight", publication.EMAWeight, "medianWindow", publication.MedianWindow,
+			"maxRelativeChange", publication.MaxRelativeChange)
+	}
+
+	var staleness pkgsvc.StalenessPolicy
+	for env, ttl := range map[string]*time.Duration{
+		pkgsvc.StaleAfterEnvName:  &staleness.StaleAfter,
+		pkgsvc.ExpireAfterEnvName: &staleness.ExpireAfter,
+	} {
+		if v := os.Getenv(env); v != "" {
+			d, err := time.ParseDuration(v)
+			if err != nil {
+				log.Fatalf("invalid %s: %v", env, err)
+			}
+			*ttl = d
+		}
+	}
+	staleness.ExcludeStale = pkgsvc.DefaultExcludeStale
+	if v := os.Getenv(pkgsvc.ExcludeStaleEnvName); v != "" {
+		staleness.ExcludeStale = v == "true" || v == "1"
+	}
+	if err := service.SetStalenessPolicy(staleness); err != nil {
+		log.Fatalf("invalid staleness policy: %v", err)
+	}
+
+	shadows, err := pkgsvc.ParseShadowEstimators(os.Getenv(pkgsvc.ShadowEstimatorsEnvName), service.EstimatorConfig())
+	if err != nil {
+		log.Fatalf("invalid %s: %v", pkgsvc.ShadowEstimatorsEnvName, err)
+	}
+	for _, shadow := range shadows {
+		if err := service.AddShadow(shadow); err != nil {
+			log.Fatalf("shadow estimator error: %v", err)
+		}
+		slog.Info("running shadow estimator", "name", shadow.Name, "mode", shadow.Mode,
+			"initObs", shadow.InitObs, "windowSize", shadow.WindowSize,
+			"residualThreshold", shadow.ResidualThreshold, "initFitThreshold", shadow.InitFitThreshold)
+	}
+
+	if path := os.Getenv(pkgsvc.ReplayPathEnvName); path != "" {
+		if err := replay(service, path); err != nil {
+			log.Fatalf("replay error: %v", err)
+		}
+		return
+	}
+
+	bootstrapWindows := pkgsvc.DefaultBootstrapWindows
+	if v := os.Getenv(pkgsvc.BootstrapWindowsEnvName); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 0 {
+			bootstrapWindows = n
+		}
+	}
+	if bootstrapWindows > 0 {
+		history, err := prometheusHistory(bootstrapWindows)
+		if err != nil {
+			log.Fatalf("init bootstrap error: %v", err)
+		}
+		service.SetHistorySource(history)
+		slog.Info("bootstrapping new pairs from Prometheus history", "windows", bootstrapWindows)
+	}
+
 	server := tunerservice.NewTunerServer(service)
+	reloader, err := secure(server)
+	if err != nil {
+		log.Fatalf("security configuration error: %v", err)
+	}
+
+	shutdownValue := tunerservice.DefaultShutdownTimeout
+	if v := os.Getenv(tunerservice.ShutdownTimeoutEnvName); v != "" {
+		shutdownValue = v
+	}
+	shutdownTimeout, err := time.ParseDuration(shutdownValue)
+	if err != nil || shutdownTimeout < 0 {
+		log.Fatalf("invalid %s: %q", tunerservice.ShutdownTimeoutEnvName, shutdownValue)
+	}
+
+	// SIGINT/SIGTERM cancel ctx, which stops the background tuning loops; the servers then drain
+	// and the OnShutdown hooks flush the state before exit.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	var loops sync.WaitGroup
+
+	if path := os.Getenv(tunerservice.RecordPathEnvName); path != "" {
+		maxBytes := int64(tunerservice.DefaultRecordMaxBytes)
+		if v := os.Getenv(tunerservice.RecordMaxBytesEnvName); v != "" {
+			if n, err := strconv.ParseInt(v, 10, 64); err == nil && n >= 0 {
+				maxBytes = n
+			}
+		}
+		maxFiles := tunerservice.DefaultRecordMaxFiles
+		if v := os.Getenv(tunerservice.RecordMaxFilesEnvName); v != "" {
+			if n, err := strconv.Atoi(v); err == nil && n >= 0 {
+				maxFiles = n
+			}
+		}
+		recorder, err := tunerservice.NewRecorder(path, maxBytes, maxFiles)
+		if err != nil {
+			log.Fatalf("recorder error: %v", err)
+		}
+		defer func() { _ = recorder.Close() }()
+		server.SetRecorder(recorder)
+		slog.Info("recording /tune and /calibrate traffic",
+			"path", path, "maxBytes", maxBytes, "maxFiles", maxFiles)
+	}
+
+	observeInterval := pkgsvc.DefaultObserveInterval
+	if v := os.Getenv(pkgsvc.ObserveIntervalEnvName); v != "" {
+		observeInterval = v
+	}
+	if observeInterval != "" {
+		interval, err := time.ParseDuration(observeInterval)
+		if err != nil {
+			log.Fatalf("invalid %s: %v", pkgsvc.ObserveIntervalEnvName, err)
+		}
+		maxPending := pkgsvc.DefaultObserveMaxPending
+		if v := os.Getenv(pkgsvc.ObserveMaxPendingEnvName); v != "" {
+			if n, err := strconv.Atoi(v); err == nil && n >= 1 {
+				maxPending = n
+			}
+		}
+		ingester, err := pkgsvc.NewIngester(service, interval, maxPending)
+		if err != nil {
+			log.Fatalf("ingester error: %v", err)
+		}
+		server.SetIngester(ingester)
+		loops.Go(func() { ingester.Run(ctx) })
+		// Tune the observations buffered since the last scheduled tune rather than drop them.
+		server.OnShutdown(func(context.Context) error {
+			if _, err := ingester.Flush(); err != nil {
+				return fmt.Errorf("final scheduled tune: %w", err)
+			}
+			return nil
+		})
+		slog.Info("accepting pushed observations on /observe", "interval", interval, "maxPending", maxPending)
+	}
+
+	if kind := os.Getenv(pkgsvc.ActiveSourceEnvName); kind != "" {
+		active, err := activeTuner(service, kind)
+		if err != nil {
+			log.Fatalf("active mode error: %v", err)
+		}
+		server.SetActiveTuner(active)
+		loops.Go(func() { active.Run(ctx) })
+	}
+
+	if path := os.Getenv(tunerservice.ExportPathEnvName); path != "" {
+		exportInterval := tunerservice.DefaultExportInterval
+		if v := os.Getenv(tunerservice.ExportIntervalEnvName); v != "" {
+			exportInterval = v
+		}
+		interval, err := time.ParseDuration(exportInterval)
+		if err != nil {
+			log.Fatalf("invalid %s: %v", tunerservice.ExportIntervalEnvName, err)
+		}
+		keep := tunerservice.DefaultExportKeep
+		if v := os.Getenv(tunerservice.ExportKeepEnvName); v != "" {
+			if n, err := strconv.Atoi(v); err == nil && n >= 0 {
+				keep = n
+			}
+		}
+		models := splitList(os.Getenv(tunerservice.ExportModelsEnvName))
+		accelerators := splitList(os.Getenv(tunerservice.ExportAcceleratorsEnvName))
+		exporter, err := tunerservice.NewExporter(service, path, interval, keep, models, accelerators)
+		if err != nil {
+			log.Fatalf("exporter error: %v", err)
+		}
+		loops.Go(func() { exporter.Run(ctx) })
+		server.OnShutdown(func(context.Context) error {
+			if _, err := exporter.Export(); err != nil {
+				return fmt.Errorf("final ModelData export: %w", err)
+			}
+			return nil
+		})
+		slog.Info("exporting ModelData", "path", path, "interval", interval, "keep", keep,
+			"models", models, "accelerators", accelerators)
+	}
+
+	if peers := splitList(os.Getenv(tunerservice.ReplicationPeersEnvName)); len(peers) > 0 {
+		durations := map[string]time.Duration{}
+		for env, def := range map[string]string{
+			tunerservice.ReplicationIntervalEnvName: tunerservice.DefaultReplicationInterval,
+			tunerservice.ReplicationFullSyncEnvName: tunerservice.DefaultReplicationFullSync,
+			tunerservice.ReplicationTimeoutEnvName:  tunerservice.DefaultReplicationTimeout,
+		} {
+			v := os.Getenv(env)
+			if v == "" {
+				v = def
+			}
+			d, err := time.ParseDuration(v)
+			if err != nil {
+				log.Fatalf("invalid %s: %v", env, err)
+			}
+			durations[env] = d
+		}
+		// With TLS configured, peers are trusted by the client CA and sent this instance's certificate.
+		client := &http.Client{Timeout: durations[tunerservice.ReplicationTimeoutEnvName]}
+		if reloader != nil {
+			transport := http.DefaultTransport.(*http.Transport).Clone()
+			transport.TLSClientConfig = reloader.ClientTLSConfig()
+			client.Transport = transport
+		}
+		replicator, err := tunerservice.NewReplicator(service, peers, os.Getenv(tunerservice.ReplicationTokenEnvName),
+			durations[tunerservice.ReplicationIntervalEnvName], durations[tunerservice.ReplicationFullSyncEnvName], client)
+		if err != nil {
+			log.Fatalf("replication error: %v", err)
+		}
+		loops.Go(func() { replicator.Run(ctx) })
+		server.OnShutdown(func(ctx context.Context) error {
+			if err := replicator.Push(ctx); err != nil {
+				return fmt.Errorf("final replication push: %w", err)
+			}
+			return nil
+		})
+		slog.Info("replicating pair states to peers", "peers", peers,
+			"interval", durations[tunerservice.ReplicationIntervalEnvName],
+			"fullSync", durations[tunerservice.ReplicationFullSyncEnvName])
+	}
+
+	catalogReload := pkgsvc.DefaultCatalogReloadInterval
+	if v := os.Getenv(pkgsvc.CatalogReloadIntervalEnvName); v != "" {
+		catalogReload = v
+	}
+	if catalogPath != "" {
+		interval, err := time.ParseDuration(catalogReload)
+		if err != nil || interval < 0 {
+			log.Fatalf("invalid %s: %q", pkgsvc.CatalogReloadIntervalEnvName, catalogReload)
+		}
+		if interval > 0 {
+			loops.Go(func() { service.WatchCatalog(ctx, catalogPath, interval) })
+		}
+	}
+
+	if staleness.ExpireAfter > 0 {
+		loops.Go(func() { service.RunEviction(ctx, staleness.ExpireAfter/10) })
+		slog.Info("evicting expired parameters", "expireAfter", staleness.ExpireAfter)
+	}
 
 	estimatorMode := pkgsvc.DefaultEstimatorMode
 	if useSliding {
 		estimatorMode = "sliding-window"
 	}
 	slog.Info("Starting TunerService",
-		"host", host, "port", port,
+		"host", host, "port", port, "grpcPort", grpcPort,
 		"warmUpCycles", warmUpCycles,
 		"initObs", initObs,
 		"holdBack", holdBack,
@@ -92,8 +387,181 @@ func main() {
 		"windowSize", windowSize,
 		"residualThreshold", residualThreshold,
 		"initFitThreshold", initFitThreshold,
-		"maxConditionNumber", maxConditionNumber)
-	if err := server.Run(host, port); err != nil {
+		"maxConditionNumber", maxConditionNumber,
+		"transferPrior", transferPrior)
+	serverErr := make(chan error, 2)
+	go func() { serverErr <- server.Run(host, port) }()
+	if grpcPort != tunerservice.GRPCPortDisabled {
+		grpcServer := tunerservice.NewGRPCServer(server)
+		go func() { serverErr <- grpcServer.Run(host, grpcPort) }()
+	}
+	select {
+	case err := <-serverErr:
+		stop()
+		loops.Wait()
 		log.Fatalf("server error: %v", err)
+	case <-ctx.Done():
+		slog.Info("shutting down", "timeout", shutdownTimeout)
+		loops.Wait()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			slog.Warn("shutdown incomplete", "err", err)
+		}
+	}
+}
+
+// prometheusHistory builds the init bootstrap history source from PROMETHEUS_ADDRESS, TOKEN and
+// the optional ONLINE_OBSERVER_CONFIG, i.e. the same queries the OnlineObserver runs.
+func prometheusHistory(windows int) (*observer.PrometheusHistory, error) {
+	address := os.Getenv("PROMETHEUS_ADDRESS")
+	if address == "" {
+		return nil, fmt.Errorf("PROMETHEUS_ADDRESS is required when %s > 0", pkgsvc.BootstrapWindowsEnvName)
+	}
+	client, err := metrics.NewPrometheusClient(address, os.Getenv("TOKEN"))
+	if err != nil {
+		return nil, err
+	}
+	config := observer.DefaultOnlineObserverConfig()
+	if path := os.Getenv(observer.OnlineObserverConfigEnvName); path != "" {
+		if config, err = observer.LoadOnlineObserverConfig(path); err != nil {
+			return nil, err
+		}
+	}
+	return observer.NewPrometheusHistory(client, config, windows)
+}
+
+// secure configures TLS and authorization of server from the TUNER_TLS_* and TUNER_AUTH_*
+// variables, returning the TLS material if any. With none set the server stays plain HTTP and open.
+func secure(server *tunerservice.TunerServer) (*tunerservice.TLSReloader, error) {
+	var config tunerservice.AuthConfig
+	for env, tokens := range map[string]*[]string{
+		tunerservice.AuthReadTokensFileEnvName:   &config.ReadTokens,
+		tunerservice.AuthMutateTokensFileEnvName: &config.MutateTokens,
+	} {
+		if path := os.Getenv(env); path != "" {
+			var err error
+			if *tokens, err = tunerservice.ReadTokensFile(path); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for env, clients := range map[string]*[]string{
+		tunerservice.AuthReadClientsEnvName:   &config.ReadClients,
+		tunerservice.AuthMutateClientsEnvName: &config.MutateClients,
+	} {
+		*clients = append(*clients, splitList(os.Getenv(env))...)
+	}
+
+	var auth *tunerservice.Authorizer
+	if len(config.ReadTokens)+len(config.MutateTokens)+len(config.ReadClients)+len(config.MutateClients) > 0 {
+		var err error
+		if auth, err = tunerservice.NewAuthorizer(config); err != nil {
+			return nil, err
+		}
+		server.SetAuthorizer(auth)
+	}
+
+	certFile, keyFile := os.Getenv(tunerservice.TLSCertFileEnvName), os.Getenv(tunerservice.TLSKeyFileEnvName)
+	clientCAFile := os.Getenv(tunerservice.TLSClientCAFileEnvName)
+	if auth.RequiresClientCerts() && clientCAFile == "" {
+		return nil, fmt.Errorf("client certificate authorization requires %s", tunerservice.TLSClientCAFileEnvName)
+	}
+	if certFile == "" && keyFile == "" {
+		if clientCAFile != "" {
+			return nil, fmt.Errorf("%s requires %s and %s", tunerservice.TLSClientCAFileEnvName,
+				tunerservice.TLSCertFileEnvName, tunerservice.TLSKeyFileEnvName)
+		}
+		if auth != nil {
+			slog.Warn("authorization is enabled without TLS; bearer tokens are sent in clear text")
+		}
+		return nil, nil
+	}
+	// Client certificates are mandatory only when they are the sole way to authenticate.
+	requireClientCerts := auth.RequiresClientCerts() && len(config.ReadTokens)+len(config.MutateTokens) == 0
+	reloader, err := tunerservice.NewTLSReloader(certFile, keyFile, clientCAFile, requireClientCerts)
+	if err != nil {
+		return nil, err
+	}
+	server.SetTLS(reloader.TLSConfig())
+	return reloader, nil
+}
+
+// activeTuner builds the active-mode tuner for the given source kind, polling every
+// TUNER_ACTIVE_INTERVAL and publishing to TUNER_SINK_URL if set.
+func activeTuner(service *pkgsvc.TunerService, kind string) (*pkgsvc.ActiveTuner, error) {
+	var source pkgsvc.MetricsSource
+	var err error
+	switch kind {
+	case pkgsvc.ActiveSourcePrometheus:
+		source, err = observer.NewOnlineObserver()
+	case pkgsvc.ActiveSourceScrape:
+		source, err = observer.NewScrapeObserver()
+	default:
+		return nil, fmt.Errorf("unknown %s %q (want %q or %q)", pkgsvc.ActiveSourceEnvName, kind,
+			pkgsvc.ActiveSourcePrometheus, pkgsvc.ActiveSourceScrape)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	intervalValue := pkgsvc.DefaultActiveInterval
+	if v := os.Getenv(pkgsvc.ActiveIntervalEnvName); v != "" {
+		intervalValue = v
+	}
+	interval, err := time.ParseDuration(intervalValue)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", pkgsvc.ActiveIntervalEnvName, err)
+	}
+	active, err := pkgsvc.NewActiveTuner(service, source, interval)
+	if err != nil {
+		return nil, err
+	}
+
+	if url := os.Getenv(tunerservice.SinkURLEnvName); url != "" {
+		timeoutValue := tunerservice.DefaultSinkTimeout
+		if v := os.Getenv(tunerservice.SinkTimeoutEnvName); v != "" {
+			timeoutValue = v
+		}
+		timeout, err := time.ParseDuration(timeoutValue)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", tunerservice.SinkTimeoutEnvName, err)
+		}
+		sink, err := tunerservice.NewHTTPSink(url, os.Getenv(tunerservice.SinkTokenEnvName), timeout)
+		if err != nil {
+			return nil, err
+		}
+		active.SetSink(sink)
+	}
+	slog.Info("active mode: polling metrics", "source", kind, "interval", interval,
+		"sink", os.Getenv(tunerservice.SinkURLEnvName))
+	return active, nil
+}
+
+// replay feeds a recorded /tune and /calibrate traffic log through service and prints the
+// resulting parameter trajectory to stdout.
+func replay(service *pkgsvc.TunerService, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+	records, err := pkgsvc.ReadRecording(f)
+	if err != nil {
+		return err
+	}
+	slog.Info("replaying recording", "path", path, "requests", len(records))
+	_, err = pkgsvc.Replay(service, records, os.Stdout)
+	return err
+}
+
+// splitList splits a comma-separated environment value, dropping blank items.
+func splitList(value string) []string {
+	var items []string
+	for item := range strings.SplitSeq(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
 	}
+	return items
 }
@@ -18,14 +18,16 @@ import (
 
 type record struct {
 	RPM          float32
-	Tokens       float32
+	InTokens     float32
+	OutTokens    float32
 	MaxBatchSize int
 	AvgBatchSize float32
-	AvgWait      float32
+	AvgTTFT      float32
 	AvgItl       float32
 	Alpha        float64
 	Beta         float64
-	DiffWait     float64
+	Gamma        float64
+	DiffTTFT     float64
 	DiffITL      float64
 
 	// Experiment metadata
@@ -69,6 +71,7 @@ func main() {
 	observer, err := observer.NewOnlineObserver()
 	if err != nil {
 		fmt.Printf("Error in Observer creation: %s\n", err)
+		return
 	}
 	configData, err := utils.LoadConfigForServer("default")
 	if err != nil {
@@ -77,7 +80,11 @@ func main() {
 
 	// create tuner by supplying the observers environment
 	env := observer.GetEnvironment()
-	tuner, _, err := core.SetupTunerForQueueingModel(configData, env, "decode")
+	if env == nil {
+		fmt.Println("error getting the environment")
+		return
+	}
+	tuner, _, err := core.SetupTunerForQueueingModel(configData, env, "prefill-decode")
 	if err != nil {
 		fmt.Println(err)
 		return
@@ -133,18 +140,20 @@ loop:
 		x := tuner.X().RawVector().Data
 		delta := tuner.Innovation().RawVector().Data
 
-		envData := env.(*core.EnvironmentDecode)
+		envData := env.(*core.EnvironmentPrefillDecode)
 
 		r := record{
 			RPM:          envData.Lambda,
-			Tokens:       envData.AvgOutputTokens,
+			InTokens:     envData.AvgInputTokens,
+			OutTokens:    envData.AvgOutputTokens,
 			MaxBatchSize: envData.MaxBatchSize,
 			AvgBatchSize: envData.BatchSize,
-			AvgWait:      envData.AvgQueueTime,
+			AvgTTFT:      envData.AvgTTFT,
 			AvgItl:       envData.AvgITL,
 			Alpha:        x[0],
 			Beta:         x[1],
-			DiffWait:     delta[0],
+			Gamma:        x[2],
+			DiffTTFT:     delta[0],
 			DiffITL:      delta[1],
 
 			ModelName:     modelName,
@@ -178,8 +187,8 @@ func writeCSV(filename string, data []record) {
 	defer writer.Flush()
 
 	header := []string{
-		"RPM", "Tokens", "MaxBatchSize", "AvgBatchSize", "AvgWait", "AvgItl",
-		"alpha", "beta", "diffWait", "diffITL", "", // empty spacer column
+		"RPM", "InTokens", "OutTokens", "MaxBatchSize", "AvgBatchSize", "AvgTTFT", "AvgItl",
+		"alpha", "beta", "gamma", "diffTTFT", "diffITL", "", // empty spacer column
 		"Model", "ExperimentRPM", "InputLen", "OutputLen", "Dataset",
 	}
 	_ = writer.Write(header)
@@ -187,14 +196,16 @@ func writeCSV(filename string, data []record) {
 	for _, r := range data {
 		row := []string{
 			fmt.Sprintf("%.3f", r.RPM),
-			fmt.Sprintf("%.3f", r.Tokens),
+			fmt.Sprintf("%.3f", r.InTokens),
+			fmt.Sprintf("%.3f", r.OutTokens),
 			strconv.Itoa(r.MaxBatchSize),
 			fmt.Sprintf("%.3f", r.AvgBatchSize),
-			fmt.Sprintf("%.3f", r.AvgWait),
+			fmt.Sprintf("%.3f", r.AvgTTFT),
 			fmt.Sprintf("%.3f", r.AvgItl),
 			fmt.Sprintf("%.6f", r.Alpha),
 			fmt.Sprintf("%.6f", r.Beta),
-			fmt.Sprintf("%.6f", r.DiffWait),
+			fmt.Sprintf("%.6f", r.Gamma),
+			fmt.Sprintf("%.6f", r.DiffTTFT),
 			fmt.Sprintf("%.6f", r.DiffITL),
 			"", // empty column for spacing
 			r.ModelName,
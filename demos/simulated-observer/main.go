@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/llm-inferno/model-tuner/pkg/core"
 	"github.com/llm-inferno/model-tuner/pkg/observer"
@@ -65,10 +66,27 @@ func main() {
 		percentNoise[i] = float32(0.05)
 	}
 
-	observer := observer.NewSimulatedObserver(rpm, inputTokens, outputTokens, alpha, beta, gamma, percentNoise, maxBatchSize)
-	if observer == nil {
+	numSteps := total
+	simObserver := observer.NewSimulatedObserver(rpm, inputTokens, outputTokens, alpha, beta, gamma, percentNoise, maxBatchSize)
+
+	// a scenario file (JSON or YAML), if given, replaces the built-in phases
+	if len(os.Args) > 1 {
+		scenario, err := observer.LoadScenario(os.Args[1])
+		if err != nil {
+			fmt.Printf("Error in loading scenario: %s\n", err)
+			return
+		}
+		if simObserver, err = observer.NewScenarioObserver(scenario); err != nil {
+			fmt.Printf("Error in Observer creation: %s\n", err)
+			return
+		}
+		numSteps = scenario.Steps
+	}
+	if simObserver == nil {
 		fmt.Println("invalid parameters for observer")
+		return
 	}
+	observer := simObserver
 
 	// create tuner
 	env := observer.GetEnvironment()
@@ -81,7 +99,6 @@ func main() {
 	fmt.Println(tuner)
 
 	// run tuner a number of steps
-	numSteps := phase1 + phase2 + phase3
 	for k := range numSteps {
 		env = observer.GetEnvironment()
 		if env == nil {
@@ -53,38 +53,7 @@ func (c *QueueModelSystemFuncCreatorPrefillDecode) Create() func(x *mat.VecDense
 		if !ok {
 			return zero
 		}
-		maxBatchSize := envData.MaxBatchSize
-		maxQueueSize := envData.MaxQueueSize
-		avgInputTokens := envData.AvgInputTokens
-		avgOutputTokens := envData.AvgOutputTokens
-
-		alpha := float32(x.AtVec(0))
-		beta := float32(x.AtVec(1))
-		gamma := float32(x.AtVec(2))
-
-		// create queueing model
-		qConfig := &analyzer.Configuration{
-			MaxBatchSize: maxBatchSize,
-			MaxQueueSize: maxQueueSize,
-			ServiceParms: &analyzer.ServiceParms{
-				Alpha: alpha,
-				Beta:  beta,
-				Gamma: gamma,
-			},
-		}
-
-		requestSize := &analyzer.RequestSize{
-			AvgInputTokens:  avgInputTokens,
-			AvgOutputTokens: avgOutputTokens,
-		}
-		queueAnalyzer, err := analyzer.NewLLMQueueAnalyzer(qConfig, requestSize)
-		if err != nil {
-			return zero
-		}
-
-		// convert arrival rate from req/min to req/sec
-		arrivalRateRPM := envData.Lambda
-		metrics, err := queueAnalyzer.Analyze(arrivalRateRPM / 60)
+		metrics, err := AnalyzePrefillDecode(envData, float32(x.AtVec(0)), float32(x.AtVec(1)), float32(x.AtVec(2)))
 		if err != nil {
 			return zero
 		}
@@ -97,6 +66,34 @@ func (c *QueueModelSystemFuncCreatorPrefillDecode) Create() func(x *mat.VecDense
 	}
 }
 
+// AnalyzePrefillDecode evaluates the prefill-decode queueing model with parameters alpha, beta
+// and gamma at the operating point of env (its arrival rate, token sizes, max batch and max queue
+// size). It is the model behind QueueModelSystemFuncCreatorPrefillDecode.
+func AnalyzePrefillDecode(env *EnvironmentPrefillDecode, alpha, beta, gamma float32) (*analyzer.AnalysisMetrics, error) {
+	// create queueing model
+	qConfig := &analyzer.Configuration{
+		MaxBatchSize: env.MaxBatchSize,
+		MaxQueueSize: env.MaxQueueSize,
+		ServiceParms: &analyzer.ServiceParms{
+			Alpha: alpha,
+			Beta:  beta,
+			Gamma: gamma,
+		},
+	}
+
+	requestSize := &analyzer.RequestSize{
+		AvgInputTokens:  env.AvgInputTokens,
+		AvgOutputTokens: env.AvgOutputTokens,
+	}
+	queueAnalyzer, err := analyzer.NewLLMQueueAnalyzer(qConfig, requestSize)
+	if err != nil {
+		return nil, err
+	}
+
+	// convert arrival rate from req/min to req/sec
+	return queueAnalyzer.Analyze(env.Lambda / 60)
+}
+
 func (c *QueueModelSystemFuncCreatorDecode) Create() func(x *mat.VecDense) *mat.VecDense {
 	tuner := c.tuner
 	return func(x *mat.VecDense) *mat.VecDense {
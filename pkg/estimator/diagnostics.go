@@ -0,0 +1,149 @@
+package estimator
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// ObservationFit compares one retained observation with the queueing model at given params.
+type ObservationFit struct {
+	ArrivalRate   float64 `json:"arrivalRate"` // requests/min
+	AvgInTokens   float32 `json:"avgInTokens"`
+	AvgOutTokens  float32 `json:"avgOutTokens"`
+	MaxBatchSize  int     `json:"maxBatchSize"`
+	MaxQueueSize  int     `json:"maxQueueSize"`
+	ObservedTTFT  float64 `json:"observedTTFT"`  // msec
+	PredictedTTFT float64 `json:"predictedTTFT"` // msec; 0 if not evaluated
+	ObservedITL   float64 `json:"observedITL"`   // msec
+	PredictedITL  float64 `json:"predictedITL"`  // msec; 0 if not evaluated
+	ResidualTTFT  float64 `json:"residualTTFT"`  // (predicted - observed) / observed
+	ResidualITL   float64 `json:"residualITL"`   // (predicted - observed) / observed
+	// Evaluated is false if the model cannot be evaluated at this point with the params (e.g.
+	// the params cannot sustain its arrival rate); the predictions and residuals are then 0.
+	Evaluated bool `json:"evaluated"`
+	// Outlier marks the observation the sliding window's outlier pass would drop at the params:
+	// the one with the largest combined residual, if above the residual threshold. The init
+	// window never flags outliers.
+	Outlier bool `json:"outlier"`
+}
+
+// Identifiability is the singular value decomposition of the residual Jacobian with respect to
+// the log of each parameter (see fitConditionNumber). A small singular value marks a parameter
+// direction the observations barely constrain; its singular vector names the direction.
+type Identifiability struct {
+	// SingularValues are in descending order.
+	SingularValues []float64 `json:"singularValues"`
+	// SingularVectors[i] is the right singular vector of SingularValues[i] over
+	// (ln alpha, ln beta, ln gamma), signed so its largest component is positive. E.g. a
+	// vanishing value with vector (-0.57, 0.04, 0.82) means raising gamma while lowering alpha in
+	// that proportion leaves the fit unchanged.
+	SingularVectors [][]float64 `json:"singularVectors"`
+	// ConditionNumber is the ratio of the largest to the smallest singular value; 0 if the
+	// smallest vanishes (an unidentifiable direction).
+	ConditionNumber float64 `json:"conditionNumber"`
+}
+
+// FitDiagnostics evaluates params against the observations an estimator retains.
+type FitDiagnostics struct {
+	Observations []ObservationFit `json:"observations"` // oldest first
+	// RMSResidual is the root mean square of the relative TTFT and ITL residuals of the
+	// evaluated observations.
+	RMSResidual float64 `json:"rmsResidual"`
+	// Identifiability is nil when the window is underdetermined (fewer residuals than
+	// parameters) or some observation cannot be evaluated.
+	Identifiability *Identifiability `json:"identifiability,omitempty"`
+}
+
+// Diagnose evaluates params x=[alpha,beta,gamma] against the collected init observations; nil if
+// there are none.
+func (ie *InitEstimator) Diagnose(x []float64) *FitDiagnostics {
+	return diagnose(ie.observations, x, -1)
+}
+
+// Diagnose evaluates params x=[alpha,beta,gamma] against the current window, flagging the
+// observation the outlier pass would drop; nil if the window is empty.
+func (swe *SlidingWindowEstimator) Diagnose(x []float64) *FitDiagnostics {
+	return diagnose(swe.window, x, swe.worstOutlier(swe.window, x))
+}
+
+// diagnose evaluates x against obs, flagging obs[outlier] (-1 for none).
+func diagnose(obs []fitObservation, x []float64, outlier int) *FitDiagnostics {
+	if len(obs) == 0 || len(x) != 3 {
+		return nil
+	}
+	d := &FitDiagnostics{Observations: make([]ObservationFit, len(obs))}
+	var sumSq float64
+	var n int
+	for i, o := range obs {
+		fit := ObservationFit{
+			ArrivalRate:  o.Lambda,
+			AvgInTokens:  o.AvgInputTokens,
+			AvgOutTokens: o.AvgOutputTokens,
+			MaxBatchSize: o.MaxBatch,
+			MaxQueueSize: o.MaxQueueSize,
+			ObservedTTFT: o.AvgTTFT,
+			ObservedITL:  o.AvgITL,
+			Outlier:      i == outlier,
+		}
+		if x[0] > 0 && x[1] > 0 && x[2] > 0 && o.AvgTTFT > 0 && o.AvgITL > 0 {
+			if ttft, itl, ok := modelLatencies(o, x); ok {
+				fit.PredictedTTFT, fit.PredictedITL = ttft, itl
+				fit.ResidualTTFT = (ttft - o.AvgTTFT) / o.AvgTTFT
+				fit.ResidualITL = (itl - o.AvgITL) / o.AvgITL
+				fit.Evaluated = true
+				sumSq += fit.ResidualTTFT*fit.ResidualTTFT + fit.ResidualITL*fit.ResidualITL
+				n += 2
+			}
+		}
+		d.Observations[i] = fit
+	}
+	if n > 0 {
+		d.RMSResidual = math.Sqrt(sumSq / float64(n))
+	}
+	d.Identifiability = identifiability(obs, x)
+	return d
+}
+
+// identifiability decomposes the log-parameter residual Jacobian of obs at x; nil if the window
+// is underdetermined or cannot be evaluated.
+func identifiability(obs []fitObservation, x []float64) *Identifiability {
+	if 2*len(obs) < len(x) {
+		return nil
+	}
+	if _, ok := residualVector(obs, x); !ok {
+		return nil
+	}
+	jac, ok := logJacobian(obs, x)
+	if !ok {
+		return nil
+	}
+	var svd mat.SVD
+	if !svd.Factorize(jac, mat.SVDThin) {
+		return nil
+	}
+	values := svd.Values(nil)
+	var v mat.Dense
+	svd.VTo(&v)
+	id := &Identifiability{SingularValues: values, SingularVectors: make([][]float64, len(values))}
+	for j := range values {
+		vec := make([]float64, len(x))
+		largest := 0
+		for i := range vec {
+			vec[i] = v.At(i, j)
+			if math.Abs(vec[i]) > math.Abs(vec[largest]) {
+				largest = i
+			}
+		}
+		if vec[largest] < 0 {
+			for i := range vec {
+				vec[i] = -vec[i]
+			}
+		}
+		id.SingularVectors[j] = vec
+	}
+	if sMin := values[len(values)-1]; sMin > 0 {
+		id.ConditionNumber = values[0] / sMin
+	}
+	return id
+}
@@ -0,0 +1,80 @@
+package estimator
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDiagnose_ResidualsAndOutlier(t *testing.T) {
+	x := []float64{8.0, 0.016, 0.0005}
+	swe := NewSlidingWindowEstimator(10, 1, 0.3)
+	swe.Seed([]fitObservation{
+		mkObs(t, x, 12, 500, 400, 128, 0),
+		mkObs(t, x, 15, 1500, 1000, 128, 0),
+		mkObs(t, x, 18, 2500, 1600, 128, 0),
+	})
+	bad := mkObs(t, x, 15, 1000, 800, 128, 0)
+	bad.AvgTTFT *= 2
+	swe.Seed([]fitObservation{bad})
+
+	d := swe.Diagnose(x)
+	if d == nil || len(d.Observations) != 4 {
+		t.Fatalf("Diagnose = %+v, want four observations", d)
+	}
+	for i, o := range d.Observations[:3] {
+		if !o.Evaluated || o.Outlier || math.Abs(o.ResidualTTFT) > 1e-3 || math.Abs(o.ResidualITL) > 1e-3 {
+			t.Errorf("observation %d = %+v, want a clean fit", i, o)
+		}
+	}
+	if o := d.Observations[3]; !o.Outlier || math.Abs(o.ResidualTTFT+0.5) > 1e-3 || o.PredictedTTFT >= o.ObservedTTFT {
+		t.Errorf("corrupted observation = %+v, want an outlier with residual -0.5", o)
+	}
+	if d.RMSResidual <= 0 || d.RMSResidual > 0.5 {
+		t.Errorf("RMSResidual = %g, want within (0, 0.5]", d.RMSResidual)
+	}
+	id := d.Identifiability
+	if id == nil || len(id.SingularValues) != 3 || len(id.SingularVectors) != 3 || id.ConditionNumber < 1 {
+		t.Fatalf("Identifiability = %+v, want three values and vectors", id)
+	}
+	for j, v := range id.SingularVectors {
+		var norm float64
+		for _, c := range v {
+			norm += c * c
+		}
+		if math.Abs(norm-1) > 1e-9 {
+			t.Errorf("singular vector %d = %v, want unit length", j, v)
+		}
+	}
+
+	// the init window evaluates the same way, but never flags outliers
+	ie := NewInitEstimator(1, false)
+	ie.observations = swe.window
+	if d := ie.Diagnose(x); d == nil || d.Observations[3].Outlier {
+		t.Errorf("init Diagnose = %+v, want no outlier", d)
+	}
+	if d := NewInitEstimator(1, false).Diagnose(x); d != nil {
+		t.Errorf("empty Diagnose = %+v, want nil", d)
+	}
+}
+
+// A single operating point cannot pin three parameters: one singular value vanishes, and its
+// vector trades gamma against alpha.
+func TestDiagnose_WeakDirection(t *testing.T) {
+	x := []float64{8.0, 0.016, 0.0005}
+	swe := NewSlidingWindowEstimator(10, 1, 0)
+	swe.Seed([]fitObservation{
+		mkObs(t, x, 15, 2000, 1000, 128, 0),
+		mkObs(t, x, 15, 2000, 1000, 128, 0),
+	})
+	id := swe.Diagnose(x).Identifiability
+	if id == nil {
+		t.Fatal("no identifiability")
+	}
+	weakest := id.SingularValues[2]
+	if weakest > 1e-3*id.SingularValues[0] {
+		t.Errorf("singular values %v, want a vanishing one", id.SingularValues)
+	}
+	if v := id.SingularVectors[2]; v[0] >= 0 || v[2] <= 0 {
+		t.Errorf("weakest direction %v, want gamma up against alpha down", v)
+	}
+}
@@ -16,28 +16,8 @@ func residualVector(obs []fitObservation, x []float64) ([]float64, bool) {
 	}
 	r := make([]float64, 0, 2*len(obs))
 	for _, o := range obs {
-		qConfig := &analyzer.Configuration{
-			MaxBatchSize: o.MaxBatch,
-			MaxQueueSize: o.MaxQueueSize,
-			ServiceParms: &analyzer.ServiceParms{
-				Alpha: float32(x[0]), Beta: float32(x[1]), Gamma: float32(x[2]),
-			},
-		}
-		requestSize := &analyzer.RequestSize{
-			AvgInputTokens:  o.AvgInputTokens,
-			AvgOutputTokens: o.AvgOutputTokens,
-		}
-		qa, err := analyzer.NewLLMQueueAnalyzer(qConfig, requestSize)
-		if err != nil {
-			return nil, false
-		}
-		metrics, err := qa.Analyze(float32(o.Lambda / 60))
-		if err != nil {
-			return nil, false
-		}
-		ttftModel := float64(metrics.AvgTTFT)
-		itlModel := float64(metrics.AvgTokenTime)
-		if o.AvgTTFT <= 0 || o.AvgITL <= 0 || ttftModel <= 0 || itlModel <= 0 {
+		ttftModel, itlModel, ok := modelLatencies(o, x)
+		if !ok || o.AvgTTFT <= 0 || o.AvgITL <= 0 {
 			return nil, false
 		}
 		r = append(r, (ttftModel-o.AvgTTFT)/o.AvgTTFT, (itlModel-o.AvgITL)/o.AvgITL)
@@ -45,6 +25,33 @@ func residualVector(obs []fitObservation, x []float64) ([]float64, bool) {
 	return r, true
 }
 
+// modelLatencies returns the TTFT and ITL the queueing model predicts for the operating point of
+// o at params x. The boolean is false if the model cannot be evaluated or predicts a non-positive
+// value.
+func modelLatencies(o fitObservation, x []float64) (ttft, itl float64, ok bool) {
+	qConfig := &analyzer.Configuration{
+		MaxBatchSize: o.MaxBatch,
+		MaxQueueSize: o.MaxQueueSize,
+		ServiceParms: &analyzer.ServiceParms{
+			Alpha: float32(x[0]), Beta: float32(x[1]), Gamma: float32(x[2]),
+		},
+	}
+	requestSize := &analyzer.RequestSize{
+		AvgInputTokens:  o.AvgInputTokens,
+		AvgOutputTokens: o.AvgOutputTokens,
+	}
+	qa, err := analyzer.NewLLMQueueAnalyzer(qConfig, requestSize)
+	if err != nil {
+		return 0, 0, false
+	}
+	metrics, err := qa.Analyze(float32(o.Lambda / 60))
+	if err != nil {
+		return 0, 0, false
+	}
+	ttft, itl = float64(metrics.AvgTTFT), float64(metrics.AvgTokenTime)
+	return ttft, itl, ttft > 0 && itl > 0
+}
+
 // fitConditionNumber estimates the practical identifiability of a fit: the ratio of the
 // largest to smallest singular value of the residual Jacobian taken with respect to the
 // log of each parameter (relative perturbations, so the measure is scale-invariant across
@@ -54,12 +61,38 @@ func residualVector(obs []fitObservation, x []float64) ([]float64, bool) {
 // number. Returns +Inf when the window is underdetermined (fewer residuals than
 // parameters) or cannot be evaluated.
 func fitConditionNumber(obs []fitObservation, x []float64) float64 {
-	const relEps = 1e-3
 	m := 2 * len(obs)
 	n := len(x)
 	if n == 0 || m < n {
 		return math.Inf(1)
 	}
+	jac, ok := logJacobian(obs, x)
+	if !ok {
+		return math.Inf(1)
+	}
+	var svd mat.SVD
+	if !svd.Factorize(jac, mat.SVDThin) {
+		return math.Inf(1)
+	}
+	sv := svd.Values(nil) // descending order
+	if len(sv) == 0 {
+		return math.Inf(1)
+	}
+	sMax := sv[0]
+	sMin := sv[len(sv)-1]
+	if sMin <= 0 {
+		return math.Inf(1)
+	}
+	return sMax / sMin
+}
+
+// logJacobian returns the Jacobian of the residual vector with respect to the log of each
+// parameter, by central differences. The boolean is false if a perturbed point cannot be
+// evaluated.
+func logJacobian(obs []fitObservation, x []float64) (*mat.Dense, bool) {
+	const relEps = 1e-3
+	m := 2 * len(obs)
+	n := len(x)
 	jac := mat.NewDense(m, n, nil)
 	for k := 0; k < n; k++ {
 		up := append([]float64(nil), x...)
@@ -69,7 +102,7 @@ func fitConditionNumber(obs []fitObservation, x []float64) float64 {
 		rUp, okUp := residualVector(obs, up)
 		rDn, okDn := residualVector(obs, dn)
 		if !okUp || !okDn {
-			return math.Inf(1)
+			return nil, false
 		}
 		// Central difference w.r.t. ln(x_k): d(ln x_k) = relEps, so the column is
 		// (rUp - rDn) / (2*relEps).
@@ -77,18 +110,51 @@ func fitConditionNumber(obs []fitObservation, x []float64) float64 {
 			jac.Set(i, k, (rUp[i]-rDn[i])/(2*relEps))
 		}
 	}
-	var svd mat.SVD
-	if !svd.Factorize(jac, mat.SVDThin) {
-		return math.Inf(1)
+	return jac, true
+}
+
+// fitCovariance estimates the covariance of the fitted params x=[alpha,beta,gamma] from the
+// observations they were fitted to, by the Gauss-Newton approximation: the covariance of ln(x)
+// is s^2 (J^T J)^-1, with J the log-parameter residual Jacobian and s^2 the residual variance
+// (sum of squared residuals over m-n degrees of freedom), then scaled to absolute parameters by
+// x_i x_j. Returns nil when the window has no spare degree of freedom (m <= n), cannot be
+// evaluated, or J^T J is singular (an unidentifiable direction).
+func fitCovariance(obs []fitObservation, x []float64) [][]float64 {
+	m := 2 * len(obs)
+	n := len(x)
+	if n == 0 || m <= n {
+		return nil
 	}
-	sv := svd.Values(nil) // descending order
-	if len(sv) == 0 {
-		return math.Inf(1)
+	r, ok := residualVector(obs, x)
+	if !ok {
+		return nil
 	}
-	sMax := sv[0]
-	sMin := sv[len(sv)-1]
-	if sMin <= 0 {
-		return math.Inf(1)
+	jac, ok := logJacobian(obs, x)
+	if !ok {
+		return nil
 	}
-	return sMax / sMin
+	var rss float64
+	for _, v := range r {
+		rss += v * v
+	}
+	variance := rss / float64(m-n)
+
+	jtj := mat.NewSymDense(n, nil)
+	jtj.SymOuterK(1, jac.T())
+	var chol mat.Cholesky
+	if !chol.Factorize(jtj) {
+		return nil
+	}
+	var inv mat.SymDense
+	if err := chol.InverseTo(&inv); err != nil {
+		return nil
+	}
+	cov := make([][]float64, n)
+	for i := range n {
+		cov[i] = make([]float64, n)
+		for j := range n {
+			cov[i][j] = variance * inv.At(i, j) * x[i] * x[j]
+		}
+	}
+	return cov
 }
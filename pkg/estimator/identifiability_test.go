@@ -67,3 +67,49 @@ func TestFitConditionNumber_LargeForCollinearWindow(t *testing.T) {
 		t.Fatalf("expected excited condition number to be finite and < 1e4, got %g", kExcited)
 	}
 }
+
+// The Gauss-Newton covariance is nil without spare degrees of freedom or on an unidentifiable
+// window, zero for a window the params fit exactly, and grows with the residual noise.
+func TestFitCovariance(t *testing.T) {
+	x := []float64{8.0, 0.016, 0.0005}
+	excited := []fitObservation{
+		mkObs(t, x, 12, 500, 400, 128, 2048),
+		mkObs(t, x, 15, 1500, 1000, 128, 2048),
+		mkObs(t, x, 18, 2500, 1600, 128, 2048),
+	}
+	if cov := fitCovariance(excited[:1], x); cov != nil {
+		t.Errorf("single observation: covariance %v, want nil", cov)
+	}
+	collinear := []fitObservation{excited[1], excited[1], excited[1]}
+	if cov := fitCovariance(collinear, x); cov != nil {
+		t.Errorf("collinear window: covariance %v, want nil", cov)
+	}
+
+	exact := fitCovariance(excited, x)
+	if exact == nil {
+		t.Fatal("excited window: covariance nil")
+	}
+	for i := range x {
+		if math.Abs(exact[i][i]) > 1e-12*x[i]*x[i] {
+			t.Errorf("exact fit: variance of param %d = %g, want ~0", i, exact[i][i])
+		}
+	}
+
+	noisy := append([]fitObservation(nil), excited...)
+	noisy[0].AvgTTFT *= 1.05
+	noisy[2].AvgITL *= 0.95
+	cov := fitCovariance(noisy, x)
+	if cov == nil {
+		t.Fatal("noisy window: covariance nil")
+	}
+	for i := range x {
+		if cov[i][i] <= 0 {
+			t.Errorf("noisy fit: variance of param %d = %g, want positive", i, cov[i][i])
+		}
+		for j := range x {
+			if cov[i][j] != cov[j][i] {
+				t.Errorf("covariance not symmetric at (%d, %d)", i, j)
+			}
+		}
+	}
+}
@@ -20,6 +20,7 @@ type InitEstimator struct {
 	lastFitFuncValue    float64
 	maxConditionNumber  float64
 	lastConditionNumber float64
+	lastCovariance      [][]float64
 	seed                []float64
 }
 
@@ -97,6 +98,11 @@ func (ie *InitEstimator) LastFitFuncValue() float64 { return ie.lastFitFuncValue
 // trigger reads this to decide whether natural excitation during warm-up was sufficient.
 func (ie *InitEstimator) LastConditionNumber() float64 { return ie.lastConditionNumber }
 
+// LastFitCovariance returns the estimated covariance of the params returned by the most recent
+// Fit() (see fitCovariance), or nil if it could not be estimated or Fit() fell back to
+// GuessInitState.
+func (ie *InitEstimator) LastFitCovariance() [][]float64 { return ie.lastCovariance }
+
 // Fit runs Nelder-Mead minimisation over all accumulated observations to find the
 // (alpha, beta, gamma) that best explains all K observations jointly via the full
 // queueing model. Returns [alpha, beta, gamma] or an error.
@@ -121,6 +127,7 @@ func (ie *InitEstimator) fitWithX0(x0 []float64) ([]float64, error) {
 	if len(ie.observations) == 0 {
 		return nil, fmt.Errorf("no observations to fit")
 	}
+	ie.lastCovariance = nil
 
 	scale := make([]float64, len(x0))
 	scaledX0 := make([]float64, len(x0))
@@ -201,6 +208,7 @@ func (ie *InitEstimator) fitWithX0(x0 []float64) ([]float64, error) {
 	}
 
 	ie.lastFitFuncValue = result.F
+	ie.lastCovariance = fitCovariance(ie.observations, x)
 	slog.Info("InitEstimator: Fit complete",
 		"alpha", x[0], "beta", x[1], "gamma", x[2],
 		"observations", len(ie.observations), "funcValue", result.F)
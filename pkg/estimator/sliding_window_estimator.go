@@ -20,6 +20,7 @@ type SlidingWindowEstimator struct {
 	residualThreshold     float64
 	maxConditionNumber    float64
 	lastFit               []float64
+	lastCovariance        [][]float64
 	heldOnIllConditioning bool
 	seed                  []float64
 }
@@ -174,6 +175,7 @@ func (swe *SlidingWindowEstimator) Fit() ([]float64, error) {
 				slog.Warn("SlidingWindowEstimator: ill-conditioned fit, no prior fit, using GuessInitState",
 					"kappa", kappa, "max", swe.maxConditionNumber)
 				swe.lastFit = fallback
+				swe.lastCovariance = nil
 				return fallback, nil
 			}
 			return nil, fmt.Errorf("fit ill-conditioned (kappa=%.3g > %.3g) and no fallback available",
@@ -182,15 +184,36 @@ func (swe *SlidingWindowEstimator) Fit() ([]float64, error) {
 	}
 
 	swe.lastFit = fitted
+	swe.lastCovariance = fitCovariance(used, fitted)
 	return fitted, nil
 }
 
+// LastFitCovariance returns the estimated covariance of the params returned by the most recent
+// Fit() (see fitCovariance), or nil if it could not be estimated or the fit was a GuessInitState
+// fallback. A held fit keeps the covariance it was fitted with.
+func (swe *SlidingWindowEstimator) LastFitCovariance() [][]float64 {
+	return swe.lastCovariance
+}
+
 // filterOutliers removes the single observation with the largest residual if that residual
 // exceeds swe.residualThreshold.
 func (swe *SlidingWindowEstimator) filterOutliers(obs []fitObservation, x []float64) []fitObservation {
-	if swe.residualThreshold <= 0 {
+	worstIdx := swe.worstOutlier(obs, x)
+	if worstIdx < 0 {
 		return obs
 	}
+	kept := make([]fitObservation, 0, len(obs)-1)
+	kept = append(kept, obs[:worstIdx]...)
+	kept = append(kept, obs[worstIdx+1:]...)
+	return kept
+}
+
+// worstOutlier returns the index of the observation with the largest residual at params x if
+// that residual exceeds swe.residualThreshold, else -1 (also when the threshold is disabled).
+func (swe *SlidingWindowEstimator) worstOutlier(obs []fitObservation, x []float64) int {
+	if swe.residualThreshold <= 0 {
+		return -1
+	}
 	worstIdx := -1
 	worstResidual := swe.residualThreshold
 	for i, o := range obs {
@@ -200,13 +223,7 @@ func (swe *SlidingWindowEstimator) filterOutliers(obs []fitObservation, x []floa
 			worstIdx = i
 		}
 	}
-	if worstIdx < 0 {
-		return obs
-	}
-	kept := make([]fitObservation, 0, len(obs)-1)
-	kept = append(kept, obs[:worstIdx]...)
-	kept = append(kept, obs[worstIdx+1:]...)
-	return kept
+	return worstIdx
 }
 
 // residual returns sqrt(dTTFT² + dITL²) for one observation evaluated at params x=[α,β,γ].
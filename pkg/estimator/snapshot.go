@@ -0,0 +1,178 @@
+package estimator
+
+import (
+	"fmt"
+	"math"
+	"slices"
+)
+
+// Observation is one retained operating point of an estimator, as carried in a Snapshot.
+type Observation struct {
+	ArrivalRate  float64 `json:"arrivalRate"` // requests/min
+	AvgInTokens  float32 `json:"avgInTokens"`
+	AvgOutTokens float32 `json:"avgOutTokens"`
+	MaxBatchSize int     `json:"maxBatchSize"`
+	MaxQueueSize int     `json:"maxQueueSize"`
+	TTFT         float64 `json:"ttft"` // msec
+	ITL          float64 `json:"itl"`  // msec
+}
+
+// Snapshot is the state of an InitEstimator or SlidingWindowEstimator: what another estimator
+// needs to continue where this one is, e.g. on a standby tuner instance. Its configuration
+// (minimum observations, window size, thresholds, seed) is not part of it.
+type Snapshot struct {
+	Observations []Observation `json:"observations"` // oldest first
+	// FitDone, LastFitFuncValue and LastConditionNumber are the InitEstimator's fit results.
+	FitDone             bool    `json:"fitDone,omitempty"`
+	LastFitFuncValue    float64 `json:"lastFitFuncValue,omitempty"`
+	LastConditionNumber float64 `json:"lastConditionNumber,omitempty"`
+	// LastFit and HeldLastGoodFit are the SlidingWindowEstimator's warm start and guard state.
+	LastFit         []float64   `json:"lastFit,omitempty"`
+	HeldLastGoodFit bool        `json:"heldLastGoodFit,omitempty"`
+	LastCovariance  [][]float64 `json:"lastCovariance,omitempty"`
+}
+
+// Validate reports an error if s could not be restored safely: a held fit that is not three
+// finite positive parameters, a covariance that is not a finite 3x3 matrix, or an observation
+// with a non-finite or negative field.
+func (s *Snapshot) Validate() error {
+	for i, o := range s.Observations {
+		for _, v := range []float64{o.ArrivalRate, float64(o.AvgInTokens), float64(o.AvgOutTokens), o.TTFT, o.ITL} {
+			if math.IsNaN(v) || math.IsInf(v, 0) || v < 0 {
+				return fmt.Errorf("observation %d: values must be finite and non-negative", i)
+			}
+		}
+		if o.MaxBatchSize < 0 || o.MaxQueueSize < 0 {
+			return fmt.Errorf("observation %d: batch and queue sizes must be non-negative", i)
+		}
+	}
+	if s.LastFit != nil {
+		if err := ValidateParams(s.LastFit); err != nil {
+			return fmt.Errorf("lastFit: %w", err)
+		}
+	}
+	if err := ValidateCovariance(s.LastCovariance); err != nil {
+		return fmt.Errorf("lastCovariance: %w", err)
+	}
+	return nil
+}
+
+// ValidateParams reports an error unless x is three finite positive parameters (alpha, beta,
+// gamma).
+func ValidateParams(x []float64) error {
+	if len(x) != 3 {
+		return fmt.Errorf("want 3 parameters, got %d", len(x))
+	}
+	for i, v := range x {
+		if math.IsNaN(v) || math.IsInf(v, 0) || v <= 0 {
+			return fmt.Errorf("parameter %d must be finite and positive, got %g", i, v)
+		}
+	}
+	return nil
+}
+
+// ValidateCovariance reports an error unless m is nil or a finite 3x3 matrix.
+func ValidateCovariance(m [][]float64) error {
+	if m == nil {
+		return nil
+	}
+	if len(m) != 3 {
+		return fmt.Errorf("want 3 rows, got %d", len(m))
+	}
+	for i, row := range m {
+		if len(row) != 3 {
+			return fmt.Errorf("row %d: want 3 columns, got %d", i, len(row))
+		}
+		for _, v := range row {
+			if math.IsNaN(v) || math.IsInf(v, 0) {
+				return fmt.Errorf("row %d: values must be finite", i)
+			}
+		}
+	}
+	return nil
+}
+
+// Snapshot returns a copy of the collected observations and fit results.
+func (ie *InitEstimator) Snapshot() *Snapshot {
+	return &Snapshot{
+		Observations:        observationsOf(ie.observations),
+		FitDone:             ie.fitDone,
+		LastFitFuncValue:    ie.lastFitFuncValue,
+		LastConditionNumber: ie.lastConditionNumber,
+		LastCovariance:      cloneMatrix(ie.lastCovariance),
+	}
+}
+
+// Restore replaces the collected observations and fit results with those of s, which must have
+// passed Validate.
+func (ie *InitEstimator) Restore(s *Snapshot) {
+	ie.observations = fitObservationsOf(s.Observations)
+	ie.fitDone = s.FitDone
+	ie.lastFitFuncValue = s.LastFitFuncValue
+	ie.lastConditionNumber = s.LastConditionNumber
+	ie.lastCovariance = s.LastCovariance
+}
+
+// Snapshot returns a copy of the window and the held fit.
+func (swe *SlidingWindowEstimator) Snapshot() *Snapshot {
+	return &Snapshot{
+		Observations:    observationsOf(swe.window),
+		LastFit:         slices.Clone(swe.lastFit),
+		HeldLastGoodFit: swe.heldOnIllConditioning,
+		LastCovariance:  cloneMatrix(swe.lastCovariance),
+	}
+}
+
+// Restore replaces the window and the held fit with those of s, which must have passed
+// Validate. A window larger than this
+// estimator's keeps its newest observations.
+func (swe *SlidingWindowEstimator) Restore(s *Snapshot) {
+	swe.window = nil
+	swe.Seed(fitObservationsOf(s.Observations))
+	swe.lastFit = s.LastFit
+	swe.heldOnIllConditioning = s.HeldLastGoodFit
+	swe.lastCovariance = s.LastCovariance
+}
+
+func observationsOf(obs []fitObservation) []Observation {
+	out := make([]Observation, len(obs))
+	for i, o := range obs {
+		out[i] = Observation{
+			ArrivalRate:  o.Lambda,
+			AvgInTokens:  o.AvgInputTokens,
+			AvgOutTokens: o.AvgOutputTokens,
+			MaxBatchSize: o.MaxBatch,
+			MaxQueueSize: o.MaxQueueSize,
+			TTFT:         o.AvgTTFT,
+			ITL:          o.AvgITL,
+		}
+	}
+	return out
+}
+
+func fitObservationsOf(obs []Observation) []fitObservation {
+	out := make([]fitObservation, len(obs))
+	for i, o := range obs {
+		out[i] = fitObservation{
+			Lambda:          o.ArrivalRate,
+			MaxBatch:        o.MaxBatchSize,
+			MaxQueueSize:    o.MaxQueueSize,
+			AvgInputTokens:  o.AvgInTokens,
+			AvgOutputTokens: o.AvgOutTokens,
+			AvgTTFT:         o.TTFT,
+			AvgITL:          o.ITL,
+		}
+	}
+	return out
+}
+
+func cloneMatrix(m [][]float64) [][]float64 {
+	if m == nil {
+		return nil
+	}
+	out := make([][]float64, len(m))
+	for i, row := range m {
+		out[i] = slices.Clone(row)
+	}
+	return out
+}
@@ -0,0 +1,62 @@
+package estimator
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// roundTrip encodes and decodes s, as a replica receives it.
+func roundTrip(t *testing.T, s *Snapshot) *Snapshot {
+	t.Helper()
+	data, err := json.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out Snapshot
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+	return &out
+}
+
+func TestInitEstimator_SnapshotRestore(t *testing.T) {
+	ie := NewInitEstimator(2, true)
+	ie.AddObservation(makeTestEnvWithQueue(10, 50, 5, 100, 500, 64, 128))
+	ie.AddObservation(makeTestEnv(20, 60, 6, 110, 510, 64))
+	want, err := ie.Fit()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	restored := NewInitEstimator(2, true)
+	restored.Restore(roundTrip(t, ie.Snapshot()))
+	if !reflect.DeepEqual(restored.observations, ie.observations) || !restored.FitDone() ||
+		restored.LastFitFuncValue() != ie.LastFitFuncValue() || !restored.IsReady() {
+		t.Fatalf("restored %+v, want %+v", restored, ie)
+	}
+	if got, err := restored.Fit(); err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("restored Fit = (%v, %v), want %v", got, err, want)
+	}
+}
+
+func TestSlidingWindowEstimator_SnapshotRestore(t *testing.T) {
+	swe := NewSlidingWindowEstimator(3, 1, 0.5)
+	for _, lambda := range []float32{10, 20, 30} {
+		swe.AddObservation(makeTestEnv(lambda, 50+lambda, 5, 100, 500, 64))
+	}
+	swe.SeedLastFit([]float64{5, 0.05, 5e-5})
+
+	restored := NewSlidingWindowEstimator(3, 1, 0.5)
+	restored.Restore(roundTrip(t, swe.Snapshot()))
+	if !reflect.DeepEqual(restored.window, swe.window) || !reflect.DeepEqual(restored.lastFit, swe.lastFit) {
+		t.Fatalf("restored %+v, want %+v", restored, swe)
+	}
+
+	// a smaller window keeps the newest observations
+	small := NewSlidingWindowEstimator(2, 1, 0.5)
+	small.Restore(swe.Snapshot())
+	if small.Len() != 2 || small.window[0].Lambda != 20 || small.window[1].Lambda != 30 {
+		t.Errorf("window %+v, want the two newest observations", small.window)
+	}
+}
@@ -15,14 +15,38 @@ type PrometheusClient struct {
 	client api.Client
 }
 
+// Sample is one series of an instant-vector query result: its label set and value.
+type Sample struct {
+	Labels map[string]string
+	Value  float64
+}
+
+// Point is one timestamped value of a range-query series.
+type Point struct {
+	Time  time.Time
+	Value float64
+}
+
+// Series is one series of a range-query result: its label set and values in time order.
+type Series struct {
+	Labels map[string]string
+	Points []Point
+}
+
+// NewPrometheusClient creates a client for the Prometheus server at url. A non-empty secretToken
+// is sent as a bearer token; an empty one disables authentication.
 func NewPrometheusClient(url, secretToken string) (*PrometheusClient, error) {
-	client, err := api.NewClient(api.Config{
-		Address: url,
-		RoundTripper: cfg.NewAuthorizationCredentialsRoundTripper(
+	roundTripper := api.DefaultRoundTripper
+	if secretToken != "" {
+		roundTripper = cfg.NewAuthorizationCredentialsRoundTripper(
 			"Bearer",
 			cfg.NewInlineSecret(secretToken),
 			api.DefaultRoundTripper,
-		),
+		)
+	}
+	client, err := api.NewClient(api.Config{
+		Address:      url,
+		RoundTripper: roundTripper,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("error creating client: %v", err)
@@ -33,20 +57,84 @@ func NewPrometheusClient(url, secretToken string) (*PrometheusClient, error) {
 }
 
 func (pc *PrometheusClient) Query(query string) (float64, error) {
+	vector, err := pc.query(query)
+	if err != nil {
+		return 0, err
+	}
+	if len(vector) > 0 {
+		return float64(vector[0].Value), nil
+	}
+
+	return 0, fmt.Errorf("no data for query: %s", query)
+}
+
+// QueryVector runs an instant query and returns every series of the resulting vector, e.g. one
+// per label combination of a `sum by (...)` aggregation. An empty result is not an error.
+func (pc *PrometheusClient) QueryVector(query string) ([]Sample, error) {
+	vector, err := pc.query(query)
+	if err != nil {
+		return nil, err
+	}
+	samples := make([]Sample, 0, len(vector))
+	for _, s := range vector {
+		samples = append(samples, Sample{Labels: labelMap(s.Metric), Value: float64(s.Value)})
+	}
+	return samples, nil
+}
+
+// QueryRange runs a range query over [start, end] at the given resolution step and returns every
+// series of the resulting matrix. An empty result is not an error.
+func (pc *PrometheusClient) QueryRange(query string, start, end time.Time, step time.Duration) ([]Series, error) {
+	v1api := v1.NewAPI(pc.client)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	r := v1.Range{Start: start, End: end, Step: step}
+	result, warnings, err := v1api.QueryRange(ctx, query, r, v1.WithTimeout(20*time.Second))
+	if err != nil {
+		return nil, fmt.Errorf("error querying Prometheus: %v", err)
+	}
+	if len(warnings) > 0 {
+		fmt.Printf("Warnings: %v\n", warnings)
+	}
+	matrix, ok := result.(model.Matrix)
+	if !ok {
+		return nil, fmt.Errorf("unexpected result type %s for range query: %s", result.Type(), query)
+	}
+	series := make([]Series, 0, len(matrix))
+	for _, m := range matrix {
+		points := make([]Point, 0, len(m.Values))
+		for _, v := range m.Values {
+			points = append(points, Point{Time: v.Timestamp.Time(), Value: float64(v.Value)})
+		}
+		series = append(series, Series{Labels: labelMap(m.Metric), Points: points})
+	}
+	return series, nil
+}
+
+func (pc *PrometheusClient) query(query string) (model.Vector, error) {
 	v1api := v1.NewAPI(pc.client)
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
 	result, warnings, err := v1api.Query(ctx, query, time.Now(), v1.WithTimeout(5*time.Second))
 	if err != nil {
-		return 0, fmt.Errorf("error querying Prometheus: %v", err)
+		return nil, fmt.Errorf("error querying Prometheus: %v", err)
 	}
 	if len(warnings) > 0 {
 		fmt.Printf("Warnings: %v\n", warnings)
 	}
-	if vector, ok := result.(model.Vector); ok && len(vector) > 0 {
-		return float64(vector[0].Value), nil
+	vector, ok := result.(model.Vector)
+	if !ok {
+		return nil, fmt.Errorf("unexpected result type %s for query: %s", result.Type(), query)
 	}
+	return vector, nil
+}
 
-	return 0, fmt.Errorf("no data for query: %s", query)
+func labelMap(metric model.Metric) map[string]string {
+	labels := make(map[string]string, len(metric))
+	for k, v := range metric {
+		labels[string(k)] = string(v)
+	}
+	return labels
 }
@@ -0,0 +1,228 @@
+/*
+Discrete-Event Observer simulates an LLM server request by request, with continuous batching,
+instead of evaluating the queue-analysis model, so estimators can be tested against a system their
+model only approximates.
+*/
+
+package observer
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"math/rand/v2"
+	"time"
+
+	"github.com/llm-inferno/model-tuner/pkg/core"
+)
+
+// DiscreteEventObserver plays a Scenario through a request-level simulation of a continuously
+// batched server. Requests arrive as a Poisson process at the scenario rpm, with token lengths
+// drawn per request, and wait in a FIFO queue of at most maxQueueSize requests (arrivals to a full
+// queue are dropped). At the start of every iteration queued requests are admitted while fewer
+// than maxBatchSize are running. An admitted request spends one iteration in prefill, then one
+// iteration per output token. An iteration takes
+//
+//	alpha + sum over prefills of (beta + gamma) * inputTokens
+//	      + sum over decodes of (beta + gamma * (inputTokens + generatedTokens + 1))
+//
+// msec, i.e. alpha plus beta per computed token plus gamma per KV-cache token read, the
+// semantics of the queue-analysis model, but without its averaging and without chunked prefill.
+//
+// Each GetEnvironment call simulates one window of the scenario's next step and reports the
+// requests completed in it: arrival rate as completions per minute, mean token lengths, TTFT
+// (arrival to first output token), ITL (decode time divided by output tokens) and queueing time,
+// and batch size as the time-averaged number of running requests. Simulation state carries
+// across windows. The scenario's noise model is not applied: the simulation is the noise.
+type DiscreteEventObserver struct {
+	BaseObserver
+
+	scenario *Scenario
+	rng      *rand.Rand
+	window   float64 // msec
+
+	clock       float64 // msec
+	nextArrival float64 // msec
+	queue       []*simRequest
+	running     []*simRequest
+	dropped     int
+
+	timeStep int
+	maxStep  int
+}
+
+// simRequest is one simulated request.
+type simRequest struct {
+	inputTokens, outputTokens int
+	arrival, admitted         float64
+	prefilled                 bool
+	prefillEnd, firstToken    float64
+	generated                 int
+}
+
+// simWindow accumulates the requests completed in one observation window.
+type simWindow struct {
+	completed                  int
+	inputTokens, outputTokens  float64
+	ttft, itl, queueTime, busy float64
+}
+
+// NewDiscreteEventObserver creates an observer that simulates scenario one window per step, with
+// an RNG seeded by scenario.Seed. After the last step the final step's parameters repeat.
+func NewDiscreteEventObserver(scenario *Scenario, window time.Duration) (*DiscreteEventObserver, error) {
+	if err := scenario.Validate(); err != nil {
+		return nil, err
+	}
+	if window <= 0 {
+		return nil, fmt.Errorf("window must be positive, got %s", window)
+	}
+	obs := &DiscreteEventObserver{
+		BaseObserver: BaseObserver{},
+
+		scenario: scenario,
+		rng:      rand.New(rand.NewPCG(scenario.Seed, 0)),
+		window:   float64(window) / float64(time.Millisecond),
+		maxStep:  scenario.Steps - 1,
+	}
+	obs.nextArrival = obs.interArrival(0)
+	return obs, nil
+}
+
+// WarmUp simulates d at the first step's parameters without reporting, so the first observation
+// does not start from an empty server.
+func (obs *DiscreteEventObserver) WarmUp(d time.Duration) {
+	obs.simulate(0, obs.clock+float64(d)/float64(time.Millisecond))
+}
+
+// Step returns the index of the next observation.
+func (obs *DiscreteEventObserver) Step() int {
+	return obs.timeStep
+}
+
+// Parameters returns the true [alpha, beta, gamma] of the last observation returned.
+func (obs *DiscreteEventObserver) Parameters() [3]float64 {
+	return obs.scenario.ParametersAt(min(max(obs.timeStep-1, 0), obs.maxStep))
+}
+
+// Dropped returns the number of arrivals dropped so far because the queue was full.
+func (obs *DiscreteEventObserver) Dropped() int {
+	return obs.dropped
+}
+
+// GetEnvironment simulates the next window and returns its measurements, or nil if no request
+// completed in it.
+func (obs *DiscreteEventObserver) GetEnvironment() core.Environment {
+	i := min(obs.timeStep, obs.maxStep)
+	obs.timeStep++
+	start := obs.clock
+	w := obs.simulate(i, start+obs.window)
+	if w.completed == 0 {
+		log.Printf("No requests completed in simulated window %d", i)
+		return nil
+	}
+	n := float64(w.completed)
+	maxBatchSize, maxQueueSize := obs.limits(i)
+	env := core.NewEnvironmentPrefillDecode(float32(n/((obs.clock-start)/60000)), float32(w.busy/(obs.clock-start)),
+		float32(w.queueTime/n), maxBatchSize,
+		float32(w.inputTokens/n), float32(w.outputTokens/n),
+		float32(w.ttft/n), float32(w.itl/n))
+	env.MaxQueueSize = maxQueueSize
+	return env
+}
+
+// limits returns the batch and queue limits at step i.
+func (obs *DiscreteEventObserver) limits(i int) (maxBatchSize, maxQueueSize int) {
+	maxBatchSize = max(int(math.Round(obs.scenario.MaxBatchSize.At(i))), 1)
+	maxQueueSize = 10 * maxBatchSize
+	if obs.scenario.MaxQueueSize != nil {
+		maxQueueSize = *obs.scenario.MaxQueueSize
+	}
+	return maxBatchSize, maxQueueSize
+}
+
+// interArrival returns the time of the next arrival after the current one at step i's rate.
+func (obs *DiscreteEventObserver) interArrival(i int) float64 {
+	rate := obs.scenario.RPM.At(i) / 60000 // per msec
+	if rate <= 0 {
+		return math.Inf(1)
+	}
+	return obs.rng.ExpFloat64() / rate
+}
+
+// simulate runs step i's parameters until the clock passes end, returning the completions.
+func (obs *DiscreteEventObserver) simulate(i int, end float64) simWindow {
+	s := obs.scenario
+	alpha, beta, gamma := s.Alpha.At(i), s.Beta.At(i), s.Gamma.At(i)
+	inMean, outMean := s.InputTokens.Mean.At(i), s.OutputTokens.Mean.At(i)
+	maxBatchSize, maxQueueSize := obs.limits(i)
+	if math.IsInf(obs.nextArrival, 1) {
+		obs.nextArrival = obs.clock + obs.interArrival(i)
+	}
+
+	var w simWindow
+	for obs.clock < end {
+		// arrivals up to now join the queue, or are dropped if neither the queue nor the batch has room
+		for obs.nextArrival <= obs.clock {
+			if len(obs.queue) < maxQueueSize+maxBatchSize-len(obs.running) {
+				obs.queue = append(obs.queue, &simRequest{
+					inputTokens:  max(int(math.Round(s.InputTokens.draw(obs.rng, inMean))), 1),
+					outputTokens: max(int(math.Round(s.OutputTokens.draw(obs.rng, outMean))), 1),
+					arrival:      obs.nextArrival,
+				})
+			} else {
+				obs.dropped++
+			}
+			obs.nextArrival += obs.interArrival(i)
+		}
+
+		// admit while there is room in the batch
+		for len(obs.running) < maxBatchSize && len(obs.queue) > 0 {
+			r := obs.queue[0]
+			obs.queue = obs.queue[1:]
+			r.admitted = obs.clock
+			obs.running = append(obs.running, r)
+		}
+		if len(obs.running) == 0 {
+			obs.clock = min(obs.nextArrival, end)
+			continue
+		}
+
+		// one iteration over the batch
+		iteration := alpha
+		for _, r := range obs.running {
+			if !r.prefilled {
+				iteration += (beta + gamma) * float64(r.inputTokens)
+			} else {
+				iteration += beta + gamma*float64(r.inputTokens+r.generated+1)
+			}
+		}
+		w.busy += iteration * float64(len(obs.running))
+		obs.clock += iteration
+
+		remaining := obs.running[:0]
+		for _, r := range obs.running {
+			if !r.prefilled {
+				r.prefilled = true
+				r.prefillEnd = obs.clock
+				remaining = append(remaining, r)
+				continue
+			}
+			r.generated++
+			if r.generated == 1 {
+				r.firstToken = obs.clock
+			}
+			if r.generated < r.outputTokens {
+				remaining = append(remaining, r)
+				continue
+			}
+			w.completed++
+			w.inputTokens += float64(r.inputTokens)
+			w.outputTokens += float64(r.outputTokens)
+			w.ttft += r.firstToken - r.arrival
+			w.itl += (obs.clock - r.prefillEnd) / float64(r.outputTokens)
+			w.queueTime += r.admitted - r.arrival
+		}
+		obs.running = remaining
+	}
+	return w
+}
@@ -0,0 +1,112 @@
+package observer
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/llm-inferno/model-tuner/pkg/core"
+	"github.com/llm-inferno/queue-analysis/pkg/analyzer"
+)
+
+func desScenario(rpm float64, maxBatch int, maxQueue *int) *Scenario {
+	return &Scenario{
+		Seed:         3,
+		Steps:        1,
+		MaxBatchSize: Signal{Value: float64(maxBatch)},
+		MaxQueueSize: maxQueue,
+		Alpha:        Signal{Value: 10},
+		Beta:         Signal{Value: 0.1},
+		Gamma:        Signal{Value: 0.001},
+		RPM:          Signal{Value: rpm},
+		InputTokens:  TokenDistribution{Mean: Signal{Value: 100}},
+		OutputTokens: TokenDistribution{Mean: Signal{Value: 10}},
+	}
+}
+
+// At low load requests rarely overlap, so the simulation must agree with the analytic model.
+func TestDiscreteEventObserver_MatchesAnalyticAtLowLoad(t *testing.T) {
+	obs, err := NewDiscreteEventObserver(desScenario(6, 64, nil), 30*time.Minute)
+	if err != nil {
+		t.Fatalf("NewDiscreteEventObserver: %v", err)
+	}
+	env, ok := obs.GetEnvironment().(*core.EnvironmentPrefillDecode)
+	if !ok || env == nil {
+		t.Fatal("expected an environment")
+	}
+
+	qa, err := analyzer.NewLLMQueueAnalyzer(
+		&analyzer.Configuration{MaxBatchSize: 64, MaxQueueSize: 640,
+			ServiceParms: &analyzer.ServiceParms{Alpha: 10, Beta: 0.1, Gamma: 0.001}},
+		&analyzer.RequestSize{AvgInputTokens: 100, AvgOutputTokens: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := qa.Analyze(6.0 / 60)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rel := math.Abs(float64(env.AvgTTFT-m.AvgTTFT)) / float64(m.AvgTTFT); rel > 0.05 {
+		t.Errorf("TTFT %g vs analytic %g", env.AvgTTFT, m.AvgTTFT)
+	}
+	if rel := math.Abs(float64(env.AvgITL-m.AvgTokenTime)) / float64(m.AvgTokenTime); rel > 0.05 {
+		t.Errorf("ITL %g vs analytic %g", env.AvgITL, m.AvgTokenTime)
+	}
+	if rel := math.Abs(float64(env.Lambda)-6) / 6; rel > 0.2 {
+		t.Errorf("arrival rate %g, want about 6", env.Lambda)
+	}
+	if env.AvgInputTokens != 100 || env.AvgOutputTokens != 10 || env.MaxQueueSize != 640 {
+		t.Errorf("unexpected environment: %s", env.String())
+	}
+	if obs.Parameters() != [3]float64{10, 0.1, 0.001} {
+		t.Errorf("Parameters() = %v", obs.Parameters())
+	}
+}
+
+func TestDiscreteEventObserver_BoundedQueueDrops(t *testing.T) {
+	queue := 2
+	// One request at a time takes about 130 ms; 1200 rpm offers one every 50 ms.
+	obs, err := NewDiscreteEventObserver(desScenario(1200, 1, &queue), time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	obs.WarmUp(10 * time.Second)
+	env := obs.GetEnvironment().(*core.EnvironmentPrefillDecode)
+	if obs.Dropped() == 0 {
+		t.Error("expected drops from a full queue")
+	}
+	if env.Lambda >= 1200 || env.Lambda < 300 {
+		t.Errorf("throughput %g should be capped by service capacity", env.Lambda)
+	}
+	if env.AvgQueueTime <= 0 || env.BatchSize > 1 {
+		t.Errorf("expected queueing with batch size <= 1: %s", env.String())
+	}
+}
+
+func TestDiscreteEventObserver_Reproducible(t *testing.T) {
+	run := func() []string {
+		s := desScenario(600, 8, nil)
+		s.InputTokens.Distribution = DistLogNormal
+		s.InputTokens.Spread = 0.5
+		s.Steps = 3
+		obs, err := NewDiscreteEventObserver(s, 30*time.Second)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var out []string
+		for range 3 {
+			out = append(out, obs.GetEnvironment().String())
+		}
+		return out
+	}
+	a, b := run(), run()
+	for i := range a {
+		if a[i] != b[i] {
+			t.Fatalf("window %d differs:\n%s\n%s", i, a[i], b[i])
+		}
+	}
+
+	if _, err := NewDiscreteEventObserver(desScenario(1, 1, nil), 0); err == nil {
+		t.Error("expected error for zero window")
+	}
+}
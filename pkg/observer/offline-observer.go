@@ -5,50 +5,188 @@ Offline Observer gets environment values from a file
 package observer
 
 import (
+	"encoding/csv"
+	"errors"
 	"fmt"
+	"io"
+	"os"
 	"strconv"
+	"strings"
 
 	"github.com/llm-inferno/model-tuner/pkg/core"
-	"github.com/llm-inferno/model-tuner/pkg/utils"
 )
 
+// RowErrorPolicy selects how the OfflineObserver handles a CSV row that cannot be parsed.
+type RowErrorPolicy int
+
+const (
+	RowErrorFail RowErrorPolicy = iota // abort loading with the first RowError
+	RowErrorSkip                       // drop the row and record its RowError
+)
+
+// RowError reports a CSV row that could not be parsed into an Environment.
+type RowError struct {
+	Line   int    // 1-based line number in the file
+	Column string // header name of the offending column
+	Err    error
+}
+
+func (e *RowError) Error() string {
+	return fmt.Sprintf("line %d, column %s: %v", e.Line, e.Column, e.Err)
+}
+
+func (e *RowError) Unwrap() error { return e.Err }
+
 type OfflineObserver struct {
 	BaseObserver
-	Data      [][]string
+	Envs      []core.Environment // parsed environments, in file order
+	RowErrors []*RowError        // rows dropped under RowErrorSkip
 	CurrIndex int
 }
 
+// NewOfflineObserver reads a CSV file in the legacy decode format (see DefaultDecodeSchema),
+// skipping rows that cannot be parsed.
 func NewOfflineObserver(envFilePath string) (*OfflineObserver, error) {
-	rows, err := utils.ReadCsvFile(envFilePath)
+	return NewOfflineObserverWithSchema(envFilePath, DefaultDecodeSchema(), RowErrorSkip)
+}
+
+// NewOfflineObserverWithSchema reads a CSV file whose first row is a header, mapping columns to
+// Environment fields by header name according to schema. Rows that cannot be parsed either abort
+// loading (RowErrorFail) or are dropped and recorded in RowErrors (RowErrorSkip).
+func NewOfflineObserverWithSchema(envFilePath string, schema *OfflineSchema, policy RowErrorPolicy) (*OfflineObserver, error) {
+	f, err := os.Open(envFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read input file %s: %v", envFilePath, err)
+	}
+	defer func() { _ = f.Close() }()
+
+	obs, err := ReadOfflineEnvironments(f, schema, policy)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", envFilePath, err)
+	}
+	for _, rowErr := range obs.RowErrors {
+		fmt.Printf("Warning: skipping %s: %v\n", envFilePath, rowErr)
+	}
+	return obs, nil
+}
+
+// ReadOfflineEnvironments parses CSV data from r as NewOfflineObserverWithSchema does.
+func ReadOfflineEnvironments(r io.Reader, schema *OfflineSchema, policy RowErrorPolicy) (*OfflineObserver, error) {
+	if schema == nil {
+		return nil, fmt.Errorf("schema is required")
+	}
+	csvReader := csv.NewReader(r)
+	csvReader.FieldsPerRecord = -1
+
+	header, err := csvReader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read CSV header: %v", err)
+	}
+	cols, err := schema.resolve(header)
 	if err != nil {
 		return nil, err
 	}
+	required := make(map[OfflineField]bool)
+	for _, f := range requiredFields[schema.Kind] {
+		required[f] = true
+	}
+
+	obs := &OfflineObserver{}
+	for {
+		row, err := csvReader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				rowErr := &RowError{Line: parseErr.Line, Column: "-", Err: parseErr.Err}
+				if policy == RowErrorFail {
+					return nil, rowErr
+				}
+				obs.RowErrors = append(obs.RowErrors, rowErr)
+				continue
+			}
+			return nil, fmt.Errorf("unable to parse CSV: %v", err)
+		}
+		line, _ := csvReader.FieldPos(0)
+
+		values, rowErr := parseRow(row, line, cols, required, schema)
+		if rowErr != nil {
+			if policy == RowErrorFail {
+				return nil, rowErr
+			}
+			obs.RowErrors = append(obs.RowErrors, rowErr)
+			continue
+		}
+		obs.Envs = append(obs.Envs, newOfflineEnvironment(schema.Kind, values))
+	}
+	if len(obs.Envs) == 0 {
+		return nil, fmt.Errorf("not enough data")
+	}
+	return obs, nil
+}
 
-	return &OfflineObserver{
-		Data:      rows[1:],
-		CurrIndex: 0,
-	}, nil
+// parseRow converts the mapped cells of one row to numbers. Empty or absent optional cells are
+// zero; an empty required cell or a non-numeric cell is a RowError.
+func parseRow(row []string, line int, cols map[OfflineField]int, required map[OfflineField]bool,
+	schema *OfflineSchema) (map[OfflineField]float64, *RowError) {
+	values := make(map[OfflineField]float64, len(cols))
+	for _, field := range allFields {
+		i, ok := cols[field]
+		if !ok {
+			continue
+		}
+		cell := ""
+		if i < len(row) {
+			cell = strings.TrimSpace(row[i])
+		}
+		if cell == "" {
+			if required[field] {
+				return nil, &RowError{Line: line, Column: schema.Columns[field], Err: fmt.Errorf("missing value")}
+			}
+			continue
+		}
+		v, err := strconv.ParseFloat(cell, 32)
+		if err != nil {
+			return nil, &RowError{Line: line, Column: schema.Columns[field], Err: err}
+		}
+		values[field] = v
+	}
+	return values, nil
+}
+
+func newOfflineEnvironment(kind string, v map[OfflineField]float64) core.Environment {
+	if kind == EnvKindPrefillDecode {
+		env := core.NewEnvironmentPrefillDecode(float32(v[FieldLambda]), float32(v[FieldBatchSize]),
+			float32(v[FieldAvgQueueTime]), int(v[FieldMaxBatchSize]),
+			float32(v[FieldAvgInputTokens]), float32(v[FieldAvgOutputTokens]),
+			float32(v[FieldAvgTTFT]), float32(v[FieldAvgITL]))
+		env.MaxQueueSize = int(v[FieldMaxQueueSize])
+		return env
+	}
+	env := core.NewEnvironmentDecode(float32(v[FieldLambda]), float32(v[FieldBatchSize]),
+		float32(v[FieldAvgQueueTime]), int(v[FieldMaxBatchSize]),
+		float32(v[FieldAvgOutputTokens]), float32(v[FieldAvgITL]))
+	env.MaxQueueSize = int(v[FieldMaxQueueSize])
+	return env
 }
 
 func (o *OfflineObserver) GetEnvironment() core.Environment {
-	if o.CurrIndex >= len(o.Data) {
+	if o.CurrIndex >= len(o.Envs) {
 		fmt.Println("Warning: No more data to read the environement")
 		return nil
 	}
-
-	row := o.Data[o.CurrIndex]
+	env := o.Envs[o.CurrIndex]
 	o.CurrIndex++
-
-	lambda, _ := strconv.ParseFloat(row[0], 32)
-	avgTokens, _ := strconv.ParseFloat(row[1], 32)
-	batchSize, _ := strconv.ParseFloat(row[2], 32)
-	maxBatchSize, _ := strconv.ParseFloat(row[3], 32)
-	avgQueueTime, _ := strconv.ParseFloat(row[4], 32)
-	avgITL, _ := strconv.ParseFloat(row[5], 32)
-
-	// create environment with input parameters
-	env := core.NewEnvironmentDecode(float32(lambda), float32(batchSize), float32(avgQueueTime),
-		int(maxBatchSize),
-		float32(avgTokens), float32(avgITL))
 	return env
 }
+
+// Len returns the number of environments loaded from the file.
+func (o *OfflineObserver) Len() int {
+	return len(o.Envs)
+}
+
+func (o *OfflineObserver) Reset() {
+	o.CurrIndex = 0
+}
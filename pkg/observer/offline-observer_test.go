@@ -0,0 +1,106 @@
+package observer
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/llm-inferno/model-tuner/pkg/core"
+)
+
+func TestReadOfflineEnvironments_PrefillDecodeByHeader(t *testing.T) {
+	// Columns deliberately out of schema order; batchSize/avgQueueTime absent (optional).
+	data := "avgITL,avgTTFT,lambda,avgOutputTokens,avgInputTokens,maxQueueSize,maxBatchSize\n" +
+		"9.8,20.2,407,458,254,128,512\n" +
+		"9.5,18.7,401,465,209,,512\n"
+
+	obs, err := ReadOfflineEnvironments(strings.NewReader(data), DefaultPrefillDecodeSchema(), RowErrorFail)
+	if err != nil {
+		t.Fatalf("ReadOfflineEnvironments: %v", err)
+	}
+	if obs.Len() != 2 {
+		t.Fatalf("expected 2 environments, got %d", obs.Len())
+	}
+	env, ok := obs.GetEnvironment().(*core.EnvironmentPrefillDecode)
+	if !ok {
+		t.Fatal("expected *core.EnvironmentPrefillDecode")
+	}
+	if env.Lambda != 407 || env.AvgInputTokens != 254 || env.AvgOutputTokens != 458 ||
+		env.AvgTTFT != 20.2 || env.AvgITL != 9.8 || env.MaxBatchSize != 512 || env.MaxQueueSize != 128 {
+		t.Errorf("fields mapped incorrectly: %s (maxQueue=%d)", env.String(), env.MaxQueueSize)
+	}
+	second := obs.GetEnvironment().(*core.EnvironmentPrefillDecode)
+	if second.MaxQueueSize != 0 {
+		t.Errorf("empty optional cell: MaxQueueSize = %d, want 0", second.MaxQueueSize)
+	}
+	if obs.GetEnvironment() != nil {
+		t.Error("expected nil after the last row")
+	}
+}
+
+func TestReadOfflineEnvironments_LegacyDecodeWithBOM(t *testing.T) {
+	data := "\ufeffRPM,tokens,avgConcurrency,maxConcurrency,avgWait,avgITL\n" +
+		"636.05,1015.23,29,64,0.00336,2.713109\n"
+
+	obs, err := ReadOfflineEnvironments(strings.NewReader(data), DefaultDecodeSchema(), RowErrorFail)
+	if err != nil {
+		t.Fatalf("ReadOfflineEnvironments: %v", err)
+	}
+	env, ok := obs.GetEnvironment().(*core.EnvironmentDecode)
+	if !ok {
+		t.Fatal("expected *core.EnvironmentDecode")
+	}
+	if env.Lambda != 636.05 || env.MaxBatchSize != 64 || env.BatchSize != 29 || env.AvgITL != 2.713109 {
+		t.Errorf("fields mapped incorrectly: %s", env.String())
+	}
+}
+
+func TestReadOfflineEnvironments_BadRows(t *testing.T) {
+	data := "lambda,maxBatchSize,avgInputTokens,avgOutputTokens,avgTTFT,avgITL\n" +
+		"10,64,100,200,50,5\n" +
+		"abc,64,100,200,50,5\n" +
+		"12,64,,200,50,5\n" +
+		"14,64,100,200,50,5\n"
+
+	_, err := ReadOfflineEnvironments(strings.NewReader(data), DefaultPrefillDecodeSchema(), RowErrorFail)
+	var rowErr *RowError
+	if !errors.As(err, &rowErr) || rowErr.Line != 3 || rowErr.Column != "lambda" {
+		t.Fatalf("expected RowError at line 3 column lambda, got %v", err)
+	}
+
+	obs, err := ReadOfflineEnvironments(strings.NewReader(data), DefaultPrefillDecodeSchema(), RowErrorSkip)
+	if err != nil {
+		t.Fatalf("skip policy: %v", err)
+	}
+	if obs.Len() != 2 || len(obs.RowErrors) != 2 {
+		t.Fatalf("expected 2 environments and 2 row errors, got %d and %d", obs.Len(), len(obs.RowErrors))
+	}
+	if obs.RowErrors[1].Line != 4 || obs.RowErrors[1].Column != "avgInputTokens" {
+		t.Errorf("expected missing avgInputTokens at line 4, got %v", obs.RowErrors[1])
+	}
+}
+
+func TestReadOfflineEnvironments_DuplicateColumn(t *testing.T) {
+	for _, header := range []string{
+		"lambda,maxBatchSize,avgInputTokens,avgOutputTokens,avgTTFT,avgITL,AvgTTFT ",
+		"lambda,maxBatchSize,avgInputTokens,avgOutputTokens,avgTTFT,avgITL,lambda",
+	} {
+		data := header + "\n10,64,100,200,50,5,60\n"
+		if _, err := ReadOfflineEnvironments(strings.NewReader(data), DefaultPrefillDecodeSchema(), RowErrorSkip); err == nil ||
+			!strings.Contains(err.Error(), "duplicate column") {
+			t.Errorf("header %q: err = %v, want a duplicate column error", header, err)
+		}
+	}
+	// unnamed columns, e.g. from trailing commas, do not collide
+	data := "lambda,maxBatchSize,avgInputTokens,avgOutputTokens,avgTTFT,avgITL,,\n10,64,100,200,50,5,,\n"
+	if obs, err := ReadOfflineEnvironments(strings.NewReader(data), DefaultPrefillDecodeSchema(), RowErrorFail); err != nil || obs.Len() != 1 {
+		t.Errorf("trailing empty columns: err = %v", err)
+	}
+}
+
+func TestReadOfflineEnvironments_MissingRequiredColumn(t *testing.T) {
+	data := "lambda,maxBatchSize,avgOutputTokens,avgITL\n10,64,200,5\n"
+	if _, err := ReadOfflineEnvironments(strings.NewReader(data), DefaultPrefillDecodeSchema(), RowErrorSkip); err == nil {
+		t.Fatal("expected error for missing avgTTFT/avgInputTokens columns")
+	}
+}
@@ -0,0 +1,120 @@
+package observer
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Environment kinds an observer can produce; the names match the kinds accepted by
+// core.SetupTunerForQueueingModel.
+const (
+	EnvKindDecode        = "decode"
+	EnvKindPrefillDecode = "prefill-decode"
+)
+
+// OfflineField identifies an Environment field populated from a CSV column.
+type OfflineField string
+
+const (
+	FieldLambda          OfflineField = "lambda"          // request arrival rate (per minute)
+	FieldBatchSize       OfflineField = "batchSize"       // average batch size
+	FieldAvgQueueTime    OfflineField = "avgQueueTime"    // average queueing time (msec)
+	FieldMaxBatchSize    OfflineField = "maxBatchSize"    // maximum batch size
+	FieldMaxQueueSize    OfflineField = "maxQueueSize"    // maximum external queue depth
+	FieldAvgInputTokens  OfflineField = "avgInputTokens"  // average input tokens per request
+	FieldAvgOutputTokens OfflineField = "avgOutputTokens" // average output tokens per request
+	FieldAvgTTFT         OfflineField = "avgTTFT"         // average time to first token (msec)
+	FieldAvgITL          OfflineField = "avgITL"          // average inter token latency (msec)
+)
+
+// allFields lists every OfflineField in a fixed order, so row parsing reports errors
+// deterministically.
+var allFields = []OfflineField{
+	FieldLambda, FieldBatchSize, FieldAvgQueueTime, FieldMaxBatchSize, FieldMaxQueueSize,
+	FieldAvgInputTokens, FieldAvgOutputTokens, FieldAvgTTFT, FieldAvgITL,
+}
+
+// fields required in the CSV header for each environment kind; all other fields are optional
+// and default to zero when their column is absent or a cell is empty.
+var requiredFields = map[string][]OfflineField{
+	EnvKindDecode: {
+		FieldLambda, FieldMaxBatchSize, FieldAvgOutputTokens, FieldAvgITL,
+	},
+	EnvKindPrefillDecode: {
+		FieldLambda, FieldMaxBatchSize, FieldAvgInputTokens, FieldAvgOutputTokens, FieldAvgTTFT, FieldAvgITL,
+	},
+}
+
+// OfflineSchema maps Environment fields to CSV header names for the OfflineObserver. Header
+// names are matched case-insensitively, ignoring surrounding whitespace and a UTF-8 BOM, so
+// column order in the file does not matter.
+type OfflineSchema struct {
+	Kind    string                  // EnvKindDecode or EnvKindPrefillDecode
+	Columns map[OfflineField]string // field -> CSV header name
+}
+
+// DefaultDecodeSchema returns the schema of the legacy decode CSV format
+// (RPM,tokens,avgConcurrency,maxConcurrency,avgWait,avgITL), e.g. demos/offline-observer/tuner-exp13.csv.
+func DefaultDecodeSchema() *OfflineSchema {
+	return &OfflineSchema{
+		Kind: EnvKindDecode,
+		Columns: map[OfflineField]string{
+			FieldLambda:          "RPM",
+			FieldAvgOutputTokens: "tokens",
+			FieldBatchSize:       "avgConcurrency",
+			FieldMaxBatchSize:    "maxConcurrency",
+			FieldAvgQueueTime:    "avgWait",
+			FieldAvgITL:          "avgITL",
+			FieldMaxQueueSize:    "maxQueueSize",
+		},
+	}
+}
+
+// DefaultPrefillDecodeSchema returns a prefill-decode schema whose header names are the field
+// names themselves (lambda, batchSize, avgQueueTime, maxBatchSize, maxQueueSize, avgInputTokens,
+// avgOutputTokens, avgTTFT, avgITL).
+func DefaultPrefillDecodeSchema() *OfflineSchema {
+	columns := make(map[OfflineField]string)
+	for _, f := range allFields {
+		columns[f] = string(f)
+	}
+	return &OfflineSchema{Kind: EnvKindPrefillDecode, Columns: columns}
+}
+
+// resolve maps each schema field to its column index in header. It fails if the kind is unknown,
+// two header names are equal once normalized (so a field could read either column), or a field
+// required by the kind has no matching column; optional fields without a column are omitted from
+// the result. Empty header names are ignored.
+func (s *OfflineSchema) resolve(header []string) (map[OfflineField]int, error) {
+	required, ok := requiredFields[s.Kind]
+	if !ok {
+		return nil, fmt.Errorf("unknown environment kind %q", s.Kind)
+	}
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		key := normalizeHeader(name)
+		if key == "" {
+			continue
+		}
+		if j, ok := index[key]; ok {
+			return nil, fmt.Errorf("duplicate column %q (columns %d and %d)", key, j+1, i+1)
+		}
+		index[key] = i
+	}
+	cols := make(map[OfflineField]int, len(s.Columns))
+	for field, name := range s.Columns {
+		if i, ok := index[normalizeHeader(name)]; ok {
+			cols[field] = i
+		}
+	}
+	for _, field := range required {
+		if _, ok := cols[field]; !ok {
+			return nil, fmt.Errorf("missing column for required field %s (header %q)", field, s.Columns[field])
+		}
+	}
+	return cols, nil
+}
+
+func normalizeHeader(name string) string {
+	return strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+}
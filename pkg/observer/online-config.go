@@ -0,0 +1,198 @@
+package observer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/llm-inferno/model-tuner/pkg/core"
+)
+
+// Names of the PromQL queries used by the OnlineObserver. Each query must aggregate by the
+// model and accelerator labels (the {{.By}} template parameter) and return values in tuner
+// units: rates per minute, times in msec. The rpm query is the pair's total over its replicas;
+// it is divided by the replicas query to give the per-replica rate the tuner fits.
+const (
+	QueryRPM             = "rpm"             // request arrival rate (per minute)
+	QueryAvgInputTokens  = "avgInputTokens"  // average prompt tokens per request
+	QueryAvgOutputTokens = "avgOutputTokens" // average generation tokens per request
+	QueryBatchSize       = "batchSize"       // average number of running requests
+	QueryAvgQueueTime    = "avgQueueTime"    // average queueing time (msec)
+	QueryAvgTTFT         = "avgTTFT"         // average time to first token (msec)
+	QueryAvgITL          = "avgITL"          // average inter token latency (msec)
+	QueryMaxBatchSize    = "maxBatchSize"    // optional: maximum batch size
+	QueryReplicas        = "replicas"        // optional: number of replicas serving the pair (default 1)
+)
+
+// requiredQueries must be present in an OnlineObserverConfig; the rest are optional.
+var requiredQueries = []string{
+	QueryRPM, QueryAvgInputTokens, QueryAvgOutputTokens, QueryAvgTTFT, QueryAvgITL,
+}
+
+// Environment variable naming an OnlineObserverConfig JSON file read by NewOnlineObserver.
+const OnlineObserverConfigEnvName = "ONLINE_OBSERVER_CONFIG"
+
+// OnlineObserverConfig holds the PromQL query templates and their parameters for the
+// OnlineObserver. Templates are Go text/templates over QueryParams.
+type OnlineObserverConfig struct {
+	Namespace        string            `json:"namespace"`        // Kubernetes namespace of the model servers
+	ModelPattern     string            `json:"modelPattern"`     // regex matched against the model label
+	Window           string            `json:"window"`           // PromQL range window, e.g. "1m"
+	ModelLabel       string            `json:"modelLabel"`       // label carrying the model name
+	AcceleratorLabel string            `json:"acceleratorLabel"` // label carrying the accelerator ("" = not labelled)
+	Accelerator      string            `json:"accelerator"`      // accelerator name used when a series has no accelerator label
+	MaxBatchSize     int               `json:"maxBatchSize"`     // used when the maxBatchSize query is absent or empty
+	MaxQueueSize     int               `json:"maxQueueSize"`     // maximum external queue depth (0 = no external queue)
+	Queries          map[string]string `json:"queries"`          // query name -> PromQL template
+}
+
+// QueryParams are the parameters available to query templates.
+type QueryParams struct {
+	Namespace string // OnlineObserverConfig.Namespace
+	Model     string // OnlineObserverConfig.ModelPattern
+	Window    string // OnlineObserverConfig.Window
+	By        string // comma-separated grouping labels (model, and accelerator if labelled)
+}
+
+// DefaultOnlineObserverConfig returns queries over the standard vLLM metrics, grouped by the
+// vLLM model_name label. vLLM does not label series with an accelerator, so all pairs report
+// Accelerator unless AcceleratorLabel is set to a label added by relabelling.
+func DefaultOnlineObserverConfig() *OnlineObserverConfig {
+	sel := `{namespace="{{.Namespace}}", model_name=~"{{.Model}}"}`
+	rate := func(metric string) string {
+		return fmt.Sprintf(`sum by ({{.By}}) (rate(%s%s[{{.Window}}]))`, metric, sel)
+	}
+	return &OnlineObserverConfig{
+		Namespace:        "platform-opt",
+		ModelPattern:     ".*opt-125.*",
+		Window:           "1m",
+		ModelLabel:       "model_name",
+		AcceleratorLabel: "",
+		Accelerator:      "unknown",
+		MaxBatchSize:     256,
+		MaxQueueSize:     0,
+		Queries: map[string]string{
+			QueryRPM:             rate("vllm:request_success_total") + " * 60",
+			QueryAvgInputTokens:  rate("vllm:prompt_tokens_total") + " / " + rate("vllm:request_success_total"),
+			QueryAvgOutputTokens: rate("vllm:generation_tokens_total") + " / " + rate("vllm:request_success_total"),
+			QueryBatchSize:       fmt.Sprintf(`avg by ({{.By}}) (avg_over_time(vllm:num_requests_running%s[{{.Window}}]))`, sel),
+			QueryAvgQueueTime:    rate("vllm:request_queue_time_seconds_sum") + " / " + rate("vllm:request_queue_time_seconds_count") + " * 1000",
+			QueryAvgTTFT:         rate("vllm:time_to_first_token_seconds_sum") + " / " + rate("vllm:time_to_first_token_seconds_count") + " * 1000",
+			QueryAvgITL:          rate("vllm:time_per_output_token_seconds_sum") + " / " + rate("vllm:time_per_output_token_seconds_count") + " * 1000",
+			// every vLLM pod exports one num_requests_running series
+			QueryReplicas: fmt.Sprintf(`count by ({{.By}}) (vllm:num_requests_running%s)`, sel),
+		},
+	}
+}
+
+// LoadOnlineObserverConfig reads a JSON config file over DefaultOnlineObserverConfig: fields set
+// in the file override the defaults, and entries in its "queries" object override (or add to) the
+// default queries by name.
+func LoadOnlineObserverConfig(path string) (*OnlineObserverConfig, error) {
+	byteValue, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read online observer config: %w", err)
+	}
+	cfg := DefaultOnlineObserverConfig()
+	defaultQueries := cfg.Queries
+	cfg.Queries = nil
+	if err := json.Unmarshal(byteValue, cfg); err != nil {
+		return nil, fmt.Errorf("error unmarshalling json data in file %s: %v", path, err)
+	}
+	for name, q := range defaultQueries {
+		if _, ok := cfg.Queries[name]; !ok {
+			if cfg.Queries == nil {
+				cfg.Queries = make(map[string]string)
+			}
+			cfg.Queries[name] = q
+		}
+	}
+	return cfg, cfg.Validate()
+}
+
+// Validate checks that the config names a model label and all required queries.
+func (c *OnlineObserverConfig) Validate() error {
+	if c.ModelLabel == "" {
+		return fmt.Errorf("modelLabel is required")
+	}
+	if c.AcceleratorLabel == "" && c.Accelerator == "" {
+		return fmt.Errorf("one of acceleratorLabel or accelerator is required")
+	}
+	for _, name := range requiredQueries {
+		if strings.TrimSpace(c.Queries[name]) == "" {
+			return fmt.Errorf("query %q is required", name)
+		}
+	}
+	return nil
+}
+
+// params returns the template parameters derived from the config.
+func (c *OnlineObserverConfig) params() QueryParams {
+	by := c.ModelLabel
+	if c.AcceleratorLabel != "" {
+		by += ", " + c.AcceleratorLabel
+	}
+	return QueryParams{Namespace: c.Namespace, Model: c.ModelPattern, Window: c.Window, By: by}
+}
+
+// RenderQueries expands every query template with the config parameters.
+func (c *OnlineObserverConfig) RenderQueries() (map[string]string, error) {
+	return c.renderQueries(c.params())
+}
+
+func (c *OnlineObserverConfig) renderQueries(params QueryParams) (map[string]string, error) {
+	out := make(map[string]string, len(c.Queries))
+	for name, text := range c.Queries {
+		tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("query %q: %w", name, err)
+		}
+		var b bytes.Buffer
+		if err := tmpl.Execute(&b, params); err != nil {
+			return nil, fmt.Errorf("query %q: %w", name, err)
+		}
+		out[name] = b.String()
+	}
+	return out, nil
+}
+
+// newEnvironment builds the environment of one average replica from query values keyed by query
+// name, or returns nil if a required value is missing: the rpm value is divided by the replicas
+// value. Missing optional values default to zero (maxBatchSize to the config value, replicas to
+// one).
+func (c *OnlineObserverConfig) newEnvironment(values map[string]float64) *core.EnvironmentPrefillDecode {
+	for _, name := range requiredQueries {
+		if _, ok := values[name]; !ok {
+			return nil
+		}
+	}
+	maxBatchSize := c.MaxBatchSize
+	if v, ok := values[QueryMaxBatchSize]; ok && v > 0 {
+		maxBatchSize = int(v)
+	}
+	rpm := values[QueryRPM]
+	if replicas := values[QueryReplicas]; replicas > 1 {
+		rpm /= replicas
+	}
+	env := core.NewEnvironmentPrefillDecode(float32(rpm), float32(values[QueryBatchSize]),
+		float32(values[QueryAvgQueueTime]), maxBatchSize,
+		float32(values[QueryAvgInputTokens]), float32(values[QueryAvgOutputTokens]),
+		float32(values[QueryAvgTTFT]), float32(values[QueryAvgITL]))
+	env.MaxQueueSize = c.MaxQueueSize
+	return env
+}
+
+// pairOf keys a series by its (model, accelerator) labels, using the config accelerator when the
+// series is not labelled with one.
+func (c *OnlineObserverConfig) pairOf(labels map[string]string) pairKey {
+	acc := c.Accelerator
+	if c.AcceleratorLabel != "" {
+		if v := labels[c.AcceleratorLabel]; v != "" {
+			acc = v
+		}
+	}
+	return pairKey{model: labels[c.ModelLabel], accelerator: acc}
+}
@@ -0,0 +1,105 @@
+package observer
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/prometheus/common/model"
+
+	"github.com/llm-inferno/model-tuner/pkg/core"
+	"github.com/llm-inferno/model-tuner/pkg/metrics"
+)
+
+// PrometheusHistory reconstructs past observations of a (model, accelerator) pair from
+// Prometheus range queries over the OnlineObserverConfig queries: one environment per query
+// window, for the last `windows` windows. It satisfies the history source used by
+// pkg/service to bootstrap the init phase of a newly seen pair.
+type PrometheusHistory struct {
+	promClient *metrics.PrometheusClient
+	config     *OnlineObserverConfig
+	windows    int
+	step       time.Duration
+	now        func() time.Time
+}
+
+// NewPrometheusHistory creates a PrometheusHistory returning up to windows observations.
+func NewPrometheusHistory(client *metrics.PrometheusClient, config *OnlineObserverConfig, windows int) (*PrometheusHistory, error) {
+	if client == nil {
+		return nil, fmt.Errorf("prometheus client is required")
+	}
+	if windows < 1 {
+		return nil, fmt.Errorf("windows must be positive, got %d", windows)
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	step, err := model.ParseDuration(config.Window)
+	if err != nil {
+		return nil, fmt.Errorf("invalid window %q: %w", config.Window, err)
+	}
+	return &PrometheusHistory{
+		promClient: client,
+		config:     config,
+		windows:    windows,
+		step:       time.Duration(step),
+		now:        time.Now,
+	}, nil
+}
+
+// History returns the pair's observations over the last windows non-overlapping query windows,
+// oldest first. Windows without traffic, with a missing required value, or whose mean is NaN
+// (0/0 over an idle window) are skipped, so fewer than windows observations (possibly none) may
+// be returned.
+func (h *PrometheusHistory) History(modelName, accelerator string) ([]*core.EnvironmentPrefillDecode, error) {
+	params := h.config.params()
+	params.Model = regexp.QuoteMeta(modelName)
+	queries, err := h.config.renderQueries(params)
+	if err != nil {
+		return nil, err
+	}
+
+	end := h.now()
+	start := end.Add(-time.Duration(h.windows-1) * h.step)
+	values := make(map[time.Time]map[string]float64)
+	for name, query := range queries {
+		series, err := h.promClient.QueryRange(query, start, end, h.step)
+		if err != nil {
+			return nil, fmt.Errorf("fetching %s history: %w", name, err)
+		}
+		for _, s := range series {
+			pair := h.config.pairOf(s.Labels)
+			if pair.model != modelName || (h.config.AcceleratorLabel != "" && pair.accelerator != accelerator) {
+				continue
+			}
+			for _, p := range s.Points {
+				if math.IsNaN(p.Value) {
+					continue
+				}
+				if values[p.Time] == nil {
+					values[p.Time] = make(map[string]float64)
+				}
+				values[p.Time][name] = p.Value
+			}
+		}
+	}
+
+	times := make([]time.Time, 0, len(values))
+	for t := range values {
+		times = append(times, t)
+	}
+	sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+
+	var out []*core.EnvironmentPrefillDecode
+	for _, t := range times {
+		if values[t][QueryRPM] <= 0 {
+			continue
+		}
+		if env := h.config.newEnvironment(values[t]); env != nil {
+			out = append(out, env)
+		}
+	}
+	return out, nil
+}
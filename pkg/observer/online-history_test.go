@@ -0,0 +1,120 @@
+package observer
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/llm-inferno/model-tuner/pkg/metrics"
+)
+
+// fakeRangeSeries is one series returned by the fake Prometheus for a range query: values by
+// unix timestamp.
+type fakeRangeSeries struct {
+	labels map[string]string
+	values map[int64]string
+}
+
+// newFakePrometheusRange serves /api/v1/query_range like newFakePrometheus serves instant
+// queries, and records the queries it received.
+func newFakePrometheusRange(t *testing.T, series map[string][]fakeRangeSeries, queries *[]string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		query := r.Form.Get("query")
+		*queries = append(*queries, query)
+		result := []map[string]any{}
+		for key, ss := range series {
+			if !strings.Contains(query, key) {
+				continue
+			}
+			for _, s := range ss {
+				values := [][]any{}
+				for ts := int64(1700000000); ts <= 1700000360; ts += 120 {
+					if v, ok := s.values[ts]; ok {
+						values = append(values, []any{ts, v})
+					}
+				}
+				result = append(result, map[string]any{"metric": s.labels, "values": values})
+			}
+			break
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"status": "success",
+			"data":   map[string]any{"resultType": "matrix", "result": result},
+		})
+	}))
+}
+
+func TestPrometheusHistory_History(t *testing.T) {
+	llama := map[string]string{"model_name": "llama-3.1", "accelerator": "H100"}
+	other := map[string]string{"model_name": "llama-3.1", "accelerator": "A100"}
+	// t0..t3 at 120s steps: t1 is idle (rpm 0), t2 has NaN TTFT (no completed requests).
+	const t0, t1, t2, t3 = 1700000000, 1700000120, 1700000240, 1700000360
+	var queries []string
+	srv := newFakePrometheusRange(t, map[string][]fakeRangeSeries{
+		"q_rpm":  {{llama, map[int64]string{t0: "60", t1: "0", t2: "90", t3: "120"}}, {other, map[int64]string{t0: "5"}}},
+		"q_in":   {{llama, map[int64]string{t0: "512", t1: "NaN", t2: "512", t3: "256"}}},
+		"q_out":  {{llama, map[int64]string{t0: "128", t1: "NaN", t2: "128", t3: "64"}}},
+		"q_ttft": {{llama, map[int64]string{t0: "40", t1: "NaN", t2: "NaN", t3: "55"}}},
+		"q_itl":  {{llama, map[int64]string{t0: "8", t1: "NaN", t2: "9", t3: "10"}}},
+	}, &queries)
+	defer srv.Close()
+
+	client, err := metrics.NewPrometheusClient(srv.URL, "")
+	if err != nil {
+		t.Fatalf("NewPrometheusClient: %v", err)
+	}
+	config := testOnlineConfig()
+	config.Queries[QueryRPM] = `q_rpm{model=~"{{.Model}}"}`
+	h, err := NewPrometheusHistory(client, config, 4)
+	if err != nil {
+		t.Fatalf("NewPrometheusHistory: %v", err)
+	}
+	h.now = func() time.Time { return time.Unix(t3, 0) }
+
+	envs, err := h.History("llama-3.1", "H100")
+	if err != nil {
+		t.Fatalf("History: %v", err)
+	}
+	if len(envs) != 2 {
+		t.Fatalf("expected 2 observations (idle and NaN windows skipped), got %d", len(envs))
+	}
+	if first, last := envs[0], envs[1]; first.Lambda != 60 || first.AvgTTFT != 40 ||
+		last.Lambda != 120 || last.AvgInputTokens != 256 || last.AvgITL != 10 || last.MaxBatchSize != 64 {
+		t.Errorf("unexpected observations: %s, %s", first.String(), last.String())
+	}
+
+	// The model name is regex-escaped into the pattern.
+	want := fmt.Sprintf(`q_rpm{model=~"%s"}`, `llama-3\.1`)
+	found := false
+	for _, q := range queries {
+		found = found || q == want
+	}
+	if !found {
+		t.Errorf("expected query %s, got %v", want, queries)
+	}
+}
+
+func TestNewPrometheusHistory_Validation(t *testing.T) {
+	client, err := metrics.NewPrometheusClient("http://localhost:9090", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewPrometheusHistory(client, testOnlineConfig(), 0); err == nil {
+		t.Error("expected error for zero windows")
+	}
+	config := testOnlineConfig()
+	config.Window = "two minutes"
+	if _, err := NewPrometheusHistory(client, config, 5); err == nil {
+		t.Error("expected error for an invalid window")
+	}
+}
@@ -1,6 +1,6 @@
 /*
 Online Observer acts as Prometheus client and the environement struct is returned using Prometheus queries
-To be able to use Online Observer, the user must set the secret bearer token and prometheus address as environment variable
+To be able to use Online Observer, the user must set the prometheus address (and, if required, the secret bearer token) as environment variables
 */
 
 package observer
@@ -8,65 +8,144 @@ package observer
 import (
 	"fmt"
 	"log"
+	"math"
 	"os"
+	"sort"
 
 	"github.com/llm-inferno/model-tuner/pkg/core"
 	"github.com/llm-inferno/model-tuner/pkg/metrics"
 )
 
+// PairEnvironment is the environment observed for one (model, accelerator) pair.
+type PairEnvironment struct {
+	Model       string
+	Accelerator string
+	Env         *core.EnvironmentPrefillDecode
+}
+
 // observer is prom client that knows where to access the prometheus, and which queries to use to get the measurements from the environment
 type OnlineObserver struct {
 	BaseObserver
 	promClient *metrics.PrometheusClient
+	config     *OnlineObserverConfig
+	queries    map[string]string // rendered PromQL by query name
 }
 
+// NewOnlineObserver creates an OnlineObserver from the environment: PROMETHEUS_ADDRESS, an
+// optional bearer TOKEN, and an optional ONLINE_OBSERVER_CONFIG file (defaults otherwise).
 func NewOnlineObserver() (*OnlineObserver, error) {
-	secretToken := os.Getenv("TOKEN")
-	if secretToken == "" {
-		return nil, fmt.Errorf("TOKEN is not set")
-	}
 	promAddress := os.Getenv("PROMETHEUS_ADDRESS")
-	client, err := metrics.NewPrometheusClient(promAddress, secretToken)
+	if promAddress == "" {
+		return nil, fmt.Errorf("PROMETHEUS_ADDRESS is not set")
+	}
+	client, err := metrics.NewPrometheusClient(promAddress, os.Getenv("TOKEN"))
+	if err != nil {
+		return nil, err
+	}
+	config := DefaultOnlineObserverConfig()
+	if path := os.Getenv(OnlineObserverConfigEnvName); path != "" {
+		if config, err = LoadOnlineObserverConfig(path); err != nil {
+			return nil, err
+		}
+	}
+	return NewOnlineObserverWithConfig(client, config)
+}
+
+// NewOnlineObserverWithConfig creates an OnlineObserver that runs the config's queries with client.
+func NewOnlineObserverWithConfig(client *metrics.PrometheusClient, config *OnlineObserverConfig) (*OnlineObserver, error) {
+	if client == nil {
+		return nil, fmt.Errorf("prometheus client is required")
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	queries, err := config.RenderQueries()
 	if err != nil {
 		return nil, err
 	}
 	return &OnlineObserver{
 		BaseObserver: BaseObserver{},
 		promClient:   client,
+		config:       config,
+		queries:      queries,
 	}, nil
 }
 
+// GetEnvironment returns the environment of the first discovered pair (in model, accelerator
+// order), or nil if none could be observed. Use GetPairEnvironments to observe every pair.
 func (obs *OnlineObserver) GetEnvironment() core.Environment {
-	var namespace, modelName, duration string
-	namespace, modelName, duration = "platform-opt", "opt-125", "1m"
-	metrics := map[string]string{
-		"rpm":             fmt.Sprintf(`sum(rate(vllm:request_success_total{namespace="%s", model_name=~".*%s.*"}[%s]))`, namespace, modelName, duration),
-		"AvgOutputTokens": fmt.Sprintf(`sum(rate(vllm:generation_tokens_total{namespace="%s", model_name=~".*%s.*"}[%s])) / sum(rate(vllm:request_success_total{namespace="%s", model_name=~".*%s.*"}[%s]))`, namespace, modelName, duration, namespace, modelName, duration),
-		"batchSize":       fmt.Sprintf(`avg(avg_over_time(vllm:num_requests_running{namespace="%s", model_name=~".*%s.*"}[%s]))`, namespace, modelName, duration),
-		"avgWaitTime":     fmt.Sprintf(`sum(rate(vllm:request_queue_time_seconds_sum{namespace="%s", model_name=~".*%s.*"}[%s])) / sum(rate(vllm:request_queue_time_seconds_count{namespace="%s", model_name=~".*%s.*"}[%s]))`, namespace, modelName, duration, namespace, modelName, duration),
-		"avgITL":          fmt.Sprintf(`sum(rate(vllm:time_per_output_token_seconds_sum{namespace="%s", model_name=~".*%s.*"}[%s])) / sum(rate(vllm:time_per_output_token_seconds_count{namespace="%s", model_name=~".*%s.*"}[%s]))`, namespace, modelName, duration, namespace, modelName, duration),
+	pairs, err := obs.GetPairEnvironments()
+	if err != nil {
+		log.Printf("Error fetching environments: %v", err)
+		return nil
+	}
+	if len(pairs) == 0 {
+		log.Printf("No (model, accelerator) pairs with traffic")
+		return nil
 	}
+	return pairs[0].Env
+}
 
-	results := make(map[string]float64)
-	for key, query := range metrics {
-		value, err := obs.fetchMetric(query)
+// GetPairEnvironments queries Prometheus and returns one EnvironmentPrefillDecode per
+// (model, accelerator) pair with traffic, sorted by model then accelerator. Pairs are
+// discovered from the label values of the rpm query result; a pair missing a value for another
+// required query is skipped. Missing optional values default to zero (maxBatchSize to the
+// config value).
+func (obs *OnlineObserver) GetPairEnvironments() ([]PairEnvironment, error) {
+	values := make(map[string]map[pairKey]float64, len(obs.queries))
+	for name, query := range obs.queries {
+		byPair, err := obs.fetchByPair(query)
 		if err != nil {
-			log.Printf("Error fetching %s: %v", key, err)
-			return nil
+			return nil, fmt.Errorf("fetching %s: %w", name, err)
 		}
-		results[key] = value
+		values[name] = byPair
 	}
 
-	// create environment with input parameters
-	env := core.NewEnvironmentDecode(float32(results["rpm"])*60, float32(results["batchSize"]), float32(results["avgWaitTime"])*1000, 8,
-		float32(results["AvgOutputTokens"]), float32(results["avgITL"])*1000)
-	return env
+	var out []PairEnvironment
+	for pair, rpm := range values[QueryRPM] {
+		if rpm <= 0 {
+			continue
+		}
+		pairValues := make(map[string]float64, len(values))
+		for name, byPair := range values {
+			if v, ok := byPair[pair]; ok {
+				pairValues[name] = v
+			}
+		}
+		env := obs.config.newEnvironment(pairValues)
+		if env == nil {
+			log.Printf("Skipping %s/%s: incomplete metrics", pair.model, pair.accelerator)
+			continue
+		}
+		out = append(out, PairEnvironment{Model: pair.model, Accelerator: pair.accelerator, Env: env})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Model != out[j].Model {
+			return out[i].Model < out[j].Model
+		}
+		return out[i].Accelerator < out[j].Accelerator
+	})
+	return out, nil
+}
+
+type pairKey struct {
+	model       string
+	accelerator string
 }
 
-func (obs *OnlineObserver) fetchMetric(query string) (float64, error) {
-	value, err := obs.promClient.Query(query)
+// fetchByPair runs query and keys each result series by its (model, accelerator) labels. NaN
+// values (e.g. a 0/0 mean over an idle window) are dropped.
+func (obs *OnlineObserver) fetchByPair(query string) (map[pairKey]float64, error) {
+	samples, err := obs.promClient.QueryVector(query)
 	if err != nil {
-		return 0, err
+		return nil, err
+	}
+	out := make(map[pairKey]float64, len(samples))
+	for _, s := range samples {
+		if math.IsNaN(s.Value) {
+			continue
+		}
+		out[obs.config.pairOf(s.Labels)] = s.Value
 	}
-	return value, nil
+	return out, nil
 }
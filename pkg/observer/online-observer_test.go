@@ -0,0 +1,189 @@
+package observer
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/llm-inferno/model-tuner/pkg/metrics"
+)
+
+// fakeSeries is one series returned by the fake Prometheus for a query.
+type fakeSeries struct {
+	labels map[string]string
+	value  float64
+}
+
+// newFakePrometheus serves /api/v1/query, answering each query with the series registered for
+// the first key that the query contains.
+func newFakePrometheus(t *testing.T, series map[string][]fakeSeries) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		query := r.Form.Get("query")
+		result := []map[string]any{}
+		for key, ss := range series {
+			if !strings.Contains(query, key) {
+				continue
+			}
+			for _, s := range ss {
+				result = append(result, map[string]any{
+					"metric": s.labels,
+					"value":  []any{1700000000, fmt.Sprintf("%g", s.value)},
+				})
+			}
+			break
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"status": "success",
+			"data":   map[string]any{"resultType": "vector", "result": result},
+		})
+	}))
+}
+
+func testOnlineConfig() *OnlineObserverConfig {
+	return &OnlineObserverConfig{
+		Namespace:        "inferno",
+		ModelPattern:     ".*",
+		Window:           "2m",
+		ModelLabel:       "model_name",
+		AcceleratorLabel: "accelerator",
+		MaxBatchSize:     64,
+		MaxQueueSize:     16,
+		Queries: map[string]string{
+			QueryRPM:             `q_rpm{ns="{{.Namespace}}",by="{{.By}}",w="{{.Window}}"}`,
+			QueryAvgInputTokens:  `q_in{ns="{{.Namespace}}"}`,
+			QueryAvgOutputTokens: `q_out{ns="{{.Namespace}}"}`,
+			QueryAvgTTFT:         `q_ttft{ns="{{.Namespace}}"}`,
+			QueryAvgITL:          `q_itl{ns="{{.Namespace}}"}`,
+			QueryMaxBatchSize:    `q_maxbatch{ns="{{.Namespace}}"}`,
+		},
+	}
+}
+
+func TestOnlineObserver_GetPairEnvironments(t *testing.T) {
+	llamaH100 := map[string]string{"model_name": "llama", "accelerator": "H100"}
+	llamaA100 := map[string]string{"model_name": "llama", "accelerator": "A100"}
+	granite := map[string]string{"model_name": "granite", "accelerator": "H100"}
+	srv := newFakePrometheus(t, map[string][]fakeSeries{
+		"q_rpm":      {{llamaH100, 120}, {llamaA100, 60}, {granite, 30}},
+		"q_in":       {{llamaH100, 512}, {llamaA100, 256}, {granite, 128}},
+		"q_out":      {{llamaH100, 128}, {llamaA100, 64}, {granite, 32}},
+		"q_ttft":     {{llamaH100, 45}, {llamaA100, 80}},
+		"q_itl":      {{llamaH100, 8}, {llamaA100, 12}, {granite, 6}},
+		"q_maxbatch": {{llamaH100, 256}},
+	})
+	defer srv.Close()
+
+	client, err := metrics.NewPrometheusClient(srv.URL, "")
+	if err != nil {
+		t.Fatalf("NewPrometheusClient: %v", err)
+	}
+	obs, err := NewOnlineObserverWithConfig(client, testOnlineConfig())
+	if err != nil {
+		t.Fatalf("NewOnlineObserverWithConfig: %v", err)
+	}
+
+	pairs, err := obs.GetPairEnvironments()
+	if err != nil {
+		t.Fatalf("GetPairEnvironments: %v", err)
+	}
+	// granite has no TTFT series and is skipped; llama pairs are sorted by accelerator.
+	if len(pairs) != 2 {
+		t.Fatalf("expected 2 pairs, got %d: %+v", len(pairs), pairs)
+	}
+	a100, h100 := pairs[0], pairs[1]
+	if a100.Model != "llama" || a100.Accelerator != "A100" || h100.Accelerator != "H100" {
+		t.Fatalf("unexpected pair order: %+v", pairs)
+	}
+	if e := h100.Env; e.Lambda != 120 || e.AvgInputTokens != 512 || e.AvgOutputTokens != 128 ||
+		e.AvgTTFT != 45 || e.AvgITL != 8 || e.MaxBatchSize != 256 || e.MaxQueueSize != 16 {
+		t.Errorf("H100 environment mapped incorrectly: %s", e.String())
+	}
+	if a100.Env.MaxBatchSize != 64 {
+		t.Errorf("expected config maxBatchSize fallback 64, got %d", a100.Env.MaxBatchSize)
+	}
+	if env := obs.GetEnvironment(); env == nil {
+		t.Error("GetEnvironment returned nil")
+	} else if env.String() != a100.Env.String() {
+		t.Errorf("GetEnvironment should return the first pair, got %s", env.String())
+	}
+}
+
+func TestOnlineObserverConfig_RenderAndLoad(t *testing.T) {
+	rendered, err := testOnlineConfig().RenderQueries()
+	if err != nil {
+		t.Fatalf("RenderQueries: %v", err)
+	}
+	if want := `q_rpm{ns="inferno",by="model_name, accelerator",w="2m"}`; rendered[QueryRPM] != want {
+		t.Errorf("rpm query = %s, want %s", rendered[QueryRPM], want)
+	}
+
+	path := filepath.Join(t.TempDir(), "online.json")
+	body := `{"namespace": "prod", "queries": {"avgTTFT": "my_ttft{ns=\"{{.Namespace}}\"}"}}`
+	if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := LoadOnlineObserverConfig(path)
+	if err != nil {
+		t.Fatalf("LoadOnlineObserverConfig: %v", err)
+	}
+	rendered, err = cfg.RenderQueries()
+	if err != nil {
+		t.Fatalf("RenderQueries: %v", err)
+	}
+	if rendered[QueryAvgTTFT] != `my_ttft{ns="prod"}` {
+		t.Errorf("override not applied: %s", rendered[QueryAvgTTFT])
+	}
+	if !strings.Contains(rendered[QueryRPM], `namespace="prod"`) || !strings.Contains(rendered[QueryRPM], "by (model_name)") {
+		t.Errorf("default rpm query not rendered with file parameters: %s", rendered[QueryRPM])
+	}
+}
+
+func TestOnlineObserver_ServerSpecsPerReplica(t *testing.T) {
+	llama := map[string]string{"model_name": "llama", "accelerator": "H100"}
+	granite := map[string]string{"model_name": "granite", "accelerator": "H100"}
+	// llama runs on two replicas at 120 rpm each; granite reports no replica count
+	srv := newFakePrometheus(t, map[string][]fakeSeries{
+		"q_rpm":      {{llama, 240}, {granite, 30}},
+		"q_in":       {{llama, 512}, {granite, 128}},
+		"q_out":      {{llama, 128}, {granite, 32}},
+		"q_ttft":     {{llama, 45}, {granite, 20}},
+		"q_itl":      {{llama, 8}, {granite, 6}},
+		"q_replicas": {{llama, 2}},
+	})
+	defer srv.Close()
+
+	client, err := metrics.NewPrometheusClient(srv.URL, "")
+	if err != nil {
+		t.Fatalf("NewPrometheusClient: %v", err)
+	}
+	config := testOnlineConfig()
+	config.Queries[QueryReplicas] = `q_replicas{ns="{{.Namespace}}"}`
+	obs, err := NewOnlineObserverWithConfig(client, config)
+	if err != nil {
+		t.Fatalf("NewOnlineObserverWithConfig: %v", err)
+	}
+	specs, err := obs.ServerSpecs()
+	if err != nil {
+		t.Fatalf("ServerSpecs: %v", err)
+	}
+	if len(specs) != 2 {
+		t.Fatalf("expected 2 specs, got %d", len(specs))
+	}
+	for _, s := range specs {
+		want := map[string]float32{"llama": 120, "granite": 30}[s.Model]
+		if a := s.CurrentAlloc; a.Load.ArrivalRate != want || a.NumReplicas != 1 {
+			t.Errorf("%s: arrival rate %g on %d replicas, want %g per replica", s.Model, a.Load.ArrivalRate, a.NumReplicas, want)
+		}
+	}
+}
@@ -0,0 +1,301 @@
+package observer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+)
+
+// Drift types of a Signal.
+const (
+	DriftStep     = "step"     // add Delta from step Start on
+	DriftRamp     = "ramp"     // add 0 before Start, rising linearly to Delta at End, Delta after
+	DriftPeriodic = "periodic" // add Amplitude*sin(2*pi*(t-Start)/Period) from Start (until End, if set)
+)
+
+// Distributions of a TokenDistribution and noise types of a NoiseModel.
+const (
+	DistFixed       = "fixed"
+	DistUniform     = "uniform"
+	DistExponential = "exponential"
+	DistLogNormal   = "lognormal"
+
+	NoiseNone      = "none"
+	NoiseUniform   = "uniform"
+	NoiseGaussian  = "gaussian"
+	NoiseLogNormal = "lognormal"
+)
+
+// Scenario describes a simulated server over a number of observation steps: the true performance
+// parameters and their drift, the load profile, the request token-length distributions and the
+// measurement noise. Scenarios are loaded from JSON or YAML with LoadScenario; runs are
+// reproducible for a given Seed.
+type Scenario struct {
+	Name         string            `json:"name"`
+	Seed         uint64            `json:"seed"`         // RNG seed for token sampling and noise
+	Steps        int               `json:"steps"`        // observations; 0 = longest Signal trace
+	MaxBatchSize Signal            `json:"maxBatchSize"` // rounded to an integer
+	MaxQueueSize *int              `json:"maxQueueSize"` // omitted = 10 * maxBatchSize
+	Alpha        Signal            `json:"alpha"`
+	Beta         Signal            `json:"beta"`
+	Gamma        Signal            `json:"gamma"`
+	RPM          Signal            `json:"rpm"` // request arrival rate (per minute)
+	InputTokens  TokenDistribution `json:"inputTokens"`
+	OutputTokens TokenDistribution `json:"outputTokens"`
+	Noise        NoiseModel        `json:"noise"`
+}
+
+// Signal is a per-step value: a base (Value, or the Values trace, whose last entry repeats) plus
+// the sum of its drifts. In JSON/YAML a bare number is shorthand for {"value": n}.
+type Signal struct {
+	Value  float64   `json:"value"`
+	Values []float64 `json:"values"`
+	Drift  []Drift   `json:"drift"`
+}
+
+// Drift is one time-varying change added to a Signal.
+type Drift struct {
+	Type      string  `json:"type"` // DriftStep, DriftRamp or DriftPeriodic
+	Start     int     `json:"start"`
+	End       int     `json:"end"`
+	Delta     float64 `json:"delta"`
+	Amplitude float64 `json:"amplitude"`
+	Period    float64 `json:"period"`
+}
+
+// TokenDistribution is the per-request token-length distribution. The observed average of a step
+// is the mean of one draw per request arriving in a one-minute window (at least one, at most
+// maxTokenSamples), so it fluctuates less as load rises.
+type TokenDistribution struct {
+	Mean         Signal  `json:"mean"`
+	Distribution string  `json:"distribution"` // DistFixed (default), DistUniform, DistExponential or DistLogNormal
+	Spread       float64 `json:"spread"`       // uniform: relative half-width; lognormal: coefficient of variation
+}
+
+// NoiseModel is the multiplicative measurement noise applied to the arrival rate and to the
+// observed wait time, TTFT and ITL, plus optional outlier bursts on the latencies.
+type NoiseModel struct {
+	Type     string        `json:"type"`  // NoiseNone (default), NoiseUniform, NoiseGaussian or NoiseLogNormal
+	Level    Signal        `json:"level"` // uniform: relative half-width; gaussian: relative std dev; lognormal: sigma
+	Outliers *OutlierModel `json:"outliers"`
+}
+
+// OutlierModel starts, with Probability per step, a burst of Length steps (default 1) whose
+// latencies are multiplied by Factor.
+type OutlierModel struct {
+	Probability float64 `json:"probability"`
+	Factor      float64 `json:"factor"`
+	Length      int     `json:"length"`
+}
+
+// maxTokenSamples caps the token draws per step.
+const maxTokenSamples = 10000
+
+// UnmarshalJSON accepts a bare number as well as the object form.
+func (s *Signal) UnmarshalJSON(data []byte) error {
+	var v float64
+	if err := json.Unmarshal(data, &v); err == nil {
+		*s = Signal{Value: v}
+		return nil
+	}
+	type signal Signal
+	var out signal
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&out); err != nil {
+		return err
+	}
+	*s = Signal(out)
+	return nil
+}
+
+// At returns the signal value at step t.
+func (s *Signal) At(t int) float64 {
+	v := s.Value
+	if len(s.Values) > 0 {
+		v = s.Values[min(t, len(s.Values)-1)]
+	}
+	for _, d := range s.Drift {
+		v += d.at(t)
+	}
+	return v
+}
+
+func (d *Drift) at(t int) float64 {
+	if t < d.Start {
+		return 0
+	}
+	switch d.Type {
+	case DriftStep:
+		return d.Delta
+	case DriftRamp:
+		if t >= d.End {
+			return d.Delta
+		}
+		return d.Delta * float64(t-d.Start) / float64(d.End-d.Start)
+	case DriftPeriodic:
+		if d.End > 0 && t >= d.End {
+			return 0
+		}
+		return d.Amplitude * math.Sin(2*math.Pi*float64(t-d.Start)/d.Period)
+	}
+	return 0
+}
+
+func (s *Signal) validate(name string) error {
+	for i, d := range s.Drift {
+		switch d.Type {
+		case DriftStep:
+		case DriftRamp:
+			if d.End <= d.Start {
+				return fmt.Errorf("%s: drift %d: ramp end must be after start", name, i)
+			}
+		case DriftPeriodic:
+			if d.Period <= 0 {
+				return fmt.Errorf("%s: drift %d: period must be positive", name, i)
+			}
+		default:
+			return fmt.Errorf("%s: drift %d: unknown type %q", name, i, d.Type)
+		}
+	}
+	return nil
+}
+
+// sample returns the observed average token length at step t for the given arrival rate.
+func (td *TokenDistribution) sample(rng *rand.Rand, t int, rpm float64) float64 {
+	mean := td.Mean.At(t)
+	if td.Distribution == "" || td.Distribution == DistFixed || mean <= 0 {
+		return mean
+	}
+	n := int(math.Min(math.Max(math.Round(rpm), 1), maxTokenSamples))
+	var sum float64
+	for range n {
+		sum += td.draw(rng, mean)
+	}
+	return sum / float64(n)
+}
+
+// draw returns one request's token length for the given mean.
+func (td *TokenDistribution) draw(rng *rand.Rand, mean float64) float64 {
+	switch td.Distribution {
+	case DistUniform:
+		return mean * (1 + td.Spread*(2*rng.Float64()-1))
+	case DistExponential:
+		return mean * rng.ExpFloat64()
+	case DistLogNormal:
+		sigma := math.Sqrt(math.Log1p(td.Spread * td.Spread))
+		return mean * math.Exp(sigma*rng.NormFloat64()-sigma*sigma/2)
+	}
+	return mean
+}
+
+// factor draws one multiplicative noise factor at step t, clamped at zero.
+func (nm *NoiseModel) factor(rng *rand.Rand, t int) float64 {
+	level := nm.Level.At(t)
+	var f float64
+	switch nm.Type {
+	case NoiseUniform:
+		f = 1 + level*(2*rng.Float64()-1)
+	case NoiseGaussian:
+		f = 1 + level*rng.NormFloat64()
+	case NoiseLogNormal:
+		f = math.Exp(level*rng.NormFloat64() - level*level/2)
+	default:
+		return 1
+	}
+	return math.Max(f, 0)
+}
+
+// LoadScenario reads a scenario from a .json, .yaml or .yml file. Unknown fields are rejected.
+func LoadScenario(path string) (*Scenario, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scenario: %w", err)
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		if data, err = yaml.YAMLToJSON(data); err != nil {
+			return nil, fmt.Errorf("error parsing yaml scenario %s: %w", path, err)
+		}
+	}
+	s, err := ParseScenario(data)
+	if err != nil {
+		return nil, fmt.Errorf("scenario %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// ParseScenario decodes and validates a JSON scenario.
+func ParseScenario(data []byte) (*Scenario, error) {
+	s := &Scenario{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(s); err != nil {
+		return nil, err
+	}
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Validate checks the scenario and derives Steps from the longest trace when it is zero.
+func (s *Scenario) Validate() error {
+	signals := map[string]*Signal{
+		"maxBatchSize": &s.MaxBatchSize, "alpha": &s.Alpha, "beta": &s.Beta, "gamma": &s.Gamma,
+		"rpm": &s.RPM, "inputTokens.mean": &s.InputTokens.Mean, "outputTokens.mean": &s.OutputTokens.Mean,
+		"noise.level": &s.Noise.Level,
+	}
+	longest := 0
+	for name, sig := range signals {
+		if err := sig.validate(name); err != nil {
+			return err
+		}
+		longest = max(longest, len(sig.Values))
+	}
+	if s.Steps == 0 {
+		s.Steps = longest
+	}
+	if s.Steps < 1 {
+		return fmt.Errorf("steps must be positive")
+	}
+	if s.MaxQueueSize != nil && *s.MaxQueueSize < 0 {
+		return fmt.Errorf("maxQueueSize must not be negative")
+	}
+	for name, td := range map[string]*TokenDistribution{"inputTokens": &s.InputTokens, "outputTokens": &s.OutputTokens} {
+		switch td.Distribution {
+		case "", DistFixed, DistUniform, DistExponential, DistLogNormal:
+		default:
+			return fmt.Errorf("%s: unknown distribution %q", name, td.Distribution)
+		}
+		if td.Spread < 0 {
+			return fmt.Errorf("%s: spread must not be negative", name)
+		}
+	}
+	switch s.Noise.Type {
+	case "", NoiseNone, NoiseUniform, NoiseGaussian, NoiseLogNormal:
+	default:
+		return fmt.Errorf("noise: unknown type %q", s.Noise.Type)
+	}
+	if o := s.Noise.Outliers; o != nil {
+		if o.Probability < 0 || o.Probability > 1 {
+			return fmt.Errorf("noise.outliers: probability must be in [0, 1]")
+		}
+		if o.Factor <= 0 || o.Length < 0 {
+			return fmt.Errorf("noise.outliers: factor must be positive and length not negative")
+		}
+	}
+	return nil
+}
+
+// ParametersAt returns the true [alpha, beta, gamma] at step t, for comparing with tuned values.
+func (s *Scenario) ParametersAt(t int) [3]float64 {
+	return [3]float64{s.Alpha.At(t), s.Beta.At(t), s.Gamma.At(t)}
+}
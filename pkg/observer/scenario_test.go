@@ -0,0 +1,183 @@
+package observer
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/llm-inferno/model-tuner/pkg/core"
+)
+
+const testScenarioYAML = `
+name: drift
+seed: 42
+steps: 30
+maxBatchSize: 64
+alpha:
+  value: 16
+  drift:
+    - {type: step, start: 10, delta: 4}
+beta:
+  value: 0.04
+  drift:
+    - {type: ramp, start: 10, end: 20, delta: 0.04}
+gamma: 0.0002
+rpm:
+  value: 30
+  drift:
+    - {type: periodic, start: 0, period: 10, amplitude: 10}
+inputTokens: {mean: 1024, distribution: lognormal, spread: 0.5}
+outputTokens: {mean: 256, distribution: exponential}
+noise:
+  type: gaussian
+  level: 0.05
+  outliers: {probability: 0.2, factor: 3, length: 2}
+`
+
+func writeScenario(t *testing.T, name, body string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadScenario_YAMLAndDrift(t *testing.T) {
+	s, err := LoadScenario(writeScenario(t, "drift.yaml", testScenarioYAML))
+	if err != nil {
+		t.Fatalf("LoadScenario: %v", err)
+	}
+	cases := []struct {
+		step int
+		want [3]float64
+	}{
+		{0, [3]float64{16, 0.04, 0.0002}},
+		{15, [3]float64{20, 0.06, 0.0002}},
+		{25, [3]float64{20, 0.08, 0.0002}},
+	}
+	for _, c := range cases {
+		got := s.ParametersAt(c.step)
+		for k := range got {
+			if math.Abs(got[k]-c.want[k]) > 1e-12 {
+				t.Errorf("step %d: parameters %v, want %v", c.step, got, c.want)
+				break
+			}
+		}
+	}
+	if got := s.RPM.At(5); math.Abs(got-30) > 1e-9 {
+		t.Errorf("periodic rpm at half period = %g, want 30", got)
+	}
+	if got := s.RPM.At(2); got <= 30 {
+		t.Errorf("periodic rpm should be above base early in the period, got %g", got)
+	}
+}
+
+func TestLoadScenario_JSONTraceAndErrors(t *testing.T) {
+	body := `{"seed": 1, "maxBatchSize": 32, "alpha": {"values": [10, 11, 12]}, "beta": 0.03, "gamma": 0.0001,
+		"rpm": 20, "inputTokens": {"mean": 512}, "outputTokens": {"mean": 128}}`
+	s, err := LoadScenario(writeScenario(t, "trace.json", body))
+	if err != nil {
+		t.Fatalf("LoadScenario: %v", err)
+	}
+	if s.Steps != 3 || s.Alpha.At(1) != 11 || s.Alpha.At(10) != 12 {
+		t.Errorf("trace not applied: steps=%d alpha(1)=%g alpha(10)=%g", s.Steps, s.Alpha.At(1), s.Alpha.At(10))
+	}
+
+	for name, bad := range map[string]string{
+		"unknown field":  `{"steps": 1, "alhpa": 1}`,
+		"unknown drift":  `{"steps": 1, "alpha": {"value": 1, "drift": [{"type": "jump"}]}}`,
+		"no steps":       `{"alpha": 1}`,
+		"bad noise":      `{"steps": 1, "noise": {"type": "pink"}}`,
+		"ramp end":       `{"steps": 1, "rpm": {"value": 1, "drift": [{"type": "ramp", "start": 5, "end": 5}]}}`,
+		"bad outlier":    `{"steps": 1, "noise": {"outliers": {"probability": 2, "factor": 1}}}`,
+		"unknown nested": `{"steps": 1, "rpm": {"value": 1, "slope": 2}}`,
+	} {
+		if _, err := ParseScenario([]byte(bad)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestScenarioObserver_Reproducible(t *testing.T) {
+	run := func(seed uint64) []string {
+		s, err := LoadScenario(writeScenario(t, "drift.yml", testScenarioYAML))
+		if err != nil {
+			t.Fatal(err)
+		}
+		s.Seed = seed
+		obs, err := NewScenarioObserver(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var out []string
+		for range s.Steps {
+			env := obs.GetEnvironment()
+			if env == nil {
+				t.Fatalf("nil environment at step %d", obs.Step())
+			}
+			out = append(out, env.String())
+		}
+		return out
+	}
+	a, b, c := run(42), run(42), run(7)
+	same := true
+	for i := range a {
+		if a[i] != b[i] {
+			t.Fatalf("step %d differs for the same seed:\n%s\n%s", i, a[i], b[i])
+		}
+		same = same && a[i] == c[i]
+	}
+	if same {
+		t.Error("different seeds produced identical runs")
+	}
+}
+
+func TestScenarioObserver_NoiseFreeAndQueueSize(t *testing.T) {
+	queue := 0
+	s := &Scenario{
+		Steps:        2,
+		MaxBatchSize: Signal{Value: 64},
+		MaxQueueSize: &queue,
+		Alpha:        Signal{Value: 16},
+		Beta:         Signal{Value: 0.04},
+		Gamma:        Signal{Value: 0.0002},
+		RPM:          Signal{Value: 30},
+		InputTokens:  TokenDistribution{Mean: Signal{Value: 1024}},
+		OutputTokens: TokenDistribution{Mean: Signal{Value: 256}},
+	}
+	obs, err := NewScenarioObserver(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first := obs.GetEnvironment().(*core.EnvironmentPrefillDecode)
+	second := obs.GetEnvironment().(*core.EnvironmentPrefillDecode)
+	if first.String() != second.String() {
+		t.Errorf("noise-free observations differ: %s vs %s", first.String(), second.String())
+	}
+	if first.Lambda != 30 || first.AvgInputTokens != 1024 || first.MaxQueueSize != 0 || first.AvgTTFT <= 0 {
+		t.Errorf("unexpected environment: %s (maxQueueSize %d)", first.String(), first.MaxQueueSize)
+	}
+	if got := obs.Parameters(); got != [3]float64{16, 0.04, 0.0002} {
+		t.Errorf("Parameters() = %v", got)
+	}
+}
+
+func TestNewSimulatedObserver_LegacySlices(t *testing.T) {
+	obs := NewSimulatedObserver(
+		[]float32{30, 60}, []float32{1024}, []float32{256},
+		[]float32{16}, []float32{0.04}, []float32{0.0002}, []float32{0}, []int{64})
+	if obs == nil {
+		t.Fatal("expected an observer")
+	}
+	_ = obs.GetEnvironment()
+	env := obs.GetEnvironment().(*core.EnvironmentPrefillDecode)
+	if env.Lambda != 60 || env.MaxQueueSize != 640 {
+		t.Errorf("expected second-step rpm 60 and default queue 10*maxBatch, got %s (maxQueueSize %d)",
+			env.String(), env.MaxQueueSize)
+	}
+	if NewSimulatedObserver(nil, nil, nil, nil, nil, nil, nil, nil) != nil {
+		t.Error("expected nil observer for empty inputs")
+	}
+}
@@ -0,0 +1,345 @@
+/*
+Scrape Observer reads vLLM /metrics endpoints directly, for clusters without Prometheus. Rates and
+means are computed from the deltas of successive scrapes, so the first scrape of a replica only
+sets its baseline.
+*/
+
+package observer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"sort"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"github.com/prometheus/common/model"
+
+	"github.com/llm-inferno/model-tuner/pkg/core"
+)
+
+// Environment variable naming a ScrapeObserverConfig JSON file read by NewScrapeObserver.
+const ScrapeObserverConfigEnvName = "SCRAPE_OBSERVER_CONFIG"
+
+// ScrapeTarget is one vLLM replica's metrics endpoint.
+type ScrapeTarget struct {
+	URL         string `json:"url"`         // e.g. http://10.0.0.12:8000/metrics
+	Accelerator string `json:"accelerator"` // accelerator of the replica ("" = config default)
+}
+
+// ScrapeMetrics names the vLLM metric families read by the ScrapeObserver. Counters are summed
+// over all series of a model (e.g. over finished_reason); histograms contribute their sum and
+// count.
+type ScrapeMetrics struct {
+	RequestSuccess   string `json:"requestSuccess"`   // counter: completed requests
+	PromptTokens     string `json:"promptTokens"`     // counter: prompt tokens
+	GenerationTokens string `json:"generationTokens"` // counter: generated tokens
+	TTFT             string `json:"ttft"`             // histogram: time to first token (sec)
+	ITL              string `json:"itl"`              // histogram: time per output token (sec)
+	QueueTime        string `json:"queueTime"`        // histogram: queueing time (sec), optional
+	NumRunning       string `json:"numRunning"`       // gauge: running requests, optional
+}
+
+// ScrapeObserverConfig lists the scrape targets and how their metrics map to environments.
+type ScrapeObserverConfig struct {
+	Targets      []ScrapeTarget `json:"targets"`
+	ModelLabel   string         `json:"modelLabel"`   // label carrying the model name
+	Accelerator  string         `json:"accelerator"`  // accelerator of targets that do not name one
+	MaxBatchSize int            `json:"maxBatchSize"` // maximum batch size of the replicas
+	MaxQueueSize int            `json:"maxQueueSize"` // maximum external queue depth (0 = no external queue)
+	Timeout      string         `json:"timeout"`      // per-scrape HTTP timeout, e.g. "5s"
+	Metrics      ScrapeMetrics  `json:"metrics"`
+}
+
+// DefaultScrapeObserverConfig returns the standard vLLM metric names with no targets.
+func DefaultScrapeObserverConfig() *ScrapeObserverConfig {
+	return &ScrapeObserverConfig{
+		ModelLabel:   "model_name",
+		Accelerator:  "unknown",
+		MaxBatchSize: 256,
+		MaxQueueSize: 0,
+		Timeout:      "5s",
+		Metrics: ScrapeMetrics{
+			RequestSuccess:   "vllm:request_success_total",
+			PromptTokens:     "vllm:prompt_tokens_total",
+			GenerationTokens: "vllm:generation_tokens_total",
+			TTFT:             "vllm:time_to_first_token_seconds",
+			ITL:              "vllm:time_per_output_token_seconds",
+			QueueTime:        "vllm:request_queue_time_seconds",
+			NumRunning:       "vllm:num_requests_running",
+		},
+	}
+}
+
+// LoadScrapeObserverConfig reads a JSON config file over DefaultScrapeObserverConfig: fields set
+// in the file, including individual metric names, override the defaults.
+func LoadScrapeObserverConfig(path string) (*ScrapeObserverConfig, error) {
+	byteValue, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scrape observer config: %w", err)
+	}
+	cfg := DefaultScrapeObserverConfig()
+	if err := json.Unmarshal(byteValue, cfg); err != nil {
+		return nil, fmt.Errorf("error unmarshalling json data in file %s: %v", path, err)
+	}
+	return cfg, cfg.Validate()
+}
+
+// Validate checks that the config has targets, a model label and the required metric names.
+func (c *ScrapeObserverConfig) Validate() error {
+	if len(c.Targets) == 0 {
+		return fmt.Errorf("at least one target is required")
+	}
+	for i, t := range c.Targets {
+		if t.URL == "" {
+			return fmt.Errorf("target %d: url is required", i)
+		}
+		if t.Accelerator == "" && c.Accelerator == "" {
+			return fmt.Errorf("target %s: no accelerator and no default accelerator", t.URL)
+		}
+	}
+	if c.ModelLabel == "" {
+		return fmt.Errorf("modelLabel is required")
+	}
+	m := c.Metrics
+	if m.RequestSuccess == "" || m.PromptTokens == "" || m.GenerationTokens == "" || m.TTFT == "" || m.ITL == "" {
+		return fmt.Errorf("metrics requestSuccess, promptTokens, generationTokens, ttft and itl are required")
+	}
+	if _, err := time.ParseDuration(c.Timeout); err != nil {
+		return fmt.Errorf("invalid timeout %q: %w", c.Timeout, err)
+	}
+	return nil
+}
+
+// ReplicaEnvironment is the environment observed for one model served by one replica.
+type ReplicaEnvironment struct {
+	Replica     string // target URL
+	Model       string
+	Accelerator string
+	Env         *core.EnvironmentPrefillDecode
+}
+
+// ScrapeObserver scrapes the configured vLLM replicas and reports one environment per replica
+// and model from the change in their metrics since the previous scrape.
+type ScrapeObserver struct {
+	BaseObserver
+	config *ScrapeObserverConfig
+	client *http.Client
+	last   map[replicaKey]*scrapeSnapshot
+	now    func() time.Time
+}
+
+type replicaKey struct {
+	url   string
+	model string
+}
+
+// scrapeSnapshot holds the cumulative counters (and running gauge) of one replica and model at
+// one scrape.
+type scrapeSnapshot struct {
+	at                          time.Time
+	success, prompt, generation float64
+	ttftSum, ttftCount          float64
+	itlSum, itlCount            float64
+	queueSum, queueCount        float64
+	running                     float64
+}
+
+// NewScrapeObserver creates a ScrapeObserver from the SCRAPE_OBSERVER_CONFIG file.
+func NewScrapeObserver() (*ScrapeObserver, error) {
+	path := os.Getenv(ScrapeObserverConfigEnvName)
+	if path == "" {
+		return nil, fmt.Errorf("%s is not set", ScrapeObserverConfigEnvName)
+	}
+	config, err := LoadScrapeObserverConfig(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewScrapeObserverWithConfig(config)
+}
+
+// NewScrapeObserverWithConfig creates a ScrapeObserver for config.
+func NewScrapeObserverWithConfig(config *ScrapeObserverConfig) (*ScrapeObserver, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	timeout, _ := time.ParseDuration(config.Timeout)
+	return &ScrapeObserver{
+		BaseObserver: BaseObserver{},
+		config:       config,
+		client:       &http.Client{Timeout: timeout},
+		last:         make(map[replicaKey]*scrapeSnapshot),
+		now:          time.Now,
+	}, nil
+}
+
+// GetEnvironment returns the environment of the first replica (in replica, model order), or nil
+// if none could be observed. Use GetReplicaEnvironments to observe every replica.
+func (obs *ScrapeObserver) GetEnvironment() core.Environment {
+	replicas, err := obs.GetReplicaEnvironments()
+	if err != nil {
+		log.Printf("Error scraping environments: %v", err)
+		return nil
+	}
+	if len(replicas) == 0 {
+		log.Printf("No replicas with traffic since the previous scrape")
+		return nil
+	}
+	return replicas[0].Env
+}
+
+// GetReplicaEnvironments scrapes every target and returns one EnvironmentPrefillDecode per
+// (replica, model) that completed requests since the previous scrape, sorted by replica then
+// model. Replicas seen for the first time, or whose counters went backwards (a restart), only
+// record a new baseline. A target that cannot be scraped is logged and skipped; an error is
+// returned only if every target fails.
+func (obs *ScrapeObserver) GetReplicaEnvironments() ([]ReplicaEnvironment, error) {
+	var out []ReplicaEnvironment
+	failed := 0
+	var lastErr error
+	for _, target := range obs.config.Targets {
+		snapshots, err := obs.scrape(target.URL)
+		if err != nil {
+			log.Printf("Error scraping %s: %v", target.URL, err)
+			failed++
+			lastErr = err
+			continue
+		}
+		accelerator := target.Accelerator
+		if accelerator == "" {
+			accelerator = obs.config.Accelerator
+		}
+		for modelName, curr := range snapshots {
+			key := replicaKey{url: target.URL, model: modelName}
+			prev := obs.last[key]
+			obs.last[key] = curr
+			if prev == nil {
+				continue
+			}
+			env, reset := obs.newEnvironment(prev, curr)
+			if reset {
+				log.Printf("Counters of %s (%s) went backwards, resetting baseline", target.URL, modelName)
+				continue
+			}
+			if env == nil {
+				continue
+			}
+			out = append(out, ReplicaEnvironment{Replica: target.URL, Model: modelName, Accelerator: accelerator, Env: env})
+		}
+	}
+	if failed > 0 && failed == len(obs.config.Targets) {
+		return nil, fmt.Errorf("all %d targets failed, last error: %w", failed, lastErr)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Replica != out[j].Replica {
+			return out[i].Replica < out[j].Replica
+		}
+		return out[i].Model < out[j].Model
+	})
+	return out, nil
+}
+
+// scrape fetches and parses one target, returning a snapshot per model label value.
+func (obs *ScrapeObserver) scrape(url string) (map[string]*scrapeSnapshot, error) {
+	resp, err := obs.client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	parser := expfmt.NewTextParser(model.UTF8Validation)
+	families, err := parser.TextToMetricFamilies(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("parsing metrics: %w", err)
+	}
+
+	at := obs.now()
+	out := make(map[string]*scrapeSnapshot)
+	snapshot := func(m *dto.Metric) *scrapeSnapshot {
+		name := ""
+		for _, lp := range m.GetLabel() {
+			if lp.GetName() == obs.config.ModelLabel {
+				name = lp.GetValue()
+			}
+		}
+		s, ok := out[name]
+		if !ok {
+			s = &scrapeSnapshot{at: at}
+			out[name] = s
+		}
+		return s
+	}
+	counter := func(family string, field func(*scrapeSnapshot) *float64) {
+		for _, m := range families[family].GetMetric() {
+			*field(snapshot(m)) += m.GetCounter().GetValue() + m.GetUntyped().GetValue()
+		}
+	}
+	histogram := func(family string, sum, count func(*scrapeSnapshot) *float64) {
+		for _, m := range families[family].GetMetric() {
+			s := snapshot(m)
+			*sum(s) += m.GetHistogram().GetSampleSum()
+			*count(s) += float64(m.GetHistogram().GetSampleCount())
+		}
+	}
+
+	metrics := obs.config.Metrics
+	counter(metrics.RequestSuccess, func(s *scrapeSnapshot) *float64 { return &s.success })
+	counter(metrics.PromptTokens, func(s *scrapeSnapshot) *float64 { return &s.prompt })
+	counter(metrics.GenerationTokens, func(s *scrapeSnapshot) *float64 { return &s.generation })
+	histogram(metrics.TTFT, func(s *scrapeSnapshot) *float64 { return &s.ttftSum }, func(s *scrapeSnapshot) *float64 { return &s.ttftCount })
+	histogram(metrics.ITL, func(s *scrapeSnapshot) *float64 { return &s.itlSum }, func(s *scrapeSnapshot) *float64 { return &s.itlCount })
+	if metrics.QueueTime != "" {
+		histogram(metrics.QueueTime, func(s *scrapeSnapshot) *float64 { return &s.queueSum }, func(s *scrapeSnapshot) *float64 { return &s.queueCount })
+	}
+	if metrics.NumRunning != "" {
+		for _, m := range families[metrics.NumRunning].GetMetric() {
+			snapshot(m).running += m.GetGauge().GetValue()
+		}
+	}
+	return out, nil
+}
+
+// newEnvironment builds the environment over the interval between two snapshots: arrival rate
+// from completed requests (per minute), token and latency means from counter and histogram
+// deltas (msec), and batch size as the mean of the two running-request readings. It returns nil
+// if no request completed or a latency histogram did not move, and reset=true if any counter
+// decreased.
+func (obs *ScrapeObserver) newEnvironment(prev, curr *scrapeSnapshot) (env *core.EnvironmentPrefillDecode, reset bool) {
+	minutes := curr.at.Sub(prev.at).Minutes()
+	d := func(c, p float64) float64 {
+		if c < p {
+			reset = true
+		}
+		return c - p
+	}
+	success := d(curr.success, prev.success)
+	prompt := d(curr.prompt, prev.prompt)
+	generation := d(curr.generation, prev.generation)
+	ttftSum, ttftCount := d(curr.ttftSum, prev.ttftSum), d(curr.ttftCount, prev.ttftCount)
+	itlSum, itlCount := d(curr.itlSum, prev.itlSum), d(curr.itlCount, prev.itlCount)
+	queueSum, queueCount := d(curr.queueSum, prev.queueSum), d(curr.queueCount, prev.queueCount)
+	if reset {
+		return nil, true
+	}
+	if minutes <= 0 || success <= 0 || ttftCount <= 0 || itlCount <= 0 {
+		return nil, false
+	}
+	var avgQueueTime float64
+	if queueCount > 0 {
+		avgQueueTime = queueSum / queueCount * 1000
+	}
+	env = core.NewEnvironmentPrefillDecode(float32(success/minutes), float32((prev.running+curr.running)/2),
+		float32(avgQueueTime), obs.config.MaxBatchSize,
+		float32(prompt/success), float32(generation/success),
+		float32(ttftSum/ttftCount*1000), float32(itlSum/itlCount*1000))
+	env.MaxQueueSize = obs.config.MaxQueueSize
+	return env, false
+}
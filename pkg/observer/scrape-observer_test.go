@@ -0,0 +1,182 @@
+package observer
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// vllmExposition renders a minimal vLLM /metrics page for model llama with the given cumulative
+// values.
+func vllmExposition(success, prompt, generation, ttftSum, ttftCount, itlSum, itlCount, queueSum, queueCount, running float64) string {
+	return fmt.Sprintf(`# HELP vllm:request_success_total Count of successfully processed requests.
+# TYPE vllm:request_success_total counter
+vllm:request_success_total{finished_reason="stop",model_name="llama"} %g
+vllm:request_success_total{finished_reason="length",model_name="llama"} %g
+# TYPE vllm:prompt_tokens_total counter
+vllm:prompt_tokens_total{model_name="llama"} %g
+# TYPE vllm:generation_tokens_total counter
+vllm:generation_tokens_total{model_name="llama"} %g
+# TYPE vllm:time_to_first_token_seconds histogram
+vllm:time_to_first_token_seconds_bucket{le="0.1",model_name="llama"} %g
+vllm:time_to_first_token_seconds_bucket{le="+Inf",model_name="llama"} %g
+vllm:time_to_first_token_seconds_sum{model_name="llama"} %g
+vllm:time_to_first_token_seconds_count{model_name="llama"} %g
+# TYPE vllm:time_per_output_token_seconds histogram
+vllm:time_per_output_token_seconds_bucket{le="+Inf",model_name="llama"} %g
+vllm:time_per_output_token_seconds_sum{model_name="llama"} %g
+vllm:time_per_output_token_seconds_count{model_name="llama"} %g
+# TYPE vllm:request_queue_time_seconds histogram
+vllm:request_queue_time_seconds_bucket{le="+Inf",model_name="llama"} %g
+vllm:request_queue_time_seconds_sum{model_name="llama"} %g
+vllm:request_queue_time_seconds_count{model_name="llama"} %g
+# TYPE vllm:num_requests_running gauge
+vllm:num_requests_running{model_name="llama"} %g
+`, success-10, 10.0, prompt, generation, ttftCount, ttftCount, ttftSum, ttftCount,
+		itlCount, itlSum, itlCount, queueCount, queueSum, queueCount, running)
+}
+
+// newFakeVLLM serves pages in turn, repeating the last one.
+func newFakeVLLM(t *testing.T, pages ...string) *httptest.Server {
+	t.Helper()
+	calls := 0
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := pages[min(calls, len(pages)-1)]
+		calls++
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		_, _ = w.Write([]byte(page))
+	}))
+}
+
+func TestScrapeObserver_GetReplicaEnvironments(t *testing.T) {
+	srv := newFakeVLLM(t,
+		vllmExposition(100, 51200, 12800, 4, 100, 10, 1000, 1, 100, 4),
+		// 120 requests in 2 minutes: 512 in / 128 out tokens each, TTFT 50ms, ITL 8ms, queue 10ms.
+		vllmExposition(220, 112640, 28160, 10, 220, 22.29, 2500, 2.2, 220, 8),
+	)
+	defer srv.Close()
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer down.Close()
+
+	config := DefaultScrapeObserverConfig()
+	config.Targets = []ScrapeTarget{{URL: srv.URL, Accelerator: "H100"}, {URL: down.URL}}
+	config.MaxBatchSize = 64
+	obs, err := NewScrapeObserverWithConfig(config)
+	if err != nil {
+		t.Fatalf("NewScrapeObserverWithConfig: %v", err)
+	}
+	now := time.Unix(1700000000, 0)
+	obs.now = func() time.Time { return now }
+
+	// The first scrape only records a baseline.
+	replicas, err := obs.GetReplicaEnvironments()
+	if err != nil {
+		t.Fatalf("first scrape: %v", err)
+	}
+	if len(replicas) != 0 {
+		t.Fatalf("expected no environments from the baseline scrape, got %+v", replicas)
+	}
+
+	now = now.Add(2 * time.Minute)
+	replicas, err = obs.GetReplicaEnvironments()
+	if err != nil {
+		t.Fatalf("second scrape: %v", err)
+	}
+	if len(replicas) != 1 {
+		t.Fatalf("expected 1 replica environment, got %d", len(replicas))
+	}
+	r := replicas[0]
+	if r.Replica != srv.URL || r.Model != "llama" || r.Accelerator != "H100" {
+		t.Errorf("unexpected replica identity: %+v", r)
+	}
+	e := r.Env
+	approx := func(name string, got float32, want float64) {
+		if math.Abs(float64(got)-want) > 1e-3*math.Max(1, want) {
+			t.Errorf("%s = %g, want %g", name, got, want)
+		}
+	}
+	approx("lambda", e.Lambda, 60)
+	approx("avgInputTokens", e.AvgInputTokens, 512)
+	approx("avgOutputTokens", e.AvgOutputTokens, 128)
+	approx("avgTTFT", e.AvgTTFT, 50)
+	approx("avgITL", e.AvgITL, 8.193333)
+	approx("avgQueueTime", e.AvgQueueTime, 10)
+	approx("batchSize", e.BatchSize, 6)
+	if e.MaxBatchSize != 64 {
+		t.Errorf("maxBatchSize = %d, want 64", e.MaxBatchSize)
+	}
+}
+
+func TestScrapeObserver_CounterResetRebaselines(t *testing.T) {
+	srv := newFakeVLLM(t,
+		vllmExposition(500, 256000, 64000, 25, 500, 40, 5000, 5, 500, 4),
+		vllmExposition(20, 10240, 2560, 1, 20, 2, 200, 0.2, 20, 2), // replica restarted
+		vllmExposition(80, 40960, 10240, 4, 80, 8, 800, 0.8, 80, 2),
+	)
+	defer srv.Close()
+	config := DefaultScrapeObserverConfig()
+	config.Targets = []ScrapeTarget{{URL: srv.URL}}
+	obs, err := NewScrapeObserverWithConfig(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Unix(1700000000, 0)
+	obs.now = func() time.Time { return now }
+
+	for i, want := range []int{0, 0, 1} {
+		replicas, err := obs.GetReplicaEnvironments()
+		if err != nil {
+			t.Fatalf("scrape %d: %v", i, err)
+		}
+		if len(replicas) != want {
+			t.Fatalf("scrape %d: expected %d environments, got %d", i, want, len(replicas))
+		}
+		now = now.Add(time.Minute)
+	}
+}
+
+func TestScrapeObserver_AllTargetsFail(t *testing.T) {
+	config := DefaultScrapeObserverConfig()
+	config.Targets = []ScrapeTarget{{URL: "http://127.0.0.1:1/metrics"}}
+	config.Timeout = "100ms"
+	obs, err := NewScrapeObserverWithConfig(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := obs.GetReplicaEnvironments(); err == nil {
+		t.Error("expected an error when every target fails")
+	}
+	if obs.GetEnvironment() != nil {
+		t.Error("expected nil environment when every target fails")
+	}
+}
+
+func TestLoadScrapeObserverConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scrape.json")
+	body := `{"targets": [{"url": "http://replica-0:8000/metrics"}], "accelerator": "A100",
+		"metrics": {"itl": "vllm:inter_token_latency_seconds"}}`
+	if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := LoadScrapeObserverConfig(path)
+	if err != nil {
+		t.Fatalf("LoadScrapeObserverConfig: %v", err)
+	}
+	if cfg.Metrics.ITL != "vllm:inter_token_latency_seconds" || cfg.Metrics.TTFT != "vllm:time_to_first_token_seconds" {
+		t.Errorf("metric override not merged with defaults: %+v", cfg.Metrics)
+	}
+	if cfg.Accelerator != "A100" || cfg.ModelLabel != "model_name" {
+		t.Errorf("unexpected config: %+v", cfg)
+	}
+
+	if _, err := NewScrapeObserverWithConfig(DefaultScrapeObserverConfig()); err == nil {
+		t.Error("expected error for a config without targets")
+	}
+}
@@ -6,6 +6,7 @@ package observer
 
 import (
 	"fmt"
+	"math"
 	"math/rand/v2"
 
 	"github.com/llm-inferno/model-tuner/pkg/core"
@@ -15,16 +16,16 @@ import (
 type SimulatedObserver struct {
 	BaseObserver
 
-	rpm                       []float32
-	inputTokens, outputTokens []float32
-	alpha, beta, gamma        []float32
-	percentNoise              []float32
-	maxBatchSize              []int
+	scenario *Scenario
+	rng      *rand.Rand
+	burst    int // remaining steps of the current outlier burst
 
 	timeStep int
 	maxStep  int
 }
 
+// NewSimulatedObserver creates an observer from per-step parameter slices (a slice shorter than
+// the longest repeats its last value) with uniform multiplicative noise, randomly seeded.
 func NewSimulatedObserver(rpm,
 	inputTokens, outputTokens,
 	alpha, beta, gamma, percentNoise []float32,
@@ -33,52 +34,98 @@ func NewSimulatedObserver(rpm,
 	maxStep := max(len(rpm), len(inputTokens), len(outputTokens),
 		len(alpha), len(beta), len(gamma),
 		len(percentNoise), len(maxBatchSize)) - 1
-	if maxStep < 0 {
+	if maxStep < 0 || min(len(rpm), len(inputTokens), len(outputTokens),
+		len(alpha), len(beta), len(gamma),
+		len(percentNoise), len(maxBatchSize)) == 0 {
+		return nil
+	}
+	trace := func(v []float32) Signal {
+		values := make([]float64, len(v))
+		for i := range v {
+			values[i] = float64(v[i])
+		}
+		return Signal{Values: values}
+	}
+	batch := make([]float32, len(maxBatchSize))
+	for i, b := range maxBatchSize {
+		batch[i] = float32(b)
+	}
+	scenario := &Scenario{
+		Seed:         rand.Uint64(),
+		Steps:        maxStep + 1,
+		MaxBatchSize: trace(batch),
+		Alpha:        trace(alpha),
+		Beta:         trace(beta),
+		Gamma:        trace(gamma),
+		RPM:          trace(rpm),
+		InputTokens:  TokenDistribution{Mean: trace(inputTokens)},
+		OutputTokens: TokenDistribution{Mean: trace(outputTokens)},
+		Noise:        NoiseModel{Type: NoiseUniform, Level: trace(percentNoise)},
+	}
+	obs, err := NewScenarioObserver(scenario)
+	if err != nil {
 		return nil
 	}
+	return obs
+}
+
+// NewScenarioObserver creates an observer that plays scenario from step 0 with an RNG seeded by
+// scenario.Seed, so the same scenario yields the same observations. After the last step the
+// final step's parameters repeat.
+func NewScenarioObserver(scenario *Scenario) (*SimulatedObserver, error) {
+	if err := scenario.Validate(); err != nil {
+		return nil, err
+	}
 	return &SimulatedObserver{
 		BaseObserver: BaseObserver{},
 
-		rpm:          rpm,
-		inputTokens:  inputTokens,
-		outputTokens: outputTokens,
-		alpha:        alpha,
-		beta:         beta,
-		gamma:        gamma,
-		maxBatchSize: maxBatchSize,
-		percentNoise: percentNoise,
-		timeStep:     0,
-		maxStep:      maxStep,
-	}
+		scenario: scenario,
+		rng:      rand.New(rand.NewPCG(scenario.Seed, 0)),
+		timeStep: 0,
+		maxStep:  scenario.Steps - 1,
+	}, nil
+}
+
+// Step returns the index of the next observation.
+func (obs *SimulatedObserver) Step() int {
+	return obs.timeStep
+}
+
+// Parameters returns the true [alpha, beta, gamma] of the last observation returned.
+func (obs *SimulatedObserver) Parameters() [3]float64 {
+	return obs.scenario.ParametersAt(min(max(obs.timeStep-1, 0), obs.maxStep))
 }
 
 func (obs *SimulatedObserver) GetEnvironment() core.Environment {
+	s := obs.scenario
 
 	// get parameters at current time step
 	i := min(obs.timeStep, obs.maxStep)
-	rpm := obs.rpm[i]
-	inputTokens := obs.inputTokens[i]
-	outputTokens := obs.outputTokens[i]
-	alpha := obs.alpha[i]
-	beta := obs.beta[i]
-	gamma := obs.gamma[i]
-	pctNoise := obs.percentNoise[i]
-	maxBatchSize := obs.maxBatchSize[i]
+	maxBatchSize := int(math.Round(s.MaxBatchSize.At(i)))
+	maxQueueSize := 10 * maxBatchSize
+	if s.MaxQueueSize != nil {
+		maxQueueSize = *s.MaxQueueSize
+	}
+
+	// noisy arrival rate, and token averages observed over it
+	rpm := s.RPM.At(i) * s.Noise.factor(obs.rng, i)
+	inputTokens := s.InputTokens.sample(obs.rng, i, rpm)
+	outputTokens := s.OutputTokens.sample(obs.rng, i, rpm)
 
 	// create queueing model
 	qConfig := &analyzer.Configuration{
 		MaxBatchSize: maxBatchSize,
-		MaxQueueSize: 10 * maxBatchSize,
+		MaxQueueSize: maxQueueSize,
 		ServiceParms: &analyzer.ServiceParms{
-			Alpha: alpha,
-			Beta:  beta,
-			Gamma: gamma,
+			Alpha: float32(s.Alpha.At(i)),
+			Beta:  float32(s.Beta.At(i)),
+			Gamma: float32(s.Gamma.At(i)),
 		},
 	}
 
 	requestSize := &analyzer.RequestSize{
-		AvgInputTokens:  inputTokens,
-		AvgOutputTokens: outputTokens,
+		AvgInputTokens:  float32(inputTokens),
+		AvgOutputTokens: float32(outputTokens),
 	}
 	queueAnalyzer, err := analyzer.NewLLMQueueAnalyzer(qConfig, requestSize)
 	if err != nil {
@@ -86,42 +133,34 @@ func (obs *SimulatedObserver) GetEnvironment() core.Environment {
 		return nil
 	}
 
-	// request per msec
-
-	if rpm *= 1 + pctNoise*(2*rand.Float32()-1); rpm < 0 {
-		rpm = 0
-	}
-
-	metrics, err := queueAnalyzer.Analyze(rpm / 60)
+	metrics, err := queueAnalyzer.Analyze(float32(rpm / 60))
 	if err != nil {
 		fmt.Println("failed to analyze queueing model: " + err.Error())
 		return nil
 	}
 
-	// fmt.Println("sim-queueAnalyzer: " + queueAnalyzer.String())
-	// fmt.Println("sim-metrics: " + metrics.String())
-
-	avgWaitTime := metrics.AvgWaitTime
-	avgTTFT := metrics.AvgTTFT
-	avgITL := metrics.AvgTokenTime
+	// add noise, and outlier bursts on the latencies
+	outlier := 1.0
+	if o := s.Noise.Outliers; o != nil {
+		if obs.burst == 0 && obs.rng.Float64() < o.Probability {
+			obs.burst = max(o.Length, 1)
+		}
+		if obs.burst > 0 {
+			obs.burst--
+			outlier = o.Factor
+		}
+	}
+	avgWaitTime := float64(metrics.AvgWaitTime) * s.Noise.factor(obs.rng, i) * outlier
+	avgTTFT := float64(metrics.AvgTTFT) * s.Noise.factor(obs.rng, i) * outlier
+	avgITL := float64(metrics.AvgTokenTime) * s.Noise.factor(obs.rng, i) * outlier
 
 	avgConcurrency := metrics.AvgNumInServ
 
-	// add noise
-	if avgWaitTime *= 1 + pctNoise*(2*rand.Float32()-1); avgWaitTime < 0 {
-		avgWaitTime = 0
-	}
-	if avgTTFT *= 1 + pctNoise*(2*rand.Float32()-1); avgTTFT < 0 {
-		avgTTFT = 0
-	}
-	if avgITL *= 1 + pctNoise*(2*rand.Float32()-1); avgITL < 0 {
-		avgITL = 0
-	}
-
 	obs.timeStep++
 
 	// create environment with input parameters
-	env := core.NewEnvironmentPrefillDecode(rpm, avgConcurrency, avgWaitTime, maxBatchSize,
-		inputTokens, outputTokens, avgTTFT, avgITL)
+	env := core.NewEnvironmentPrefillDecode(float32(rpm), avgConcurrency, float32(avgWaitTime), maxBatchSize,
+		float32(inputTokens), float32(outputTokens), float32(avgTTFT), float32(avgITL))
+	env.MaxQueueSize = maxQueueSize
 	return env
 }
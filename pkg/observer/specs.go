@@ -0,0 +1,61 @@
+package observer
+
+import (
+	"math"
+
+	optconfig "github.com/llm-inferno/optimizer-light/pkg/config"
+
+	"github.com/llm-inferno/model-tuner/pkg/core"
+)
+
+// ServerSpec converts an observed environment into the replica ServerSpec shape consumed by the
+// tuner service (one element of a /tune request). Token averages are rounded to integers.
+func ServerSpec(name, model, accelerator string, env *core.EnvironmentPrefillDecode) optconfig.ServerSpec {
+	return optconfig.ServerSpec{
+		Name:         name,
+		Model:        model,
+		MaxBatchSize: env.MaxBatchSize,
+		MaxQueueSize: env.MaxQueueSize,
+		CurrentAlloc: optconfig.AllocationData{
+			Accelerator: accelerator,
+			NumReplicas: 1,
+			MaxBatch:    env.MaxBatchSize,
+			TTFTAverage: env.AvgTTFT,
+			ITLAverage:  env.AvgITL,
+			Load: optconfig.ServerLoadSpec{
+				ArrivalRate:  env.Lambda,
+				Throughput:   env.Lambda,
+				AvgInTokens:  int(math.Round(float64(env.AvgInputTokens))),
+				AvgOutTokens: int(math.Round(float64(env.AvgOutputTokens))),
+			},
+		},
+	}
+}
+
+// ServerSpecs returns one ServerSpec per observed (model, accelerator) pair, named after the pair.
+// Each describes an average replica of the pair: its arrival rate is the pair's rate divided by
+// the number of replicas.
+func (obs *OnlineObserver) ServerSpecs() ([]optconfig.ServerSpec, error) {
+	pairs, err := obs.GetPairEnvironments()
+	if err != nil {
+		return nil, err
+	}
+	specs := make([]optconfig.ServerSpec, 0, len(pairs))
+	for _, p := range pairs {
+		specs = append(specs, ServerSpec(p.Model+"/"+p.Accelerator, p.Model, p.Accelerator, p.Env))
+	}
+	return specs, nil
+}
+
+// ServerSpecs returns one ServerSpec per observed (replica, model), named after the replica URL.
+func (obs *ScrapeObserver) ServerSpecs() ([]optconfig.ServerSpec, error) {
+	replicas, err := obs.GetReplicaEnvironments()
+	if err != nil {
+		return nil, err
+	}
+	specs := make([]optconfig.ServerSpec, 0, len(replicas))
+	for _, r := range replicas {
+		specs = append(specs, ServerSpec(r.Replica, r.Model, r.Accelerator, r.Env))
+	}
+	return specs, nil
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	optconfig "github.com/llm-inferno/optimizer-light/pkg/config"
+)
+
+// MetricsSource supplies the replica observations of one tune cycle, in the shape of a /tune
+// request (e.g. observer.OnlineObserver or observer.ScrapeObserver).
+type MetricsSource interface {
+	ServerSpecs() ([]optconfig.ServerSpec, error)
+}
+
+// Sink receives the ModelData produced by each successful active tune cycle.
+type Sink interface {
+	Publish(ctx context.Context, modelData *optconfig.ModelData) error
+}
+
+// ActiveTuner drives the service without a controller: every interval it polls a MetricsSource,
+// runs the observations through Tune, and publishes the result to an optional Sink.
+type ActiveTuner struct {
+	service  *TunerService
+	source   MetricsSource
+	interval time.Duration
+	sink     Sink
+	onTune   func(specs []optconfig.ServerSpec)
+}
+
+// NewActiveTuner creates an ActiveTuner polling source every interval.
+func NewActiveTuner(service *TunerService, source MetricsSource, interval time.Duration) (*ActiveTuner, error) {
+	if source == nil {
+		return nil, fmt.Errorf("metrics source is required")
+	}
+	if interval <= 0 {
+		return nil, fmt.Errorf("interval must be positive, got %s", interval)
+	}
+	return &ActiveTuner{service: service, source: source, interval: interval}, nil
+}
+
+// SetSink publishes each cycle's ModelData to sink. nil disables publishing. Set it before Run.
+func (a *ActiveTuner) SetSink(sink Sink) {
+	a.sink = sink
+}
+
+// OnTune registers a hook called with the polled specs before each Tune, e.g. to record them.
+// Set it before Run.
+func (a *ActiveTuner) OnTune(hook func(specs []optconfig.ServerSpec)) {
+	a.onTune = hook
+}
+
+// RunOnce runs one cycle: poll, tune and publish. A cycle without observations is not an error
+// and returns nil ModelData.
+func (a *ActiveTuner) RunOnce(ctx context.Context) (*optconfig.ModelData, error) {
+	specs, err := a.source.ServerSpecs()
+	if err != nil {
+		return nil, fmt.Errorf("polling metrics: %w", err)
+	}
+	if len(specs) == 0 {
+		return nil, nil
+	}
+	if a.onTune != nil {
+		a.onTune(specs)
+	}
+	modelData, err := a.service.Tune(specs)
+	if err != nil {
+		return nil, err
+	}
+	if a.sink != nil {
+		if err := a.sink.Publish(ctx, modelData); err != nil {
+			return modelData, fmt.Errorf("publishing results: %w", err)
+		}
+	}
+	return modelData, nil
+}
+
+// Run runs a cycle immediately and then every interval until ctx is cancelled. Cycle errors are
+// logged and do not stop the loop.
+func (a *ActiveTuner) Run(ctx context.Context) {
+	ticker := time.NewTicker(a.interval)
+	defer ticker.Stop()
+	for ctx.Err() == nil {
+		modelData, err := a.RunOnce(ctx)
+		switch {
+		case err != nil:
+			slog.Warn("active tune cycle failed", "err", err)
+		case modelData == nil:
+			slog.Info("active tune cycle: no observations")
+		default:
+			slog.Info("active tune cycle complete", "pairs", len(modelData.PerfData))
+		}
+		select {
+		case <-ctx.Done():
+		case <-ticker.C:
+		}
+	}
+}
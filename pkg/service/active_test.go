@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	optconfig "github.com/llm-inferno/optimizer-light/pkg/config"
+)
+
+// fakeSource returns specs on every poll, or err if set.
+type fakeSource struct {
+	specs []optconfig.ServerSpec
+	err   error
+	polls int
+}
+
+func (s *fakeSource) ServerSpecs() ([]optconfig.ServerSpec, error) {
+	s.polls++
+	return s.specs, s.err
+}
+
+// fakeSink collects the published ModelData.
+type fakeSink struct {
+	published []*optconfig.ModelData
+	err       error
+}
+
+func (s *fakeSink) Publish(_ context.Context, modelData *optconfig.ModelData) error {
+	s.published = append(s.published, modelData)
+	return s.err
+}
+
+func TestNewActiveTuner_Validates(t *testing.T) {
+	t.Setenv("CONFIG_DATA_DIR", "../../config-data")
+	ts := NewTunerService(0, 1, false, false, DefaultWindowSize, DefaultResidualThreshold, 0)
+	if _, err := NewActiveTuner(ts, nil, time.Second); err == nil {
+		t.Error("expected error for a nil source")
+	}
+	if _, err := NewActiveTuner(ts, &fakeSource{}, 0); err == nil {
+		t.Error("expected error for a zero interval")
+	}
+}
+
+func TestActiveTuner_RunOncePublishes(t *testing.T) {
+	t.Setenv("CONFIG_DATA_DIR", "../../config-data")
+	ts := NewTunerService(0, 1, false, false, DefaultWindowSize, DefaultResidualThreshold, 0)
+	source := &fakeSource{specs: []optconfig.ServerSpec{makeTestSpec("llama", "H100", 30, 50, 8, 512, 128, 64)}}
+	a, err := NewActiveTuner(ts, source, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sink := &fakeSink{}
+	a.SetSink(sink)
+	var tuned int
+	a.OnTune(func(specs []optconfig.ServerSpec) { tuned += len(specs) })
+
+	modelData, err := a.RunOnce(context.Background())
+	if err != nil {
+		t.Fatalf("RunOnce: %v", err)
+	}
+	if tuned != 1 || len(sink.published) != 1 || sink.published[0] != modelData {
+		t.Errorf("tuned %d specs and published %d results, want 1 and 1", tuned, len(sink.published))
+	}
+
+	sink.err = errors.New("unavailable")
+	if _, err := a.RunOnce(context.Background()); err == nil {
+		t.Error("expected the sink error to be returned")
+	}
+
+	source.specs = nil
+	if md, err := a.RunOnce(context.Background()); md != nil || err != nil {
+		t.Errorf("empty poll = (%v, %v), want (nil, nil)", md, err)
+	}
+	source.err = errors.New("prometheus down")
+	if _, err := a.RunOnce(context.Background()); err == nil {
+		t.Error("expected the poll error to be returned")
+	}
+}
+
+func TestActiveTuner_RunStopsOnCancel(t *testing.T) {
+	t.Setenv("CONFIG_DATA_DIR", "../../config-data")
+	ts := NewTunerService(0, 1, false, false, DefaultWindowSize, DefaultResidualThreshold, 0)
+	source := &fakeSource{}
+	a, err := NewActiveTuner(ts, source, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() { a.Run(ctx); close(done) }()
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run did not return after cancel")
+	}
+	if source.polls > 1 {
+		t.Errorf("polls = %d, want at most the immediate one", source.polls)
+	}
+}
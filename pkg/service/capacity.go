@@ -0,0 +1,245 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
+
+	"github.com/llm-inferno/queue-analysis/pkg/analyzer"
+
+	"github.com/llm-inferno/model-tuner/pkg/core"
+)
+
+// Constraints that can bind a Capacity.
+const (
+	BindingTTFT      = "ttft"      // the TTFT target
+	BindingITL       = "itl"       // the ITL target
+	BindingStability = "stability" // the max stable throughput, less the analyzer's safety fraction
+)
+
+// CapacitySamples is the number of parameter draws behind a confidence level.
+const CapacitySamples = 100
+
+// capacitySearchSteps is the number of bisection steps per constraint; 30 halvings of the
+// stable range resolve the rate far below a request per minute.
+const capacitySearchSteps = 30
+
+// sensitivityStep is the relative parameter perturbation of the capacity elasticities.
+const sensitivityStep = 0.01
+
+// CapacityQuery is a replica configuration and the SLO it must meet.
+type CapacityQuery struct {
+	AvgInTokens  int     `json:"avgInTokens"`
+	AvgOutTokens int     `json:"avgOutTokens"`
+	MaxBatchSize int     `json:"maxBatchSize"`
+	MaxQueueSize int     `json:"maxQueueSize"` // external queue depth (0 = no external queue)
+	TargetTTFT   float32 `json:"targetTTFT"`   // msec; 0 leaves TTFT unconstrained
+	TargetITL    float32 `json:"targetITL"`    // msec; 0 leaves ITL unconstrained
+	// Confidence 0 holds the model's mean TTFT and ITL to the targets. A confidence p in (0, 1)
+	// holds the p-quantile of the mean over the parameter uncertainty to them instead, so the
+	// targets are met with confidence p given how well the parameters are known. The targets
+	// are mean latencies either way: the queueing model yields no per-request percentiles.
+	Confidence float64 `json:"confidence,omitempty"`
+}
+
+// Validate reports the first field out of range.
+func (q CapacityQuery) Validate() error {
+	point := OperatingPoint{ArrivalRate: 1, AvgInTokens: q.AvgInTokens, AvgOutTokens: q.AvgOutTokens,
+		MaxBatchSize: q.MaxBatchSize, MaxQueueSize: q.MaxQueueSize}
+	if err := point.Validate(); err != nil {
+		return err
+	}
+	switch {
+	case q.TargetTTFT < 0 || q.TargetITL < 0:
+		return fmt.Errorf("targets must not be negative")
+	case q.TargetTTFT == 0 && q.TargetITL == 0:
+		return fmt.Errorf("at least one of targetTTFT and targetITL is required")
+	case q.Confidence < 0 || q.Confidence >= 1 || math.IsNaN(q.Confidence):
+		return fmt.Errorf("confidence must be in (0, 1), or 0 for the nominal parameters")
+	}
+	return nil
+}
+
+// Capacity is the highest arrival rate one replica sustains within an SLO.
+type Capacity struct {
+	MaxRate       float32 `json:"maxRate"`       // requests/min: the minimum of the three rates below
+	Binding       string  `json:"binding"`       // the constraint setting MaxRate: a Binding* constant
+	RateTTFT      float32 `json:"rateTTFT"`      // requests/min within the TTFT target (RateStability if unconstrained)
+	RateITL       float32 `json:"rateITL"`       // requests/min within the ITL target (RateStability if unconstrained)
+	RateStability float32 `json:"rateStability"` // requests/min the replica serves stably
+	// TTFT, ITL and Concurrency are the means at MaxRate with the stored parameters; below the
+	// targets with a confidence level, which holds their quantile to the targets instead.
+	TTFT        float32 `json:"ttft"`        // msec
+	ITL         float32 `json:"itl"`         // msec
+	Concurrency float32 `json:"concurrency"` // requests in service
+	// Sensitivity is the elasticity of MaxRate to each parameter: the relative change of the
+	// capacity per relative change of alpha, beta or gamma (e.g. a beta elasticity of -0.8 means
+	// a 1% larger beta costs 0.8% of the capacity).
+	Sensitivity ParameterSensitivity `json:"sensitivity"`
+	Source      string               `json:"source,omitempty"` // covariance behind a confidence level
+}
+
+// ParameterSensitivity holds one value per parameter.
+type ParameterSensitivity struct {
+	Alpha float64 `json:"alpha"`
+	Beta  float64 `json:"beta"`
+	Gamma float64 `json:"gamma"`
+}
+
+// Capacity searches for the highest arrival rate at which a replica of (model, accelerator),
+// configured as in q, meets q's targets with the stored parameters and the same queueing model
+// the tuner fits them with. TTFT and ITL grow with the arrival rate, so each target is bisected
+// separately over the stable range and the lowest rate binds. A confidence level needs the
+// parameters to carry a covariance. It does not change tuner state.
+func (ts *TunerService) Capacity(model, accelerator string, q CapacityQuery) (*Capacity, error) {
+	if err := q.Validate(); err != nil {
+		return nil, err
+	}
+	params := ts.published.Get(model, accelerator)
+	if params == nil {
+		return nil, fmt.Errorf("%w for model=%s accelerator=%s", ErrNoParams, model, accelerator)
+	}
+	x := []float64{float64(params.Alpha), float64(params.Beta), float64(params.Gamma)}
+	draws := [][]float64{x}
+	var source string
+	if q.Confidence > 0 {
+		var cov [][]float64
+		if cov, source = params.predictionCovariance(); cov == nil {
+			return nil, fmt.Errorf("parameters of %s/%s carry no covariance for a confidence level", model, accelerator)
+		}
+		if draws = sampleParameters(x, cov, CapacitySamples, rand.New(rand.NewPCG(1, 2))); draws == nil {
+			return nil, fmt.Errorf("sampling the parameters of %s/%s failed", model, accelerator)
+		}
+	}
+
+	result, err := capacityAt(q, x, draws)
+	if err != nil {
+		return nil, err
+	}
+	result.Source = source
+
+	// central-difference elasticities, scaling the nominal params and every draw alike
+	if result.MaxRate > 0 {
+		elasticity := make([]float64, len(x))
+		for k := range x {
+			up, errUp := capacityAt(q, scaled(x, k, 1+sensitivityStep), scaledAll(draws, k, 1+sensitivityStep))
+			dn, errDn := capacityAt(q, scaled(x, k, 1-sensitivityStep), scaledAll(draws, k, 1-sensitivityStep))
+			if errUp != nil || errDn != nil {
+				continue
+			}
+			elasticity[k] = float64(up.MaxRate-dn.MaxRate) / (2 * sensitivityStep * float64(result.MaxRate))
+		}
+		result.Sensitivity = ParameterSensitivity{Alpha: elasticity[0], Beta: elasticity[1], Gamma: elasticity[2]}
+	}
+	return result, nil
+}
+
+// capacityAt computes the capacity of q for nominal params x, holding the mean over draws (a
+// single draw, x, without a confidence level) or its q.Confidence quantile to the targets.
+func capacityAt(q CapacityQuery, x []float64, draws [][]float64) (*Capacity, error) {
+	env := func(rate float32) *core.EnvironmentPrefillDecode {
+		e := core.NewEnvironmentPrefillDecode(rate, 0, 0, q.MaxBatchSize,
+			float32(q.AvgInTokens), float32(q.AvgOutTokens), 0, 0)
+		e.MaxQueueSize = q.MaxQueueSize
+		return e
+	}
+	// any rate yields the stable range of the nominal params
+	nominal, err := core.AnalyzePrefillDecode(env(1), float32(x[0]), float32(x[1]), float32(x[2]))
+	if err != nil {
+		return nil, fmt.Errorf("analyzing the replica: %w", err)
+	}
+	stable := nominal.MaxRate * 60 * (1 - analyzer.StabilitySafetyFraction)
+
+	// latencies returns the TTFT and ITL statistic over the draws at rate; false if too few draws
+	// could be evaluated.
+	latencies := func(rate float32) (ttft, itl float32, ok bool) {
+		ttfts := make([]float32, 0, len(draws))
+		itls := make([]float32, 0, len(draws))
+		for _, d := range draws {
+			m, err := core.AnalyzePrefillDecode(env(rate), float32(d[0]), float32(d[1]), float32(d[2]))
+			if err != nil {
+				continue
+			}
+			ttfts = append(ttfts, m.AvgTTFT)
+			itls = append(itls, m.AvgTokenTime)
+		}
+		if len(ttfts) == 0 || float64(len(ttfts)) < minUsableSamples*float64(len(draws)) {
+			return 0, 0, false
+		}
+		if q.Confidence == 0 {
+			return ttfts[0], itls[0], true
+		}
+		slices.Sort(ttfts)
+		slices.Sort(itls)
+		return quantile(ttfts, q.Confidence), quantile(itls, q.Confidence), true
+	}
+
+	rateTTFT, rateITL := stable, stable
+	if q.TargetTTFT > 0 {
+		rateTTFT = maxRateWithin(stable, func(rate float32) bool {
+			ttft, _, ok := latencies(rate)
+			return ok && ttft <= q.TargetTTFT
+		})
+	}
+	if q.TargetITL > 0 {
+		rateITL = maxRateWithin(stable, func(rate float32) bool {
+			_, itl, ok := latencies(rate)
+			return ok && itl <= q.TargetITL
+		})
+	}
+
+	c := &Capacity{RateTTFT: rateTTFT, RateITL: rateITL, RateStability: stable}
+	switch c.MaxRate = min(rateTTFT, rateITL); {
+	case c.MaxRate == stable:
+		c.Binding = BindingStability
+	case rateTTFT <= rateITL:
+		c.Binding = BindingTTFT
+	default:
+		c.Binding = BindingITL
+	}
+	if c.MaxRate > 0 {
+		if m, err := core.AnalyzePrefillDecode(env(c.MaxRate), float32(x[0]), float32(x[1]), float32(x[2])); err == nil {
+			c.TTFT, c.ITL, c.Concurrency = m.AvgTTFT, m.AvgTokenTime, m.AvgNumInServ
+		}
+	}
+	return c, nil
+}
+
+// maxRateWithin returns the largest rate in (0, hi] at which within holds, by bisection, for a
+// condition that holds up to some rate and fails beyond it. It returns hi if within holds there,
+// and 0 if it fails even at a vanishing load.
+func maxRateWithin(hi float32, within func(rate float32) bool) float32 {
+	if within(hi) {
+		return hi
+	}
+	lo := hi * 1e-6
+	if !within(lo) {
+		return 0
+	}
+	for range capacitySearchSteps {
+		mid := (lo + hi) / 2
+		if within(mid) {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return lo
+}
+
+// scaled returns a copy of x with component k multiplied by f.
+func scaled(x []float64, k int, f float64) []float64 {
+	out := slices.Clone(x)
+	out[k] *= f
+	return out
+}
+
+// scaledAll applies scaled to every draw.
+func scaledAll(draws [][]float64, k int, f float64) [][]float64 {
+	out := make([][]float64, len(draws))
+	for i, d := range draws {
+		out[i] = scaled(d, k, f)
+	}
+	return out
+}
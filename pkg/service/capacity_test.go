@@ -0,0 +1,85 @@
+package service
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCapacity(t *testing.T) {
+	ts := NewTunerService(0, 1, false, false, DefaultWindowSize, DefaultResidualThreshold, 0)
+	q := CapacityQuery{AvgInTokens: 2048, AvgOutTokens: 256, MaxBatchSize: 256, TargetTTFT: 500, TargetITL: 50}
+	if _, err := ts.Capacity("llama", "H100", q); !errors.Is(err, ErrNoParams) {
+		t.Fatalf("Capacity before tuning: %v, want ErrNoParams", err)
+	}
+	setParams(ts, "llama", "H100", &LearnedParameters{Alpha: 7.7, Beta: 0.067, Gamma: 5.5e-5, UpdateCount: 1})
+
+	got, err := ts.Capacity("llama", "H100", q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.MaxRate <= 0 || got.MaxRate >= got.RateStability || got.MaxRate != min(got.RateTTFT, got.RateITL) {
+		t.Fatalf("capacity = %+v, want an SLO-bound rate below the stable rate", got)
+	}
+	if got.Binding != BindingITL || got.ITL > q.TargetITL*1.001 || got.TTFT > q.TargetTTFT {
+		t.Errorf("capacity = %+v, want ITL binding at its target", got)
+	}
+	// a larger iteration overhead lowers the capacity
+	if got.Sensitivity.Alpha >= 0 || got.Sensitivity.Beta > 0 || got.Sensitivity.Gamma > 0 {
+		t.Errorf("sensitivity = %+v, want non-positive elasticities", got.Sensitivity)
+	}
+
+	// the capacity is consistent with Predict: just within the targets
+	predicted, err := ts.Predict("llama", "H100", []OperatingPoint{{ArrivalRate: got.MaxRate, AvgInTokens: 2048,
+		AvgOutTokens: 256, MaxBatchSize: 256}}, 0)
+	if err != nil || predicted[0].ITL > q.TargetITL*1.001 {
+		t.Errorf("predicted at capacity = (%+v, %v), want ITL within %g", predicted, err, q.TargetITL)
+	}
+
+	loose := q
+	loose.TargetTTFT, loose.TargetITL = 1e6, 1e6
+	if got, err := ts.Capacity("llama", "H100", loose); err != nil || got.Binding != BindingStability || got.MaxRate != got.RateStability {
+		t.Errorf("loose targets = (%+v, %v), want stability binding", got, err)
+	}
+	tight := q
+	tight.TargetTTFT = 1
+	if got, err := ts.Capacity("llama", "H100", tight); err != nil || got.MaxRate != 0 || got.Binding != BindingTTFT {
+		t.Errorf("unreachable TTFT = (%+v, %v), want zero capacity bound by TTFT", got, err)
+	}
+
+	for _, bad := range []CapacityQuery{
+		{AvgInTokens: 2048, AvgOutTokens: 256, MaxBatchSize: 256},
+		{AvgInTokens: 2048, AvgOutTokens: 256, TargetITL: 100},
+		{AvgInTokens: 2048, AvgOutTokens: 256, MaxBatchSize: 256, TargetITL: 100, Confidence: 1},
+	} {
+		if _, err := ts.Capacity("llama", "H100", bad); err == nil {
+			t.Errorf("expected error for %+v", bad)
+		}
+	}
+}
+
+func TestCapacity_Confidence(t *testing.T) {
+	ts := NewTunerService(0, 1, false, false, DefaultWindowSize, DefaultResidualThreshold, 0)
+	params := &LearnedParameters{Alpha: 7.7, Beta: 0.067, Gamma: 5.5e-5, UpdateCount: 1}
+	setParams(ts, "llama", "H100", params)
+	q := CapacityQuery{AvgInTokens: 2048, AvgOutTokens: 256, MaxBatchSize: 256, TargetITL: 50, Confidence: 0.9}
+	if _, err := ts.Capacity("llama", "H100", q); err == nil {
+		t.Fatal("expected error for a confidence level without covariance")
+	}
+
+	sd := []float64{0.05 * 7.7, 0.05 * 0.067, 0.05 * 5.5e-5}
+	withCov := *params
+	withCov.Covariance = [][]float64{{sd[0] * sd[0], 0, 0}, {0, sd[1] * sd[1], 0}, {0, 0, sd[2] * sd[2]}}
+	setParams(ts, "llama", "H100", &withCov)
+	robust, err := ts.Capacity("llama", "H100", q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q.Confidence = 0
+	mean, err := ts.Capacity("llama", "H100", q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if robust.Source != UncertaintyEKF || robust.MaxRate <= 0 || robust.MaxRate >= mean.MaxRate {
+		t.Errorf("p90 capacity %+v, want below the mean capacity %g", robust, mean.MaxRate)
+	}
+}
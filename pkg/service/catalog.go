@@ -0,0 +1,236 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Pattern syntaxes of a catalog entry.
+const (
+	MatchGlob  = "glob"  // '*' matches any run of characters and '?' any one, case-insensitively
+	MatchRegex = "regex" // an RE2 regular expression, matched against the whole name
+)
+
+// CatalogEntry seeds the pairs whose model, and accelerator if set, match its patterns with
+// benchmark-derived parameters. MinState and MaxState bound the EKF state of matched pairs in
+// place of the bounds derived from the seed; RelativeStd sets the EKF's initial uncertainty in
+// place of the config percentChange. All three are optional.
+type CatalogEntry struct {
+	Name        string    `json:"name"`
+	Model       string    `json:"model"`
+	Accelerator string    `json:"accelerator,omitempty"` // empty matches every accelerator
+	Match       string    `json:"match,omitempty"`       // MatchGlob (the default) or MatchRegex
+	Alpha       float64   `json:"alpha"`
+	Beta        float64   `json:"beta"`
+	Gamma       float64   `json:"gamma"`
+	MinState    []float64 `json:"minState,omitempty"`
+	MaxState    []float64 `json:"maxState,omitempty"`
+	RelativeStd []float64 `json:"relativeStd,omitempty"`
+
+	model, accelerator *regexp.Regexp
+}
+
+// Catalog is an ordered list of parameter profiles; a pair is seeded from the first entry that
+// matches it.
+type Catalog struct {
+	Entries []CatalogEntry `json:"entries"`
+}
+
+// LoadCatalog reads and validates a catalog from a JSON file.
+func LoadCatalog(path string) (*Catalog, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read catalog: %w", err)
+	}
+	var c Catalog
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("failed to parse catalog %s: %w", path, err)
+	}
+	if err := c.compile(); err != nil {
+		return nil, fmt.Errorf("invalid catalog %s: %w", path, err)
+	}
+	return &c, nil
+}
+
+// compile validates the entries and compiles their patterns.
+func (c *Catalog) compile() error {
+	names := make(map[string]bool, len(c.Entries))
+	for i := range c.Entries {
+		e := &c.Entries[i]
+		if e.Name == "" {
+			return fmt.Errorf("entry %d: name is required", i)
+		}
+		if names[e.Name] {
+			return fmt.Errorf("entry %q: duplicate name", e.Name)
+		}
+		names[e.Name] = true
+		if err := e.compile(); err != nil {
+			return fmt.Errorf("entry %q: %w", e.Name, err)
+		}
+	}
+	return nil
+}
+
+func (e *CatalogEntry) compile() error {
+	if e.Model == "" {
+		return errors.New("model pattern is required")
+	}
+	seed := e.seed()
+	for i, v := range seed {
+		if v <= 0 {
+			return fmt.Errorf("parameter %d must be positive, got %g", i, v)
+		}
+	}
+	if (e.MinState == nil) != (e.MaxState == nil) {
+		return errors.New("minState and maxState must be set together")
+	}
+	if e.MinState != nil {
+		if len(e.MinState) != len(seed) || len(e.MaxState) != len(seed) {
+			return fmt.Errorf("minState and maxState must have %d values", len(seed))
+		}
+		for i, v := range seed {
+			if e.MinState[i] <= 0 || e.MinState[i] > v || v > e.MaxState[i] {
+				return fmt.Errorf("parameter %d: bounds [%g, %g] must be positive and contain %g",
+					i, e.MinState[i], e.MaxState[i], v)
+			}
+		}
+	}
+	if e.RelativeStd != nil {
+		if len(e.RelativeStd) != len(seed) {
+			return fmt.Errorf("relativeStd must have %d values", len(seed))
+		}
+		for i, v := range e.RelativeStd {
+			if v <= 0 {
+				return fmt.Errorf("relativeStd %d must be positive, got %g", i, v)
+			}
+		}
+	}
+
+	var err error
+	if e.model, err = compilePattern(e.Model, e.Match); err != nil {
+		return fmt.Errorf("model pattern: %w", err)
+	}
+	if e.Accelerator != "" {
+		if e.accelerator, err = compilePattern(e.Accelerator, e.Match); err != nil {
+			return fmt.Errorf("accelerator pattern: %w", err)
+		}
+	}
+	return nil
+}
+
+// compilePattern compiles a pattern of the given syntax into a regexp matching whole names.
+func compilePattern(pattern, syntax string) (*regexp.Regexp, error) {
+	switch syntax {
+	case "", MatchGlob:
+		return CompileGlob(pattern)
+	case MatchRegex:
+		return regexp.Compile("^(?:" + pattern + ")$")
+	default:
+		return nil, fmt.Errorf("unknown match %q (want %q or %q)", syntax, MatchGlob, MatchRegex)
+	}
+}
+
+// CompileGlob compiles a glob into a regexp matching whole names, ignoring case: '*' matches any
+// run of characters, '/' included, and '?' any one character.
+func CompileGlob(pattern string) (*regexp.Regexp, error) {
+	var b strings.Builder
+	b.WriteString("(?i)^")
+	for _, r := range pattern {
+		switch r {
+		case '*':
+			b.WriteString(".*")
+		case '?':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	b.WriteString("$")
+	return regexp.Compile(b.String())
+}
+
+// Lookup returns the first entry matching (model, accelerator), or nil. A nil catalog matches
+// nothing.
+func (c *Catalog) Lookup(model, accelerator string) *CatalogEntry {
+	if c == nil {
+		return nil
+	}
+	for i := range c.Entries {
+		e := &c.Entries[i]
+		if e.model.MatchString(model) && (e.accelerator == nil || e.accelerator.MatchString(accelerator)) {
+			return e
+		}
+	}
+	return nil
+}
+
+func (e *CatalogEntry) seed() []float64 {
+	return []float64{e.Alpha, e.Beta, e.Gamma}
+}
+
+// SetCatalog sets the parameter catalog consulted for pairs seen thereafter; pairs already
+// started keep their prior. nil removes the catalog.
+func (ts *TunerService) SetCatalog(c *Catalog) {
+	ts.replayMu.Lock()
+	defer ts.replayMu.Unlock()
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	ts.catalog = c
+	for _, shadow := range ts.shadows {
+		shadow.SetCatalog(c)
+	}
+}
+
+// catalogPrior returns the prior of (model, accelerator) from the catalog, or nil if no entry
+// matches.
+func (ts *TunerService) catalogPrior(model, accelerator string) *ColdStartPrior {
+	e := ts.catalog.Lookup(model, accelerator)
+	if e == nil {
+		return nil
+	}
+	return &ColdStartPrior{
+		Seed:         e.seed(),
+		RelativeStd:  e.RelativeStd,
+		MinState:     e.MinState,
+		MaxState:     e.MaxState,
+		Source:       PriorCatalog,
+		CatalogEntry: e.Name,
+	}
+}
+
+// WatchCatalog reloads the catalog at path every interval while its modification time changes,
+// until ctx is done. A reload that fails is logged and the previous catalog kept.
+func (ts *TunerService) WatchCatalog(ctx context.Context, path string, interval time.Duration) {
+	var modTime time.Time
+	if info, err := os.Stat(path); err == nil {
+		modTime = info.ModTime()
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			info, err := os.Stat(path)
+			if err != nil || info.ModTime().Equal(modTime) {
+				continue
+			}
+			modTime = info.ModTime()
+			c, err := LoadCatalog(path)
+			if err != nil {
+				slog.Warn("catalog reload failed, keeping previous catalog", "err", err)
+				continue
+			}
+			ts.SetCatalog(c)
+			slog.Info("reloaded parameter catalog", "path", path, "entries", len(c.Entries))
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLoadCatalog(t *testing.T) {
+	c, err := LoadCatalog("../../config-data/parameter-catalog.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []struct{ model, accelerator, want string }{
+		{"meta-llama/Llama-3.1-8B-Instruct", "H100", "llama-3.1-8b-h100"},
+		{"meta-llama/Llama-3.1-8B-Instruct", "A100", ""},
+		{"meta-llama/Llama-3.1-70B-Instruct", "H100", ""},
+		{"ibm-granite/granite-3.1-8b-instruct", "A100", "granite-8b"},
+		{"ibm-granite/granite-8b-code", "L40S", "granite-8b"},
+		{"ibm-granite/granite-3.1-2b-instruct", "A100", ""},
+	} {
+		got := ""
+		if e := c.Lookup(tt.model, tt.accelerator); e != nil {
+			got = e.Name
+		}
+		if got != tt.want {
+			t.Errorf("Lookup(%q, %q) = %q, want %q", tt.model, tt.accelerator, got, tt.want)
+		}
+	}
+	if (*Catalog)(nil).Lookup("llama", "H100") != nil {
+		t.Error("nil catalog matched")
+	}
+}
+
+func TestLoadCatalog_Invalid(t *testing.T) {
+	for name, entries := range map[string]string{
+		"no name":        `{"model": "llama", "alpha": 1, "beta": 1, "gamma": 1}`,
+		"duplicate name": `{"name": "a", "model": "llama", "alpha": 1, "beta": 1, "gamma": 1}, {"name": "a", "model": "granite", "alpha": 1, "beta": 1, "gamma": 1}`,
+		"no model":       `{"name": "a", "alpha": 1, "beta": 1, "gamma": 1}`,
+		"zero gamma":     `{"name": "a", "model": "llama", "alpha": 1, "beta": 1, "gamma": 0}`,
+		"one bound":      `{"name": "a", "model": "llama", "alpha": 1, "beta": 1, "gamma": 1, "minState": [0.1, 0.1, 0.1]}`,
+		"seed outside":   `{"name": "a", "model": "llama", "alpha": 1, "beta": 1, "gamma": 1, "minState": [2, 0.1, 0.1], "maxState": [10, 10, 10]}`,
+		"short std":      `{"name": "a", "model": "llama", "alpha": 1, "beta": 1, "gamma": 1, "relativeStd": [0.1]}`,
+		"bad regex":      `{"name": "a", "model": "llama(", "match": "regex", "alpha": 1, "beta": 1, "gamma": 1}`,
+		"unknown match":  `{"name": "a", "model": "llama", "match": "prefix", "alpha": 1, "beta": 1, "gamma": 1}`,
+	} {
+		path := filepath.Join(t.TempDir(), "catalog.json")
+		if err := os.WriteFile(path, []byte(`{"entries": [`+entries+`]}`), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := LoadCatalog(path); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestTune_CatalogPrior(t *testing.T) {
+	t.Setenv("CONFIG_DATA_DIR", "../../config-data")
+	ts := NewTunerService(0, 1, false, false, DefaultWindowSize, DefaultResidualThreshold, 0)
+	ts.SetTransferPrior(true)
+	ts.paramStore.Set("llama", "A100", &LearnedParameters{Alpha: 15.4, Beta: 0.134, Gamma: 1.1e-4})
+	ts.paramStore.Set("granite-8b", "A100", &LearnedParameters{Alpha: 8, Beta: 0.08, Gamma: 8e-5})
+	ts.paramStore.Set("granite-8b", "H100", &LearnedParameters{Alpha: 4, Beta: 0.04, Gamma: 4e-5})
+	c := &Catalog{Entries: []CatalogEntry{{
+		Name: "llama-h100", Model: "LLAMA", Accelerator: "H*", Alpha: 7, Beta: 0.06, Gamma: 5e-5,
+		MinState: []float64{6, 0.05, 4e-5}, MaxState: []float64{8, 0.08, 7e-5}, RelativeStd: []float64{0.05, 0.05, 0.05},
+	}}}
+	if err := c.compile(); err != nil {
+		t.Fatal(err)
+	}
+	ts.SetCatalog(c)
+
+	for _, specs := range shadowTestSpecs(t, 3) {
+		if _, err := ts.Tune(specs); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// the catalog entry takes precedence over the prior transferred from llama on A100
+	if prior := ts.Prior("llama", "H100"); prior == nil || prior.Source != PriorCatalog || prior.CatalogEntry != "llama-h100" {
+		t.Fatalf("prior = %+v, want the catalog entry", prior)
+	}
+	params := ts.GetRawParams("llama", "H100")
+	if params.Alpha < 6 || params.Alpha > 8 || params.Beta < 0.05 || params.Beta > 0.08 || params.Gamma < 4e-5 || params.Gamma > 7e-5 {
+		t.Errorf("params = %+v, want them within the catalog bounds", params)
+	}
+	if ts.Prior("granite-8b", "H100") != nil {
+		t.Error("prior for a pair that was not seen")
+	}
+}
+
+func TestWatchCatalog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "catalog.json")
+	write := func(model string, modTime time.Time) {
+		entry := `{"name": "a", "model": "` + model + `", "alpha": 1, "beta": 1, "gamma": 1}`
+		if err := os.WriteFile(path, []byte(`{"entries": [`+entry+`]}`), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("llama", time.Now().Add(-time.Hour))
+	c, err := LoadCatalog(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := NewTunerService(0, 1, false, false, DefaultWindowSize, DefaultResidualThreshold, 0)
+	ts.SetCatalog(c)
+	matches := func(model string) bool {
+		ts.mu.Lock()
+		defer ts.mu.Unlock()
+		return ts.catalog.Lookup(model, "H100") != nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go ts.WatchCatalog(ctx, path, 5*time.Millisecond)
+
+	// an invalid file keeps the previous catalog
+	if err := os.WriteFile(path, []byte("{"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(50 * time.Millisecond)
+	if !matches("llama") {
+		t.Fatal("invalid reload replaced the catalog")
+	}
+
+	write("granite", time.Now())
+	for deadline := time.Now().Add(5 * time.Second); !matches("granite"); time.Sleep(5 * time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatal("catalog not reloaded")
+		}
+	}
+	if matches("llama") {
+		t.Error("reloaded catalog still matches the old entry")
+	}
+}
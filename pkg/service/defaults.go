@@ -25,6 +25,38 @@ const (
 	DefaultResidualThreshold = 0.5
 )
 
+// Environment variable name for shadow estimators: estimator configurations run alongside the
+// primary on the same observations and compared on their one-step-ahead prediction error, in the
+// ParseShadowEstimators format (e.g. "wide:windowSize=20;ekf:mode=ekf"). Unset runs none.
+const (
+	ShadowEstimatorsEnvName = "TUNER_SHADOW_ESTIMATORS"
+)
+
+// Environment variable names and defaults for parameter publication (see PublicationPolicy).
+// TUNER_PUBLISH_SMOOTHING is "none", "ema" or "median"; TUNER_PUBLISH_MAX_CHANGE limits each
+// published parameter to a factor of 1 + the value per cycle, 0 disabling the limit.
+const (
+	PublishSmoothingEnvName    = "TUNER_PUBLISH_SMOOTHING"
+	PublishEMAWeightEnvName    = "TUNER_PUBLISH_EMA_WEIGHT"
+	PublishMedianWindowEnvName = "TUNER_PUBLISH_MEDIAN_WINDOW"
+	PublishMaxChangeEnvName    = "TUNER_PUBLISH_MAX_CHANGE"
+
+	DefaultPublishEMAWeight    = 0.3
+	DefaultPublishMedianWindow = 3
+	DefaultPublishMaxChange    = 0.0
+)
+
+// Environment variable names and defaults for parameter staleness (see StalenessPolicy). The
+// TTLs are durations (e.g. "1h"); empty disables them. When TUNER_EXPIRE_AFTER is set, cmd/tuner
+// checks for expired pairs every tenth of it.
+const (
+	StaleAfterEnvName   = "TUNER_STALE_AFTER"
+	ExpireAfterEnvName  = "TUNER_EXPIRE_AFTER"
+	ExcludeStaleEnvName = "TUNER_EXCLUDE_STALE"
+
+	DefaultExcludeStale = false
+)
+
 // Environment variable name and default for the init-fit quality threshold.
 const (
 	InitFitThresholdEnvName = "TUNER_INIT_FIT_THRESHOLD"
@@ -42,6 +74,67 @@ const (
 	DefaultMaxConditionNumber = 1000.0
 )
 
+// Environment variable name and default for cross-pair cold-start priors. When true, a newly seen
+// pair is seeded from the same model on other accelerators (scaled by learned accelerator ratios)
+// or from models of similar size on the same accelerator, instead of the config initState.
+const (
+	TransferPriorEnvName = "TUNER_TRANSFER_PRIOR"
+	DefaultTransferPrior = false
+)
+
+// Environment variable names and default for the parameter catalog (see Catalog). The file is
+// loaded at startup and, unless the reload interval (a duration, e.g. "30s") is "0", checked at
+// that interval and reloaded whenever its modification time changes.
+const (
+	CatalogPathEnvName           = "TUNER_CATALOG_PATH"
+	CatalogReloadIntervalEnvName = "TUNER_CATALOG_RELOAD_INTERVAL"
+
+	DefaultCatalogReloadInterval = "30s"
+)
+
+// Environment variable name and default for init bootstrap from Prometheus history. When > 0
+// (and PROMETHEUS_ADDRESS is set), cmd/tuner pre-fills each newly seen pair with up to this many
+// past query windows of observations. 0 disables bootstrap.
+const (
+	BootstrapWindowsEnvName = "TUNER_BOOTSTRAP_WINDOWS"
+	DefaultBootstrapWindows = 0
+)
+
+// Environment variable name and default for push-style ingestion. When set to a positive
+// duration (e.g. "30s"), cmd/tuner accepts single replica observations on POST /observe and
+// tunes every interval from the observations buffered since the last one. Empty disables it.
+const (
+	ObserveIntervalEnvName = "TUNER_OBSERVE_INTERVAL"
+	DefaultObserveInterval = ""
+)
+
+// Environment variable name and default for the number of observations each replica can have
+// buffered for the next scheduled tune; POST /observe returns 429 beyond it.
+const (
+	ObserveMaxPendingEnvName = "TUNER_OBSERVE_MAX_PENDING"
+	DefaultObserveMaxPending = 1000
+)
+
+// Environment variable names and defaults for active mode. When TUNER_ACTIVE_SOURCE is
+// "prometheus" (OnlineObserver queries) or "scrape" (direct vLLM /metrics scraping), cmd/tuner
+// polls that source every TUNER_ACTIVE_INTERVAL and tunes without a controller. Empty keeps the
+// service passive.
+const (
+	ActiveSourceEnvName   = "TUNER_ACTIVE_SOURCE"
+	ActiveIntervalEnvName = "TUNER_ACTIVE_INTERVAL"
+
+	ActiveSourcePrometheus = "prometheus"
+	ActiveSourceScrape     = "scrape"
+	DefaultActiveInterval  = "30s"
+)
+
+// Environment variable name for replay mode. When set to the path of a recording written by the
+// tunerservice traffic recorder, cmd/tuner replays it through a fresh TunerService, prints the
+// parameter trajectory and exits instead of serving.
+const (
+	ReplayPathEnvName = "TUNER_REPLAY_PATH"
+)
+
 // Default field values used when the ParameterStore has a model/accelerator entry
 // that is not present in the Controller's current ModelData.
 const (
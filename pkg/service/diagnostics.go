@@ -0,0 +1,44 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/llm-inferno/model-tuner/pkg/estimator"
+)
+
+// Diagnostics is the goodness of fit of a pair's stored parameters to its retained observations.
+type Diagnostics struct {
+	Alpha float32 `json:"alpha"`
+	Beta  float32 `json:"beta"`
+	Gamma float32 `json:"gamma"`
+	// Init holds the observations the pair's init estimator has accumulated (those since the
+	// first tune, or the sweep of the last calibration); nil if the tuner holds none for the pair.
+	Init *estimator.FitDiagnostics `json:"init,omitempty"`
+	// Sliding holds the sliding window, which starts from the init observations; nil unless the
+	// pair is tuned by the sliding-window estimator.
+	Sliding *estimator.FitDiagnostics `json:"sliding,omitempty"`
+}
+
+// Diagnostics evaluates the raw fit of (model, accelerator), before publication, against the observations
+// the pair's estimators retain: predicted versus observed TTFT and ITL per observation, the
+// outliers the sliding window would drop, and the singular value decomposition of the residual
+// Jacobian, whose small values mark weakly determined parameter directions. Parameters restored
+// or merged without observations yield no windows. It does not change tuner state.
+func (ts *TunerService) Diagnostics(model, accelerator string) (*Diagnostics, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	params := ts.paramStore.Get(model, accelerator)
+	if params == nil {
+		return nil, fmt.Errorf("%w for model=%s accelerator=%s", ErrNoParams, model, accelerator)
+	}
+	x := []float64{float64(params.Alpha), float64(params.Beta), float64(params.Gamma)}
+	d := &Diagnostics{Alpha: params.Alpha, Beta: params.Beta, Gamma: params.Gamma}
+	key := makeKey(model, accelerator)
+	if ie, ok := ts.estimators[key]; ok {
+		d.Init = ie.Diagnose(x)
+	}
+	if swe, ok := ts.slidingEstimators[key]; ok {
+		d.Sliding = swe.Diagnose(x)
+	}
+	return d, nil
+}
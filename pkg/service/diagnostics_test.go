@@ -0,0 +1,54 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/llm-inferno/optimizer-light/pkg/config"
+
+	"github.com/llm-inferno/model-tuner/pkg/core"
+)
+
+func TestDiagnostics(t *testing.T) {
+	t.Setenv("CONFIG_DATA_DIR", "../../config-data")
+	ts := NewTunerService(0, 3, false, true, 5, DefaultResidualThreshold, 0)
+	if _, err := ts.Diagnostics("llama", "H100"); !errors.Is(err, ErrNoParams) {
+		t.Fatalf("Diagnostics before tuning: %v, want ErrNoParams", err)
+	}
+
+	// observations generated by the model itself, at spread token profiles
+	for _, tokens := range [][2]int{{512, 128}, {1024, 256}, {2048, 512}, {1024, 128}} {
+		env := makeTestEnv(60, 0, 0, float32(tokens[0]), float32(tokens[1]), 64)
+		metrics, err := core.AnalyzePrefillDecode(env, 7.7, 0.067, 5.5e-5)
+		if err != nil {
+			t.Fatal(err)
+		}
+		spec := makeTestSpec("llama", "H100", 60, metrics.AvgTTFT, metrics.AvgTokenTime, tokens[0], tokens[1], 64)
+		ts.Tune([]config.ServerSpec{spec})
+	}
+
+	d, err := ts.Diagnostics("llama", "H100")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.Alpha <= 0 || d.Init == nil || len(d.Init.Observations) != 4 {
+		t.Fatalf("diagnostics = %+v, want every observation in the init window", d)
+	}
+	if d.Sliding == nil || len(d.Sliding.Observations) != 4 {
+		t.Fatalf("sliding = %+v, want the three init observations and one more", d.Sliding)
+	}
+	if d.Sliding.RMSResidual > 0.05 || d.Sliding.Identifiability == nil {
+		t.Errorf("sliding fit = %+v, want a close fit with identifiability", d.Sliding)
+	}
+	for i, o := range d.Sliding.Observations {
+		if !o.Evaluated || o.Outlier || o.PredictedTTFT <= 0 {
+			t.Errorf("observation %d = %+v, want an evaluated inlier", i, o)
+		}
+	}
+
+	// parameters without retained observations have nothing to be evaluated against
+	ts.paramStore.Set("granite", "H100", &LearnedParameters{Alpha: 7.7, Beta: 0.067, Gamma: 5.5e-5})
+	if d, err := ts.Diagnostics("granite", "H100"); err != nil || d.Init != nil || d.Sliding != nil {
+		t.Errorf("diagnostics without observations = (%+v, %v), want no windows", d, err)
+	}
+}
@@ -0,0 +1,174 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	optconfig "github.com/llm-inferno/optimizer-light/pkg/config"
+)
+
+// ErrObserveBufferFull is returned by Ingester.Observe when the replica already has the maximum
+// number of observations buffered; the observation is not buffered.
+var ErrObserveBufferFull = errors.New("observation buffer full")
+
+// Ingester accepts single replica observations pushed as they arrive (e.g. by node metrics
+// agents) and, every interval, aggregates each replica's buffered observations into one
+// ServerSpec and runs them through Tune as one cycle. Tuning cadence is then set by the interval
+// rather than by the controller's cycle. It is safe for concurrent use.
+type Ingester struct {
+	service    *TunerService
+	interval   time.Duration
+	maxPending int // per replica
+	onTune     func(specs []optconfig.ServerSpec)
+
+	mu      sync.Mutex
+	buffers map[string]map[string][]optconfig.ServerSpec // pair key -> replica name -> observations
+}
+
+// NewIngester creates an Ingester that tunes service every interval once Run is started, and
+// buffers up to maxPending observations of each replica in between.
+func NewIngester(service *TunerService, interval time.Duration, maxPending int) (*Ingester, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("interval must be positive, got %s", interval)
+	}
+	if maxPending <= 0 {
+		return nil, fmt.Errorf("max pending observations must be positive, got %d", maxPending)
+	}
+	return &Ingester{
+		service:    service,
+		interval:   interval,
+		maxPending: maxPending,
+		buffers:    make(map[string]map[string][]optconfig.ServerSpec),
+	}, nil
+}
+
+// OnTune registers a hook called with the aggregated specs before each scheduled Tune, e.g. to
+// record them. Set it before Run.
+func (in *Ingester) OnTune(hook func(specs []optconfig.ServerSpec)) {
+	in.onTune = hook
+}
+
+// Observe buffers one replica observation until the next flush and returns the number buffered
+// for that replica. The spec must name a model, an accelerator and the replica (Name), which
+// keys the buffer; replicas whose aggregate has no traffic are dropped by Tune. A replica with maxPending observations
+// buffered gets ErrObserveBufferFull until the next flush.
+func (in *Ingester) Observe(spec optconfig.ServerSpec) (pending int, err error) {
+	if spec.Model == "" || spec.CurrentAlloc.Accelerator == "" {
+		return 0, fmt.Errorf("observation must name a model and an accelerator")
+	}
+	if spec.Name == "" {
+		return 0, fmt.Errorf("observation must name the replica")
+	}
+	key := makeKey(spec.Model, spec.CurrentAlloc.Accelerator)
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	if in.buffers[key] == nil {
+		in.buffers[key] = make(map[string][]optconfig.ServerSpec)
+	}
+	if pending := len(in.buffers[key][spec.Name]); pending >= in.maxPending {
+		return pending, fmt.Errorf("%w: %d observations of replica %q of %s pending", ErrObserveBufferFull,
+			pending, spec.Name, key)
+	}
+	in.buffers[key][spec.Name] = append(in.buffers[key][spec.Name], spec)
+	return len(in.buffers[key][spec.Name]), nil
+}
+
+// Pending returns the number of buffered observations.
+func (in *Ingester) Pending() int {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	n := 0
+	for _, replicas := range in.buffers {
+		for _, obs := range replicas {
+			n += len(obs)
+		}
+	}
+	return n
+}
+
+// Flush aggregates and clears the buffers and runs one Tune cycle over them, with the specs
+// sorted by model, accelerator and replica name so that a recorded cycle replays the same. It
+// returns nil ModelData and no error when nothing was buffered.
+func (in *Ingester) Flush() (*optconfig.ModelData, error) {
+	in.mu.Lock()
+	buffers := in.buffers
+	in.buffers = make(map[string]map[string][]optconfig.ServerSpec)
+	in.mu.Unlock()
+
+	var specs []optconfig.ServerSpec
+	for _, replicas := range buffers {
+		for _, obs := range replicas {
+			specs = append(specs, aggregateObservations(obs))
+		}
+	}
+	if len(specs) == 0 {
+		return nil, nil
+	}
+	slices.SortFunc(specs, func(a, b optconfig.ServerSpec) int {
+		return cmp.Or(cmp.Compare(a.Model, b.Model), cmp.Compare(a.CurrentAlloc.Accelerator, b.CurrentAlloc.Accelerator),
+			cmp.Compare(a.Name, b.Name))
+	})
+	if in.onTune != nil {
+		in.onTune(specs)
+	}
+	return in.service.Tune(specs)
+}
+
+// Run flushes every interval until ctx is cancelled.
+func (in *Ingester) Run(ctx context.Context) {
+	ticker := time.NewTicker(in.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			modelData, err := in.Flush()
+			if err != nil {
+				slog.Warn("scheduled tune failed", "err", err)
+			} else if modelData != nil {
+				slog.Info("scheduled tune complete", "pairs", len(modelData.PerfData))
+			}
+		}
+	}
+}
+
+// aggregateObservations combines one replica's observations over an interval into one spec: the
+// mean arrival rate and throughput, arrival-rate-weighted means of the token counts and latencies
+// (busier observations cover more requests), and the latest spec's other fields.
+func aggregateObservations(obs []optconfig.ServerSpec) optconfig.ServerSpec {
+	out := obs[len(obs)-1]
+	if len(obs) == 1 {
+		return out
+	}
+	var rate, throughput, weight, inTokens, outTokens, ttft, itl float64
+	for _, o := range obs {
+		load := o.CurrentAlloc.Load
+		rate += float64(load.ArrivalRate)
+		throughput += float64(load.Throughput)
+		w := float64(load.ArrivalRate)
+		if w <= 0 {
+			continue
+		}
+		weight += w
+		inTokens += w * float64(load.AvgInTokens)
+		outTokens += w * float64(load.AvgOutTokens)
+		ttft += w * float64(o.CurrentAlloc.TTFTAverage)
+		itl += w * float64(o.CurrentAlloc.ITLAverage)
+	}
+	out.CurrentAlloc.Load.ArrivalRate = float32(rate / float64(len(obs)))
+	out.CurrentAlloc.Load.Throughput = float32(throughput / float64(len(obs)))
+	if weight > 0 {
+		out.CurrentAlloc.Load.AvgInTokens = int(inTokens/weight + 0.5)
+		out.CurrentAlloc.Load.AvgOutTokens = int(outTokens/weight + 0.5)
+		out.CurrentAlloc.TTFTAverage = float32(ttft / weight)
+		out.CurrentAlloc.ITLAverage = float32(itl / weight)
+	}
+	return out
+}
@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	optconfig "github.com/llm-inferno/optimizer-light/pkg/config"
+)
+
+func TestAggregateObservations(t *testing.T) {
+	a := makeTestSpec("m", "acc", 30, 100, 10, 512, 128, 64)
+	b := makeTestSpec("m", "acc", 90, 200, 20, 1024, 256, 64)
+	got := aggregateObservations([]optconfig.ServerSpec{a, b})
+
+	alloc := got.CurrentAlloc
+	if alloc.Load.ArrivalRate != 60 {
+		t.Errorf("arrival rate = %g, want mean 60", alloc.Load.ArrivalRate)
+	}
+	// weights 30 and 90: (30*100 + 90*200) / 120 = 175
+	if alloc.TTFTAverage != 175 || alloc.ITLAverage != 17.5 {
+		t.Errorf("latencies = (%g, %g), want rate-weighted (175, 17.5)", alloc.TTFTAverage, alloc.ITLAverage)
+	}
+	if alloc.Load.AvgInTokens != 896 || alloc.Load.AvgOutTokens != 224 {
+		t.Errorf("tokens = (%d, %d), want (896, 224)", alloc.Load.AvgInTokens, alloc.Load.AvgOutTokens)
+	}
+	if single := aggregateObservations([]optconfig.ServerSpec{a}); single.CurrentAlloc != a.CurrentAlloc {
+		t.Error("a single observation should pass through unchanged")
+	}
+}
+
+func TestIngester_FlushTunesOncePerInterval(t *testing.T) {
+	t.Setenv("CONFIG_DATA_DIR", "../../config-data")
+	ts := NewTunerService(3, 5, true, false, DefaultWindowSize, DefaultResidualThreshold, 0)
+	in, err := NewIngester(ts, time.Hour, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var tuned [][]optconfig.ServerSpec
+	in.OnTune(func(specs []optconfig.ServerSpec) { tuned = append(tuned, specs) })
+
+	if md, err := in.Flush(); md != nil || err != nil {
+		t.Fatalf("empty flush = (%v, %v), want (nil, nil)", md, err)
+	}
+	for i := range 3 {
+		spec := makeTestSpec("llama", "H100", float32(30+i), 50, 8, 512, 128, 64)
+		spec.Name = "llama-0"
+		if n, err := in.Observe(spec); err != nil || n != i+1 {
+			t.Fatalf("Observe %d = (%d, %v)", i, n, err)
+		}
+	}
+	if _, err := in.Observe(makeTestSpec("", "H100", 30, 50, 8, 512, 128, 64)); err == nil {
+		t.Error("expected error for an observation without a model")
+	}
+	// unnamed replicas would share one buffer and be averaged as one replica
+	if _, err := in.Observe(makeTestSpec("llama", "H100", 30, 50, 8, 512, 128, 64)); err == nil {
+		t.Error("expected error for an observation without a replica name")
+	}
+	if in.Pending() != 3 {
+		t.Fatalf("Pending = %d, want 3", in.Pending())
+	}
+
+	// Still collecting init observations, so Tune reports no results; the point is one cycle.
+	_, _ = in.Flush()
+	if in.Pending() != 0 {
+		t.Errorf("Pending after flush = %d, want 0", in.Pending())
+	}
+	if len(tuned) != 1 || len(tuned[0]) != 1 {
+		t.Fatalf("expected one tune of one aggregated spec, got %v", tuned)
+	}
+	if got := ts.estimators[makeKey("llama", "H100")].ObsCount(); got != 1 {
+		t.Errorf("estimator observations = %d, want 1 per interval", got)
+	}
+}
+
+func TestIngester_FlushSortsAndCapsBuffers(t *testing.T) {
+	t.Setenv("CONFIG_DATA_DIR", "../../config-data")
+	ts := NewTunerService(3, 5, true, false, DefaultWindowSize, DefaultResidualThreshold, 0)
+	in, err := NewIngester(ts, time.Hour, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var tuned []optconfig.ServerSpec
+	in.OnTune(func(specs []optconfig.ServerSpec) { tuned = specs })
+
+	replicas := []struct{ model, acc, name string }{
+		{"llama", "H100", "llama-1"}, {"granite", "H100", "granite-0"}, {"llama", "A100", "llama-a"},
+		{"llama", "H100", "llama-0"}, {"granite", "A100", "granite-a"},
+	}
+	for _, r := range replicas {
+		spec := makeTestSpec(r.model, r.acc, 30, 50, 8, 512, 128, 64)
+		spec.Name = r.name
+		for i := range 3 {
+			n, err := in.Observe(spec)
+			if i < 2 && (err != nil || n != i+1) {
+				t.Fatalf("%s observation %d = (%d, %v)", r.name, i, n, err)
+			}
+			if i == 2 && (!errors.Is(err, ErrObserveBufferFull) || n != 2) {
+				t.Errorf("%s over the cap = (%d, %v), want ErrObserveBufferFull", r.name, n, err)
+			}
+		}
+	}
+	if in.Pending() != 2*len(replicas) {
+		t.Errorf("Pending = %d, want %d", in.Pending(), 2*len(replicas))
+	}
+
+	_, _ = in.Flush()
+	var got []string
+	for _, spec := range tuned {
+		got = append(got, spec.Name)
+	}
+	want := []string{"granite-a", "granite-0", "llama-a", "llama-0", "llama-1"}
+	if !slices.Equal(got, want) {
+		t.Errorf("flushed replicas %v, want %v", got, want)
+	}
+	// the flush emptied the buffers
+	after := makeTestSpec("llama", "H100", 30, 50, 8, 512, 128, 64)
+	after.Name = "llama-0"
+	if n, err := in.Observe(after); err != nil || n != 1 {
+		t.Errorf("Observe after flush = (%d, %v), want (1, nil)", n, err)
+	}
+}
+
+func TestIngester_RunStopsOnCancel(t *testing.T) {
+	t.Setenv("CONFIG_DATA_DIR", "../../config-data")
+	ts := NewTunerService(3, 5, true, false, DefaultWindowSize, DefaultResidualThreshold, 0)
+	in, err := NewIngester(ts, 10*time.Millisecond, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	flushed := make(chan struct{}, 1)
+	in.OnTune(func([]optconfig.ServerSpec) {
+		select {
+		case flushed <- struct{}{}:
+		default:
+		}
+	})
+	spec := makeTestSpec("llama", "H100", 30, 50, 8, 512, 128, 64)
+	spec.Name = "llama-0"
+	if _, err := in.Observe(spec); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		in.Run(ctx)
+		close(done)
+	}()
+	select {
+	case <-flushed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("scheduler did not flush")
+	}
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run did not return after cancel")
+	}
+
+	if _, err := NewIngester(ts, 0, 10); err == nil {
+		t.Error("expected error for zero interval")
+	}
+	if _, err := NewIngester(ts, time.Second, 0); err == nil {
+		t.Error("expected error for zero max pending")
+	}
+}
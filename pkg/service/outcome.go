@@ -0,0 +1,55 @@
+package service
+
+// Reason codes of a GroupOutcome.
+const (
+	// OutcomeTuned: new parameters were stored this cycle.
+	OutcomeTuned = "tuned"
+	// OutcomeCollecting: the pair is still collecting its initial observations (ObsCount of
+	// ObsTarget).
+	OutcomeCollecting = "collecting-init-observations"
+	// OutcomeWindowFilling: the sliding window does not hold enough observations to fit yet
+	// (WindowCount of WindowSize).
+	OutcomeWindowFilling = "sliding-window-filling"
+	// OutcomeEKFFallback: the init fit was too poor for the sliding window, so the pair switched
+	// to the EKF this cycle; it is tuned by the EKF from the next cycle on.
+	OutcomeEKFFallback = "ekf-fallback"
+	// OutcomeNISRejected: every EKF update of the cycle was rejected by the NIS gate.
+	OutcomeNISRejected = "nis-rejected"
+	// OutcomeValidationRejected: every EKF update of the cycle produced an invalid state.
+	OutcomeValidationRejected = "validation-rejected"
+	// OutcomeNoValidObservations: no replica of the group yielded a usable observation.
+	OutcomeNoValidObservations = "no-valid-observations"
+	// OutcomeError: the estimator failed; Message has the details.
+	OutcomeError = "error"
+)
+
+// Estimators reported in a GroupOutcome.
+const (
+	EstimatorEKF           = "ekf"
+	EstimatorSlidingWindow = "sliding-window"
+)
+
+// GroupOutcome reports what one Tune cycle did for one (model, accelerator) group, so callers
+// can tell a group still warming up from one whose update was rejected. Fresh parameters were
+// stored this cycle; parameters that are present but not fresh were carried over from an earlier
+// cycle and are what the ModelData returns for the group.
+type GroupOutcome struct {
+	Model       string `json:"model"`
+	Accelerator string `json:"accelerator"`
+	Reason      string `json:"reason"`            // one of the Outcome* codes
+	Message     string `json:"message,omitempty"` // why the group was not tuned
+	Estimator   string `json:"estimator"`         // EstimatorEKF or EstimatorSlidingWindow
+	EKFFallback bool   `json:"ekfFallback"`       // the EKF tunes this pair because the sliding-window init fit was poor
+
+	HasParams   bool `json:"hasParams"`   // the returned ModelData includes the group
+	Fresh       bool `json:"fresh"`       // its parameters were updated this cycle
+	UpdateCount int  `json:"updateCount"` // updates stored for the pair so far
+
+	Replicas    int `json:"replicas"`    // replicas with traffic in the request
+	Accepted    int `json:"accepted"`    // EKF updates accepted this cycle (one per replica)
+	Rejected    int `json:"rejected"`    // EKF updates rejected this cycle
+	ObsCount    int `json:"obsCount"`    // initial observations collected
+	ObsTarget   int `json:"obsTarget"`   // initial observations required
+	WindowCount int `json:"windowCount"` // sliding-window observations held
+	WindowSize  int `json:"windowSize"`  // sliding-window capacity
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"testing"
+
+	optconfig "github.com/llm-inferno/optimizer-light/pkg/config"
+)
+
+func TestTuneWithOutcomes_ReportsWarmUpThenTuned(t *testing.T) {
+	t.Setenv("CONFIG_DATA_DIR", "../../config-data")
+	ts := NewTunerService(0, 3, false, false, DefaultWindowSize, DefaultResidualThreshold, 0)
+	spec := makeTestSpec("llama", "H100", 30, 50, 8, 512, 128, 64)
+
+	for i := 1; i < 3; i++ {
+		md, outcomes, err := ts.TuneWithOutcomes([]optconfig.ServerSpec{spec})
+		if err == nil || md != nil {
+			t.Fatalf("tune %d = (%v, %v), want no results while collecting", i, md, err)
+		}
+		if len(outcomes) != 1 {
+			t.Fatalf("tune %d: got %d outcomes, want 1", i, len(outcomes))
+		}
+		o := outcomes[0]
+		if o.Reason != OutcomeCollecting || o.ObsCount != i || o.ObsTarget != 3 || o.Fresh || o.HasParams {
+			t.Errorf("tune %d: outcome = %+v, want collecting %d/3 without params", i, o, i)
+		}
+		if o.Model != "llama" || o.Accelerator != "H100" || o.Estimator != EstimatorEKF || o.Message == "" {
+			t.Errorf("tune %d: outcome = %+v", i, o)
+		}
+	}
+
+	md, outcomes, err := ts.TuneWithOutcomes([]optconfig.ServerSpec{spec})
+	if err != nil || md == nil {
+		t.Fatalf("third tune = (%v, %v), want results", md, err)
+	}
+	o := outcomes[0]
+	if o.Reason != OutcomeTuned || !o.Fresh || !o.HasParams || o.UpdateCount != 1 || o.Accepted != 1 || o.Message != "" {
+		t.Errorf("outcome = %+v, want a fresh tuned group", o)
+	}
+}
+
+func TestTuneWithOutcomes_SortedAndCarriedOver(t *testing.T) {
+	t.Setenv("CONFIG_DATA_DIR", "../../config-data")
+	ts := NewTunerService(0, 2, false, false, DefaultWindowSize, DefaultResidualThreshold, 0)
+	// Parameters restored from an earlier run carry over while the new estimators collect.
+	setParams(ts, "llama", "A100", &LearnedParameters{Alpha: 10, Beta: 0.05, Gamma: 0.001, UpdateCount: 4})
+
+	specs := []optconfig.ServerSpec{
+		makeTestSpec("llama", "H100", 30, 50, 8, 512, 128, 64),
+		makeTestSpec("llama", "A100", 30, 50, 8, 512, 128, 64),
+	}
+	md, outcomes, err := ts.TuneWithOutcomes(specs)
+	if err != nil || md == nil {
+		t.Fatalf("tune = (%v, %v), want carried-over results", md, err)
+	}
+	if len(outcomes) != 2 || outcomes[0].Accelerator != "A100" || outcomes[1].Accelerator != "H100" {
+		t.Fatalf("outcomes = %+v, want A100 then H100", outcomes)
+	}
+	if o := outcomes[0]; o.Reason != OutcomeCollecting || o.Fresh || !o.HasParams || o.UpdateCount != 4 {
+		t.Errorf("A100 outcome = %+v, want carried-over params while collecting", o)
+	}
+}
@@ -16,8 +16,16 @@ type LearnedParameters struct {
 	Gamma       float32
 	NIS         float64
 	UpdateCount int
-	Covariance  [][]float64
-	LastUpdated time.Time
+	Covariance  [][]float64 // EKF state covariance, restored by the next EKF cycle
+	// FitCovariance is the Gauss-Newton covariance of a Nelder-Mead fit (sliding window or
+	// calibration); it only feeds prediction intervals and is never restored into the EKF.
+	FitCovariance [][]float64
+	LastUpdated   time.Time
+	// RawAlpha, RawBeta and RawGamma are the estimator's own fit behind published parameters,
+	// which a PublicationPolicy may have smoothed or limited; zero on the raw fit itself.
+	RawAlpha float32
+	RawBeta  float32
+	RawGamma float32
 }
 
 // CovarianceMatrix converts the stored slice representation back to a mat.Dense.
@@ -33,15 +41,26 @@ func (lp *LearnedParameters) CovarianceMatrix() *mat.Dense {
 	return mat.NewDense(n, n, data)
 }
 
+// ParameterUpdate is the parameters stored for one model/accelerator pair.
+type ParameterUpdate struct {
+	Model       string
+	Accelerator string
+	Params      *LearnedParameters
+}
+
 // ParameterStore is a thread-safe in-memory store of LearnedParameters keyed by "modelName/accelerator".
 type ParameterStore struct {
-	mu     sync.RWMutex
-	params map[string]*LearnedParameters
+	mu       sync.RWMutex
+	params   map[string]*LearnedParameters
+	watchers map[chan ParameterUpdate]struct{}
 }
 
 // NewParameterStore creates an empty ParameterStore.
 func NewParameterStore() *ParameterStore {
-	return &ParameterStore{params: make(map[string]*LearnedParameters)}
+	return &ParameterStore{
+		params:   make(map[string]*LearnedParameters),
+		watchers: make(map[chan ParameterUpdate]struct{}),
+	}
 }
 
 func makeKey(model, accelerator string) string {
@@ -70,6 +89,61 @@ func (ps *ParameterStore) Set(model, accelerator string, params *LearnedParamete
 	ps.mu.Lock()
 	defer ps.mu.Unlock()
 	ps.params[makeKey(model, accelerator)] = params
+	update := ParameterUpdate{Model: model, Accelerator: accelerator, Params: params}
+	for ch := range ps.watchers {
+		select {
+		case ch <- update:
+		default: // a slow watcher misses updates rather than blocking tuning
+		}
+	}
+}
+
+// Delete drops the parameters of a model/accelerator pair. Watchers are not notified.
+func (ps *ParameterStore) Delete(model, accelerator string) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	delete(ps.params, makeKey(model, accelerator))
+}
+
+// Watch returns a snapshot of all stored parameters and a channel, buffered to buffer updates,
+// that receives every later Set; no update falls between the two. Updates to a watcher whose
+// buffer is full are dropped. cancel stops the watch and closes the channel.
+func (ps *ParameterStore) Watch(buffer int) (snapshot []ParameterUpdate, updates <-chan ParameterUpdate, cancel func()) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	snapshot = make([]ParameterUpdate, 0, len(ps.params))
+	for key, params := range ps.params {
+		model, accelerator := splitKey(key)
+		snapshot = append(snapshot, ParameterUpdate{Model: model, Accelerator: accelerator, Params: params})
+	}
+	ch := make(chan ParameterUpdate, buffer)
+	ps.watchers[ch] = struct{}{}
+	var once sync.Once
+	cancel = func() {
+		once.Do(func() {
+			ps.mu.Lock()
+			defer ps.mu.Unlock()
+			delete(ps.watchers, ch)
+			close(ch)
+		})
+	}
+	return snapshot, ch, cancel
+}
+
+// Replace makes params the store's content: pairs absent from params are dropped, silently, and
+// every pair in params is Set, notifying watchers.
+func (ps *ParameterStore) Replace(params map[string]*LearnedParameters) {
+	ps.mu.Lock()
+	for key := range ps.params {
+		if params[key] == nil {
+			delete(ps.params, key)
+		}
+	}
+	ps.mu.Unlock()
+	for key, p := range params {
+		model, accelerator := splitKey(key)
+		ps.Set(model, accelerator, p)
+	}
 }
 
 // GetAll returns a snapshot of all stored parameters.
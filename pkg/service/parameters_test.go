@@ -0,0 +1,32 @@
+package service
+
+import "testing"
+
+func TestParameterStore_Watch(t *testing.T) {
+	ps := NewParameterStore()
+	ps.Set("llama", "H100", &LearnedParameters{Alpha: 1})
+
+	snapshot, updates, cancel := ps.Watch(1)
+	if len(snapshot) != 1 || snapshot[0].Model != "llama" || snapshot[0].Accelerator != "H100" {
+		t.Fatalf("snapshot = %+v, want the stored llama/H100", snapshot)
+	}
+
+	ps.Set("org/granite", "A100", &LearnedParameters{Alpha: 2})
+	ps.Set("org/granite", "A100", &LearnedParameters{Alpha: 3}) // buffer full: dropped
+	u := <-updates
+	if u.Model != "org/granite" || u.Accelerator != "A100" || u.Params.Alpha != 2 {
+		t.Errorf("update = %+v, want org/granite/A100 alpha 2", u)
+	}
+	select {
+	case u := <-updates:
+		t.Errorf("unexpected update %+v past a full buffer", u)
+	default:
+	}
+
+	cancel()
+	cancel()
+	if _, ok := <-updates; ok {
+		t.Error("channel should be closed after cancel")
+	}
+	ps.Set("llama", "H100", &LearnedParameters{Alpha: 4}) // no watcher left to block or panic
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+
+	"github.com/llm-inferno/model-tuner/pkg/core"
+)
+
+// ErrNoParams is returned (wrapped) by Predict for a pair without tuned parameters.
+var ErrNoParams = errors.New("no parameters found")
+
+// OperatingPoint is a load at which Predict evaluates a pair.
+type OperatingPoint struct {
+	ArrivalRate  float32 `json:"arrivalRate"`  // offered load (requests/min)
+	AvgInTokens  int     `json:"avgInTokens"`  // average prompt length
+	AvgOutTokens int     `json:"avgOutTokens"` // average output length
+	MaxBatchSize int     `json:"maxBatchSize"` // server max batch size
+	MaxQueueSize int     `json:"maxQueueSize"` // external queue depth (0 = no external queue)
+}
+
+// Validate reports the first field out of range.
+func (p OperatingPoint) Validate() error {
+	switch {
+	case p.ArrivalRate <= 0:
+		return fmt.Errorf("arrivalRate must be positive")
+	case p.AvgInTokens < 0:
+		return fmt.Errorf("avgInTokens must not be negative")
+	case p.AvgOutTokens <= 0:
+		return fmt.Errorf("avgOutTokens must be positive")
+	case p.MaxBatchSize <= 0:
+		return fmt.Errorf("maxBatchSize must be positive")
+	case p.MaxQueueSize < 0:
+		return fmt.Errorf("maxQueueSize must not be negative")
+	}
+	return nil
+}
+
+// ValidateConfidence checks the confidence level of prediction intervals: 0 (none) or within
+// (0, 1).
+func ValidateConfidence(confidence float64) error {
+	if confidence < 0 || confidence >= 1 || math.IsNaN(confidence) {
+		return fmt.Errorf("confidence must be in (0, 1), or 0 for no intervals")
+	}
+	return nil
+}
+
+// Prediction is the queueing-model estimate at one OperatingPoint.
+type Prediction struct {
+	OperatingPoint
+	TTFT        float32 `json:"ttft"`        // average time to first token, queueing included (msec)
+	ITL         float32 `json:"itl"`         // average inter-token latency (msec)
+	WaitTime    float32 `json:"waitTime"`    // average queueing time (msec)
+	Concurrency float32 `json:"concurrency"` // average number of requests in service (batch size)
+	Utilization float32 `json:"utilization"` // concurrency over max batch size, in [0, 1]
+	Throughput  float32 `json:"throughput"`  // served load (requests/min); below arrivalRate when overloaded
+	MaxRate     float32 `json:"maxRate"`     // largest sustainable arrival rate (requests/min)
+	Overloaded  bool    `json:"overloaded"`  // part of the offered load is rejected
+
+	// Interval is set when intervals were requested and the parameters carry a covariance.
+	Interval *PredictionInterval `json:"interval,omitempty"`
+}
+
+// Predict evaluates the stored parameters of (model, accelerator) at each operating point,
+// through the same queueing model the tuner fits them with. It does not change tuner state.
+//
+// A confidence in (0, 1) adds a PredictionInterval to each prediction: the parameter covariance
+// (EKF, else fit-derived) is propagated through the queueing model by Monte Carlo, evaluating
+// PredictionSamples draws per point. The draws are seeded, so equal requests get equal intervals.
+// Parameters without a covariance (e.g. a fit to a single observation) yield no intervals.
+// Confidence 0 skips intervals.
+func (ts *TunerService) Predict(model, accelerator string, points []OperatingPoint, confidence float64) ([]Prediction, error) {
+	if err := ValidateConfidence(confidence); err != nil {
+		return nil, err
+	}
+	params := ts.published.Get(model, accelerator)
+	if params == nil {
+		return nil, fmt.Errorf("%w for model=%s accelerator=%s", ErrNoParams, model, accelerator)
+	}
+	var draws [][]float64
+	cov, source := params.predictionCovariance()
+	if confidence > 0 && cov != nil {
+		x := []float64{float64(params.Alpha), float64(params.Beta), float64(params.Gamma)}
+		draws = sampleParameters(x, cov, PredictionSamples, rand.New(rand.NewPCG(1, 2)))
+	}
+	predictions := make([]Prediction, len(points))
+	for i, p := range points {
+		if err := p.Validate(); err != nil {
+			return nil, fmt.Errorf("point %d: %w", i, err)
+		}
+		env := core.NewEnvironmentPrefillDecode(p.ArrivalRate, 0, 0, p.MaxBatchSize,
+			float32(p.AvgInTokens), float32(p.AvgOutTokens), 0, 0)
+		env.MaxQueueSize = p.MaxQueueSize
+		metrics, err := core.AnalyzePrefillDecode(env, params.Alpha, params.Beta, params.Gamma)
+		if err != nil {
+			return nil, fmt.Errorf("point %d: %w", i, err)
+		}
+		// the analyzer works in requests/sec
+		throughput := metrics.Throughput * 60
+		predictions[i] = Prediction{
+			OperatingPoint: p,
+			TTFT:           metrics.AvgTTFT,
+			ITL:            metrics.AvgTokenTime,
+			WaitTime:       metrics.AvgWaitTime,
+			Concurrency:    metrics.AvgNumInServ,
+			Utilization:    metrics.Rho,
+			Throughput:     throughput,
+			MaxRate:        metrics.MaxRate * 60,
+			Overloaded:     throughput < p.ArrivalRate*(1-overloadTolerance),
+		}
+		if draws != nil {
+			if interval := predictionInterval(env, draws, confidence); interval != nil {
+				interval.Source = source
+				predictions[i].Interval = interval
+			}
+		}
+	}
+	return predictions, nil
+}
+
+// overloadTolerance is the relative throughput shortfall below which a point counts as served in
+// full; the finite-queue model always blocks a sliver of the load.
+const overloadTolerance = 0.01
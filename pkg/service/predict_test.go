@@ -0,0 +1,128 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	optconfig "github.com/llm-inferno/optimizer-light/pkg/config"
+
+	"github.com/llm-inferno/model-tuner/pkg/core"
+)
+
+func TestPredict(t *testing.T) {
+	ts := NewTunerService(0, 1, false, false, DefaultWindowSize, DefaultResidualThreshold, 0)
+	light := OperatingPoint{ArrivalRate: 30, AvgInTokens: 512, AvgOutTokens: 128, MaxBatchSize: 64}
+	if _, err := ts.Predict("llama", "H100", []OperatingPoint{light}, 0); !errors.Is(err, ErrNoParams) {
+		t.Fatalf("Predict before tuning: %v, want ErrNoParams", err)
+	}
+
+	setParams(ts, "llama", "H100", &LearnedParameters{Alpha: 7.7, Beta: 0.067, Gamma: 5.5e-5, UpdateCount: 1})
+	heavy := light
+	heavy.ArrivalRate = 3000
+	heavy.AvgInTokens = 2048
+	got, err := ts.Predict("llama", "H100", []OperatingPoint{light, heavy}, 0)
+	if err != nil || len(got) != 2 {
+		t.Fatalf("Predict = (%v, %v), want two predictions", got, err)
+	}
+	if got[0].OperatingPoint != light || got[1].OperatingPoint != heavy || got[0].Interval != nil {
+		t.Error("predictions should echo their operating points in order")
+	}
+
+	// the same model the EKF observation function evaluates
+	env := core.NewEnvironmentPrefillDecode(30, 0, 0, 64, 512, 128, 0, 0)
+	metrics, err := core.AnalyzePrefillDecode(env, 7.7, 0.067, 5.5e-5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got[0].TTFT != metrics.AvgTTFT || got[0].ITL != metrics.AvgTokenTime || got[0].Overloaded {
+		t.Errorf("light load = %+v, want TTFT %g ITL %g without overload", got[0], metrics.AvgTTFT, metrics.AvgTokenTime)
+	}
+	if got[1].TTFT <= got[0].TTFT || got[1].Concurrency <= got[0].Concurrency || got[1].Utilization > 1 {
+		t.Errorf("heavy load = %+v, want higher TTFT and concurrency than %+v", got[1], got[0])
+	}
+
+	bad := light
+	bad.MaxBatchSize = 0
+	if _, err := ts.Predict("llama", "H100", []OperatingPoint{light, bad}, 0); err == nil {
+		t.Error("expected error for an operating point without max batch size")
+	}
+}
+
+func TestPredict_Intervals(t *testing.T) {
+	ts := NewTunerService(0, 1, false, false, DefaultWindowSize, DefaultResidualThreshold, 0)
+	point := OperatingPoint{ArrivalRate: 120, AvgInTokens: 1024, AvgOutTokens: 128, MaxBatchSize: 64}
+	params := &LearnedParameters{Alpha: 7.7, Beta: 0.067, Gamma: 5.5e-5, UpdateCount: 1}
+	setParams(ts, "llama", "H100", params)
+	if got, err := ts.Predict("llama", "H100", []OperatingPoint{point}, 0.9); err != nil || got[0].Interval != nil {
+		t.Fatalf("Predict without covariance = (%+v, %v), want no interval", got, err)
+	}
+	if _, err := ts.Predict("llama", "H100", []OperatingPoint{point}, 1); err == nil {
+		t.Error("expected error for confidence 1")
+	}
+
+	// 5% relative standard deviation on each parameter
+	sd := []float64{0.05 * 7.7, 0.05 * 0.067, 0.05 * 5.5e-5}
+	withFit := *params
+	withFit.FitCovariance = [][]float64{{sd[0] * sd[0], 0, 0}, {0, sd[1] * sd[1], 0}, {0, 0, sd[2] * sd[2]}}
+	setParams(ts, "llama", "H100", &withFit)
+	narrow, err := ts.Predict("llama", "H100", []OperatingPoint{point}, 0.5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wide, err := ts.Predict("llama", "H100", []OperatingPoint{point}, 0.95)
+	if err != nil {
+		t.Fatal(err)
+	}
+	n, w := narrow[0].Interval, wide[0].Interval
+	if n == nil || w == nil || n.Source != UncertaintyFit || w.Confidence != 0.95 {
+		t.Fatalf("intervals = %+v, %+v, want fit-derived intervals", n, w)
+	}
+	p := wide[0]
+	if !(w.TTFTLow < p.TTFT && p.TTFT < w.TTFTHigh && w.ITLLow < p.ITL && p.ITL < w.ITLHigh) {
+		t.Errorf("interval %+v does not bracket TTFT %g, ITL %g", w, p.TTFT, p.ITL)
+	}
+	if !(w.TTFTLow < n.TTFTLow && n.TTFTHigh < w.TTFTHigh) {
+		t.Errorf("95%% interval %+v should contain the 50%% interval %+v", w, n)
+	}
+	again, _ := ts.Predict("llama", "H100", []OperatingPoint{point}, 0.95)
+	if *again[0].Interval != *w {
+		t.Error("equal requests should get equal intervals")
+	}
+
+	// the EKF covariance takes precedence
+	withEKF := withFit
+	withEKF.Covariance = [][]float64{{4 * sd[0] * sd[0], 0, 0}, {0, 4 * sd[1] * sd[1], 0}, {0, 0, 4 * sd[2] * sd[2]}}
+	setParams(ts, "llama", "H100", &withEKF)
+	ekf, err := ts.Predict("llama", "H100", []OperatingPoint{point}, 0.95)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e := ekf[0].Interval; e == nil || e.Source != UncertaintyEKF || e.TTFTHigh-e.TTFTLow <= w.TTFTHigh-w.TTFTLow {
+		t.Errorf("EKF interval %+v, want wider than the fit interval %+v", e, w)
+	}
+}
+
+func TestPredict_IntervalsFromSlidingWindowFit(t *testing.T) {
+	t.Setenv("CONFIG_DATA_DIR", "../../config-data")
+	ts := NewTunerService(0, 3, false, true, 5, DefaultResidualThreshold, 0)
+	specs := []optconfig.ServerSpec{
+		makeTestSpec("llama", "H100", 12, 60, 7, 500, 400, 64),
+		makeTestSpec("llama", "H100", 15, 140, 8, 1500, 1000, 64),
+		makeTestSpec("llama", "H100", 18, 230, 10, 2500, 1600, 64),
+	}
+	for _, spec := range specs {
+		_, _ = ts.Tune([]optconfig.ServerSpec{spec})
+	}
+	params := ts.GetParams("llama", "H100")
+	if params == nil || params.FitCovariance == nil || params.Covariance != nil {
+		t.Fatalf("params = %+v, want a fit covariance and no EKF covariance", params)
+	}
+	point := OperatingPoint{ArrivalRate: 15, AvgInTokens: 1500, AvgOutTokens: 1000, MaxBatchSize: 64}
+	got, err := ts.Predict("llama", "H100", []OperatingPoint{point}, 0.9)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got[0].Interval == nil || got[0].Interval.Source != UncertaintyFit {
+		t.Errorf("prediction = %+v, want a fit-derived interval", got[0])
+	}
+}
@@ -0,0 +1,226 @@
+package service
+
+import (
+	"log/slog"
+	"maps"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+
+	"gonum.org/v1/gonum/mat"
+
+	"github.com/llm-inferno/model-tuner/pkg/config"
+)
+
+// Sources of a cold-start prior.
+const (
+	PriorConfig          = "config"           // the config initState
+	PriorCatalog         = "catalog"          // a parameter catalog entry (see Catalog)
+	PriorSameModel       = "same-model"       // the model on other accelerators, scaled by accelerator ratios
+	PriorSameAccelerator = "same-accelerator" // models of similar size on the accelerator
+)
+
+// SimilarSizeFactor is the largest ratio between two model sizes (parsed from the model names)
+// for which one model's parameters serve as the other's same-accelerator prior.
+const SimilarSizeFactor = 2.0
+
+// ColdStartPrior is the starting point of a newly seen (model, accelerator) pair: a matching
+// catalog entry, or a prior drawn from the pairs already tuned. Each donor yields one estimate of
+// the log-parameters; the prior is their geometric mean and its spread their standard deviation.
+type ColdStartPrior struct {
+	Seed         []float64 // [alpha, beta, gamma]
+	RelativeStd  []float64 // per parameter; nil keeps the config percentChange
+	MinState     []float64 // EKF state bounds; nil derives them from the state
+	MaxState     []float64
+	Source       string   // PriorConfig, PriorCatalog, PriorSameModel or PriorSameAccelerator
+	CatalogEntry string   // the matching entry's name, for PriorCatalog
+	Donors       []string // the pairs drawn on, "model/accelerator"
+}
+
+// SetTransferPrior enables cross-pair cold-start priors for pairs seen thereafter: a new pair is
+// seeded from the same model on other accelerators, scaled by the accelerator ratios learned from
+// models tuned on both, or failing that from models of similar size on the same accelerator.
+// Without donors, or when disabled (the default), pairs start from the config initState.
+func (ts *TunerService) SetTransferPrior(enabled bool) {
+	ts.replayMu.Lock()
+	defer ts.replayMu.Unlock()
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	ts.transferPrior = enabled
+	for _, shadow := range ts.shadows {
+		shadow.SetTransferPrior(enabled)
+	}
+}
+
+// Prior returns the cold-start prior a pair started from, or nil if the pair has not been seen.
+func (ts *TunerService) Prior(model, accelerator string) *ColdStartPrior {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return ts.priors[makeKey(model, accelerator)]
+}
+
+// priorFor returns the cold-start prior of the pair key, drawing it on first use. A catalog
+// entry takes precedence over a transferred prior, which takes precedence over the config seed.
+func (ts *TunerService) priorFor(key string) *ColdStartPrior {
+	if prior, ok := ts.priors[key]; ok {
+		return prior
+	}
+	prior := &ColdStartPrior{Seed: ts.coldStartSeed(), Source: PriorConfig}
+	model, accelerator := splitKey(key)
+	if fromCatalog := ts.catalogPrior(model, accelerator); fromCatalog != nil {
+		prior = fromCatalog
+		slog.Info("cold-start prior from catalog", "key", key, "entry", prior.CatalogEntry,
+			"alpha", prior.Seed[0], "beta", prior.Seed[1], "gamma", prior.Seed[2])
+	} else if ts.transferPrior {
+		if transferred := ts.transferredPrior(model, accelerator); transferred != nil {
+			prior = transferred
+			slog.Info("cold-start prior from tuned pairs", "key", key, "source", prior.Source,
+				"alpha", prior.Seed[0], "beta", prior.Seed[1], "gamma", prior.Seed[2], "donors", prior.Donors)
+		}
+	}
+	ts.priors[key] = prior
+	return prior
+}
+
+// transferredPrior draws a prior for (model, accelerator) from the tuned pairs, or returns nil if
+// no pair qualifies. Estimates from the same model take precedence over those from other models.
+func (ts *TunerService) transferredPrior(model, accelerator string) *ColdStartPrior {
+	logs := make(map[string]map[string][]float64) // model -> accelerator -> log-parameters
+	for key, params := range ts.paramStore.GetAll() {
+		m, a := splitKey(key)
+		if logs[m] == nil {
+			logs[m] = make(map[string][]float64)
+		}
+		logs[m][a] = []float64{
+			math.Log(float64(params.Alpha)), math.Log(float64(params.Beta)), math.Log(float64(params.Gamma)),
+		}
+	}
+
+	// Same model on accelerator b, times the b -> accelerator ratio of every model tuned on both.
+	var samples [][]float64
+	var donors []string
+	models := slices.Sorted(maps.Keys(logs))
+	for _, b := range slices.Sorted(maps.Keys(logs[model])) {
+		own := logs[model][b]
+		if b == accelerator {
+			continue
+		}
+		for _, m := range models {
+			accs := logs[m]
+			onTarget, onB := accs[accelerator], accs[b]
+			if m == model || onTarget == nil || onB == nil {
+				continue
+			}
+			sample := make([]float64, len(own))
+			for i := range own {
+				sample[i] = own[i] + onTarget[i] - onB[i]
+			}
+			samples = append(samples, sample)
+			donors = append(donors, makeKey(model, b), makeKey(m, accelerator), makeKey(m, b))
+		}
+	}
+	if len(samples) > 0 {
+		return ts.newPrior(PriorSameModel, samples, donors)
+	}
+
+	// Models of similar size on the same accelerator.
+	size := modelSize(model)
+	if size <= 0 {
+		return nil
+	}
+	for _, m := range models {
+		accs := logs[m]
+		other := modelSize(m)
+		if m == model || accs[accelerator] == nil || other <= 0 || max(size, other)/min(size, other) > SimilarSizeFactor {
+			continue
+		}
+		samples = append(samples, accs[accelerator])
+		donors = append(donors, makeKey(m, accelerator))
+	}
+	if len(samples) > 0 {
+		return ts.newPrior(PriorSameAccelerator, samples, donors)
+	}
+	return nil
+}
+
+// newPrior combines log-parameter samples into a prior. The relative spread is never tighter
+// than the config percentChange, the EKF's own initial uncertainty.
+func (ts *TunerService) newPrior(source string, samples [][]float64, donors []string) *ColdStartPrior {
+	slices.Sort(donors)
+	prior := &ColdStartPrior{Source: source, Donors: slices.Compact(donors)}
+	floor := ts.coldStartPercentChange()
+	for i := range samples[0] {
+		var sum, sumSq float64
+		for _, s := range samples {
+			sum += s[i]
+			sumSq += s[i] * s[i]
+		}
+		n := float64(len(samples))
+		mean := sum / n
+		std := math.Sqrt(max(sumSq/n-mean*mean, 0))
+		if i < len(floor) {
+			std = max(std, floor[i])
+		}
+		prior.Seed = append(prior.Seed, math.Exp(mean))
+		prior.RelativeStd = append(prior.RelativeStd, std)
+	}
+	return prior
+}
+
+// coldStartPercentChange returns the config percentChange, loaded with the cold-start seed.
+func (ts *TunerService) coldStartPercentChange() []float64 {
+	ts.coldStartSeed()
+	return ts.coldPercentChange
+}
+
+// covarianceAt returns the EKF initial covariance of a transferred prior around state x: the
+// prior's relative spread applied to x. nil for the config prior, which keeps the config's.
+func (p *ColdStartPrior) covarianceAt(x []float64) *mat.Dense {
+	if p == nil || len(p.RelativeStd) != len(x) {
+		return nil
+	}
+	variances := make([]float64, len(x))
+	for i, v := range x {
+		variances[i] = math.Pow(p.RelativeStd[i]*v, 2)
+	}
+	return mat.DenseCopyOf(mat.NewDiagDense(len(x), variances))
+}
+
+// bound replaces the state bounds of md with the prior's, if it has any, clamping the initial
+// state into them.
+func (p *ColdStartPrior) bound(md *config.ModelData) {
+	if p == nil || len(p.MinState) != len(md.InitState) || len(p.MaxState) != len(md.InitState) {
+		return
+	}
+	md.MinState = slices.Clone(p.MinState)
+	md.MaxState = slices.Clone(p.MaxState)
+	initState := make([]float64, len(md.InitState))
+	for i, v := range md.InitState {
+		initState[i] = min(max(v, p.MinState[i]), p.MaxState[i])
+	}
+	md.InitState = initState
+}
+
+// modelSizePattern matches a parameter count in billions in a model name: "70b", "8B", "1.5b"
+// or "8x7b".
+var modelSizePattern = regexp.MustCompile(`(?i)(?:(\d+)x)?(\d+(?:\.\d+)?)b(?:[^a-z0-9]|$)`)
+
+// modelSize returns the parameter count, in billions, named by the last size token of a model
+// name, or 0 if the name carries none.
+func modelSize(model string) float64 {
+	matches := modelSizePattern.FindAllStringSubmatch(model, -1)
+	if len(matches) == 0 {
+		return 0
+	}
+	last := matches[len(matches)-1]
+	size, err := strconv.ParseFloat(last[2], 64)
+	if err != nil {
+		return 0
+	}
+	if last[1] != "" {
+		experts, _ := strconv.ParseFloat(last[1], 64)
+		size *= experts
+	}
+	return size
+}
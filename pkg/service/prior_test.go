@@ -0,0 +1,133 @@
+package service
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestModelSize(t *testing.T) {
+	for name, want := range map[string]float64{
+		"llama3-8b":                         8,
+		"meta-llama/Llama-3.1-70B-Instruct": 70,
+		"qwen2.5-1.5b":                      1.5,
+		"mixtral-8x7b":                      56,
+		"granite-8b-code":                   8,
+		"llama":                             0,
+		"gpt-oss":                           0,
+	} {
+		if got := modelSize(name); got != want {
+			t.Errorf("modelSize(%q) = %g, want %g", name, got, want)
+		}
+	}
+}
+
+func approxSlice(got, want []float64, tol float64) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range got {
+		if math.Abs(got[i]-want[i]) > tol*math.Abs(want[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestPriorFor(t *testing.T) {
+	t.Setenv("CONFIG_DATA_DIR", "../../config-data")
+	ts := NewTunerService(0, 1, false, false, DefaultWindowSize, DefaultResidualThreshold, 0)
+	ts.paramStore.Set("granite-8b", "A100", &LearnedParameters{Alpha: 8, Beta: 0.08, Gamma: 8e-5})
+	ts.paramStore.Set("granite-8b", "H100", &LearnedParameters{Alpha: 4, Beta: 0.04, Gamma: 4e-5})
+	ts.paramStore.Set("qwen-14b", "A100", &LearnedParameters{Alpha: 16, Beta: 0.1, Gamma: 1e-4})
+	ts.paramStore.Set("qwen-14b", "H100", &LearnedParameters{Alpha: 8, Beta: 0.05, Gamma: 5e-5})
+	ts.paramStore.Set("llama-70b", "A100", &LearnedParameters{Alpha: 20, Beta: 0.2, Gamma: 2e-4})
+
+	if prior := ts.priorFor("llama-70b/H100"); prior.Source != PriorConfig || prior.RelativeStd != nil {
+		t.Errorf("prior while disabled = %+v, want the config seed", prior)
+	}
+	ts.SetTransferPrior(true)
+
+	if prior := ts.priorFor("llama-70b/L40S"); prior.Source != PriorConfig {
+		t.Errorf("prior without donors = %+v, want the config seed", prior)
+	}
+
+	// both models halve every parameter on H100
+	delete(ts.priors, "llama-70b/H100")
+	prior := ts.priorFor("llama-70b/H100")
+	if prior.Source != PriorSameModel || !approxSlice(prior.Seed, []float64{10, 0.1, 1e-4}, 1e-6) {
+		t.Errorf("same-model prior = %+v, want half of llama-70b on A100", prior)
+	}
+	floor := ts.coldStartPercentChange()
+	if !approxSlice(prior.RelativeStd, floor, 1e-9) {
+		t.Errorf("relative std = %v, want the config percentChange for agreeing donors", prior.RelativeStd)
+	}
+	if want := []string{"granite-8b/A100", "granite-8b/H100", "llama-70b/A100", "qwen-14b/A100", "qwen-14b/H100"}; !slices.Equal(prior.Donors, want) {
+		t.Errorf("donors = %v, want %v", prior.Donors, want)
+	}
+
+	// granite-8b and qwen-14b are within a factor two of 10b, llama-70b is not
+	prior = ts.priorFor("mistral-10b/A100")
+	if prior.Source != PriorSameAccelerator || !approxSlice(prior.Seed, []float64{math.Sqrt(128), math.Sqrt(0.008), math.Sqrt(8e-9)}, 1e-6) {
+		t.Errorf("same-accelerator prior = %+v, want the geometric mean of granite and qwen", prior)
+	}
+	if want := []float64{math.Log(2) / 2, floor[1], floor[2]}; !approxSlice(prior.RelativeStd, want, 1e-9) {
+		t.Errorf("relative std = %v, want the donors' log spread %v, no tighter than percentChange", prior.RelativeStd, want)
+	}
+	if prior := ts.priorFor("phi-2b/A100"); prior.Source != PriorConfig {
+		t.Errorf("prior = %+v, want no donor of similar size", prior)
+	}
+
+	cov := ts.priors["llama-70b/H100"].covarianceAt([]float64{10, 0.1, 1e-4})
+	if cov == nil || math.Abs(cov.At(0, 0)-math.Pow(10*floor[0], 2)) > 1e-9 || cov.At(0, 1) != 0 {
+		t.Errorf("covariance = %v, want diag((percentChange x)^2)", cov)
+	}
+	if (&ColdStartPrior{Source: PriorConfig}).covarianceAt([]float64{1, 1, 1}) != nil {
+		t.Error("config prior should keep the config covariance")
+	}
+}
+
+func TestTune_TransferPrior(t *testing.T) {
+	t.Setenv("CONFIG_DATA_DIR", "../../config-data")
+	ts := NewTunerService(0, 1, false, false, DefaultWindowSize, DefaultResidualThreshold, 0)
+	ts.SetTransferPrior(true)
+	ts.paramStore.Set("granite-8b", "A100", &LearnedParameters{Alpha: 8, Beta: 0.08, Gamma: 8e-5})
+	ts.paramStore.Set("granite-8b", "H100", &LearnedParameters{Alpha: 4, Beta: 0.04, Gamma: 4e-5})
+	ts.paramStore.Set("llama", "A100", &LearnedParameters{Alpha: 15.4, Beta: 0.134, Gamma: 1.1e-4})
+
+	specs := shadowTestSpecs(t, 1)[0]
+	if _, outcomes, err := ts.TuneWithOutcomes(specs); err != nil || !outcomes[0].Fresh {
+		t.Fatalf("Tune = (%+v, %v), want a fresh EKF update", outcomes, err)
+	}
+	if prior := ts.priors["llama/H100"]; prior == nil || prior.Source != PriorSameModel ||
+		!approxSlice(prior.Seed, []float64{7.7, 0.067, 5.5e-5}, 1e-6) {
+		t.Errorf("prior = %+v, want llama on A100 halved", prior)
+	}
+	if params := ts.GetRawParams("llama", "H100"); len(params.Covariance) != 3 {
+		t.Errorf("params = %+v, want an EKF covariance", params)
+	}
+}
+
+func TestTune_TransferPriorFromSameRequest(t *testing.T) {
+	t.Setenv("CONFIG_DATA_DIR", "../../config-data")
+	for range 10 {
+		ts := NewTunerService(0, 1, false, false, DefaultWindowSize, DefaultResidualThreshold, 0)
+		ts.SetTransferPrior(true)
+		ts.paramStore.Set("granite-8b", "A100", &LearnedParameters{Alpha: 8, Beta: 0.08, Gamma: 8e-5})
+		ts.paramStore.Set("granite-8b", "H100", &LearnedParameters{Alpha: 4, Beta: 0.04, Gamma: 4e-5})
+		ts.paramStore.Set("llama", "A100", &LearnedParameters{Alpha: 15.4, Beta: 0.134, Gamma: 1.1e-4})
+
+		// the donor llama/A100 is tuned in the same request as the new pair llama/H100
+		specs := shadowTestSpecs(t, 1)[0]
+		donor := specs[0]
+		donor.CurrentAlloc.Accelerator = "A100"
+		if _, err := ts.Tune(append(specs, donor)); err != nil {
+			t.Fatal(err)
+		}
+		raw := ts.GetRawParams("llama", "A100")
+		want := []float64{float64(raw.Alpha) / 2, float64(raw.Beta) / 2, float64(raw.Gamma) / 2}
+		if prior := ts.Prior("llama", "H100"); prior == nil || !approxSlice(prior.Seed, want, 1e-6) {
+			t.Fatalf("prior = %+v, want the donor's params of this cycle halved %v", prior, want)
+		}
+	}
+}
@@ -0,0 +1,170 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"slices"
+)
+
+// Smoothing methods of a PublicationPolicy.
+const (
+	SmoothingNone   = "none"   // publish each fit as is
+	SmoothingEMA    = "ema"    // exponential moving average of the fits
+	SmoothingMedian = "median" // per-parameter median of the last MedianWindow fits
+)
+
+// PublicationPolicy shapes the parameters served to readers (Merge, GetParams, WatchParams, the
+// ModelData of Tune, Predict and Capacity) from the estimator's raw fits. A sliding-window refit
+// or an EKF excursion can move alpha or gamma by a large factor in one cycle; smoothing and a
+// per-cycle change limit keep the optimizer from reshuffling replicas on what may be noise. The
+// estimators themselves always continue from their raw fits.
+type PublicationPolicy struct {
+	Smoothing    string  // SmoothingNone, SmoothingEMA or SmoothingMedian
+	EMAWeight    float64 // (EMA) weight of the newest fit, in (0, 1]
+	MedianWindow int     // (median) fits in the median, >= 1
+	// MaxRelativeChange limits each published parameter to a factor of 1 + MaxRelativeChange of
+	// its previous published value per cycle: 0.2 allows x1.2 up or /1.2 down. 0 disables it.
+	MaxRelativeChange float64
+}
+
+// DefaultPublicationPolicy publishes every fit as is.
+var DefaultPublicationPolicy = PublicationPolicy{
+	Smoothing:    SmoothingNone,
+	EMAWeight:    DefaultPublishEMAWeight,
+	MedianWindow: DefaultPublishMedianWindow,
+
+	MaxRelativeChange: DefaultPublishMaxChange,
+}
+
+// Validate checks the policy's method and bounds.
+func (p PublicationPolicy) Validate() error {
+	switch p.Smoothing {
+	case SmoothingNone:
+	case SmoothingEMA:
+		if !(p.EMAWeight > 0 && p.EMAWeight <= 1) {
+			return fmt.Errorf("EMA weight %g outside (0, 1]", p.EMAWeight)
+		}
+	case SmoothingMedian:
+		if p.MedianWindow < 1 {
+			return fmt.Errorf("median window %d < 1", p.MedianWindow)
+		}
+	default:
+		return fmt.Errorf("unknown smoothing %q (want %s, %s or %s)", p.Smoothing, SmoothingNone, SmoothingEMA, SmoothingMedian)
+	}
+	if p.MaxRelativeChange < 0 || math.IsNaN(p.MaxRelativeChange) {
+		return errors.New("max relative change must be non-negative")
+	}
+	return nil
+}
+
+// SetPublicationPolicy sets how raw fits are published from the next tune cycle on. Parameters
+// published so far are kept as the base of the next smoothing and change limit.
+func (ts *TunerService) SetPublicationPolicy(p PublicationPolicy) error {
+	if err := p.Validate(); err != nil {
+		return err
+	}
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	ts.publication = p
+	return nil
+}
+
+// GetRawParams returns the estimator's own most recent fit for a model/accelerator pair, before
+// publication, or nil if the pair has not been tuned yet.
+func (ts *TunerService) GetRawParams(model, accelerator string) *LearnedParameters {
+	return ts.paramStore.Get(model, accelerator)
+}
+
+// publish derives the pair's published parameters from its raw fit just stored: the fit is
+// smoothed with the earlier fits, then held within MaxRelativeChange of the previous publication.
+// The first fit of a pair is published as is.
+func (ts *TunerService) publish(model, accelerator string) {
+	raw := ts.paramStore.Get(model, accelerator)
+	if raw == nil {
+		return
+	}
+	key := makeKey(model, accelerator)
+	fit := []float64{float64(raw.Alpha), float64(raw.Beta), float64(raw.Gamma)}
+	keep := 1
+	if ts.publication.Smoothing == SmoothingMedian {
+		keep = ts.publication.MedianWindow
+	}
+	fits := append(ts.recentFits[key], fit)
+	ts.recentFits[key] = fits[max(len(fits)-keep, 0):]
+
+	previous := ts.published.Get(model, accelerator)
+	if previous == nil {
+		ts.published.Set(model, accelerator, publishedCopy(raw, fit))
+		return
+	}
+	last := []float64{float64(previous.Alpha), float64(previous.Beta), float64(previous.Gamma)}
+	values := make([]float64, len(fit))
+	for i := range fit {
+		switch ts.publication.Smoothing {
+		case SmoothingEMA:
+			values[i] = last[i] + ts.publication.EMAWeight*(fit[i]-last[i])
+		case SmoothingMedian:
+			values[i] = median(ts.recentFits[key], i)
+		default:
+			values[i] = fit[i]
+		}
+		if m := ts.publication.MaxRelativeChange; m > 0 {
+			values[i] = min(max(values[i], last[i]/(1+m)), last[i]*(1+m))
+		}
+	}
+	if values[0] != fit[0] || values[1] != fit[1] || values[2] != fit[2] {
+		slog.Debug("published smoothed parameters", "model", model, "accelerator", accelerator,
+			"alpha", values[0], "beta", values[1], "gamma", values[2],
+			"rawAlpha", fit[0], "rawBeta", fit[1], "rawGamma", fit[2])
+	}
+	ts.published.Set(model, accelerator, publishedCopy(raw, values))
+}
+
+// publishNow publishes the pair's raw fit as is and restarts its smoothing from it. A calibration
+// sweep is deliberate and replaces what natural load taught, so it is neither smoothed nor limited.
+func (ts *TunerService) publishNow(model, accelerator string) {
+	raw := ts.paramStore.Get(model, accelerator)
+	if raw == nil {
+		return
+	}
+	fit := []float64{float64(raw.Alpha), float64(raw.Beta), float64(raw.Gamma)}
+	ts.recentFits[makeKey(model, accelerator)] = [][]float64{fit}
+	ts.published.Set(model, accelerator, publishedCopy(raw, fit))
+}
+
+// republish replaces every publication with the raw fits as is, as after a change of estimator.
+func (ts *TunerService) republish() {
+	raw := ts.paramStore.GetAll()
+	published := make(map[string]*LearnedParameters, len(raw))
+	ts.recentFits = make(map[string][][]float64, len(raw))
+	for key, params := range raw {
+		fit := []float64{float64(params.Alpha), float64(params.Beta), float64(params.Gamma)}
+		ts.recentFits[key] = [][]float64{fit}
+		published[key] = publishedCopy(params, fit)
+	}
+	ts.published.Replace(published)
+}
+
+// publishedCopy returns a copy of raw carrying values as its parameters and raw's own as Raw*.
+func publishedCopy(raw *LearnedParameters, values []float64) *LearnedParameters {
+	p := *raw
+	p.Alpha, p.Beta, p.Gamma = float32(values[0]), float32(values[1]), float32(values[2])
+	p.RawAlpha, p.RawBeta, p.RawGamma = raw.Alpha, raw.Beta, raw.Gamma
+	return &p
+}
+
+// median returns the median of component i of fits.
+func median(fits [][]float64, i int) float64 {
+	values := make([]float64, len(fits))
+	for j, fit := range fits {
+		values[j] = fit[i]
+	}
+	slices.Sort(values)
+	n := len(values)
+	if n%2 == 1 {
+		return values[n/2]
+	}
+	return (values[n/2-1] + values[n/2]) / 2
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"math"
+	"testing"
+)
+
+// setParams stores params as the pair's raw fit and publishes them as is, as a calibration does.
+func setParams(ts *TunerService, model, accelerator string, params *LearnedParameters) {
+	ts.paramStore.Set(model, accelerator, params)
+	ts.publishNow(model, accelerator)
+}
+
+// publishFits stores and publishes each alpha in turn as one cycle's raw fit, returning the
+// published alphas.
+func publishFits(ts *TunerService, alphas ...float32) []float32 {
+	out := make([]float32, len(alphas))
+	for i, alpha := range alphas {
+		ts.paramStore.Set("llama", "H100", &LearnedParameters{Alpha: alpha, Beta: 0.067, Gamma: 5.5e-5, UpdateCount: i + 1})
+		ts.publish("llama", "H100")
+		out[i] = ts.GetParams("llama", "H100").Alpha
+	}
+	return out
+}
+
+func TestPublicationPolicy_Validate(t *testing.T) {
+	if err := DefaultPublicationPolicy.Validate(); err != nil {
+		t.Errorf("default policy: %v", err)
+	}
+	for _, bad := range []PublicationPolicy{
+		{Smoothing: "mean"},
+		{Smoothing: SmoothingEMA, EMAWeight: 0},
+		{Smoothing: SmoothingEMA, EMAWeight: 1.5},
+		{Smoothing: SmoothingMedian, MedianWindow: 0},
+		{Smoothing: SmoothingNone, MaxRelativeChange: -0.1},
+	} {
+		if err := bad.Validate(); err == nil {
+			t.Errorf("expected error for %+v", bad)
+		}
+	}
+}
+
+func TestPublish(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy PublicationPolicy
+		want   []float32
+	}{
+		{"none", DefaultPublicationPolicy, []float32{8, 16, 8, 8.5}},
+		{"ema", PublicationPolicy{Smoothing: SmoothingEMA, EMAWeight: 0.5}, []float32{8, 12, 10, 9.25}},
+		{"median", PublicationPolicy{Smoothing: SmoothingMedian, MedianWindow: 3}, []float32{8, 12, 8, 8.5}},
+		{"limit", PublicationPolicy{Smoothing: SmoothingNone, MaxRelativeChange: 0.25}, []float32{8, 10, 8, 8.5}},
+		{"median and limit", PublicationPolicy{Smoothing: SmoothingMedian, MedianWindow: 3, MaxRelativeChange: 0.25}, []float32{8, 10, 8, 8.5}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := NewTunerService(0, 1, false, false, DefaultWindowSize, DefaultResidualThreshold, 0)
+			if err := ts.SetPublicationPolicy(tt.policy); err != nil {
+				t.Fatal(err)
+			}
+			got := publishFits(ts, 8, 16, 8, 8.5)
+			for i := range got {
+				if math.Abs(float64(got[i]-tt.want[i])) > 1e-5 {
+					t.Fatalf("published alphas %v, want %v", got, tt.want)
+				}
+			}
+			published := ts.GetParams("llama", "H100")
+			if published.RawAlpha != 8.5 || published.UpdateCount != 4 || ts.GetRawParams("llama", "H100").Alpha != 8.5 {
+				t.Errorf("published %+v, want the raw fit alongside", published)
+			}
+		})
+	}
+}
+
+func TestPublish_ServedByReaders(t *testing.T) {
+	ts := NewTunerService(0, 1, false, false, DefaultWindowSize, DefaultResidualThreshold, 0)
+	if err := ts.SetPublicationPolicy(PublicationPolicy{Smoothing: SmoothingNone, MaxRelativeChange: 0.1}); err != nil {
+		t.Fatal(err)
+	}
+	_, updates, cancel := ts.WatchParams(4)
+	defer cancel()
+	publishFits(ts, 10, 20)
+
+	if u := <-updates; u.Params.Alpha != 10 {
+		t.Errorf("first update alpha %g, want 10", u.Params.Alpha)
+	}
+	if u := <-updates; u.Params.Alpha != 11 || u.Params.RawAlpha != 20 {
+		t.Errorf("second update %+v, want alpha 11 from raw 20", u.Params)
+	}
+	merged := ts.Merge(nil)
+	if len(merged.PerfData) != 1 || merged.PerfData[0].PerfParms.Alpha != 11 {
+		t.Errorf("Merge = %+v, want the published alpha", merged.PerfData)
+	}
+
+	// a calibration publishes its fit as is
+	setParams(ts, "llama", "H100", &LearnedParameters{Alpha: 30, Beta: 0.067, Gamma: 5.5e-5})
+	if got := ts.GetParams("llama", "H100").Alpha; got != 30 {
+		t.Errorf("alpha after calibration %g, want 30", got)
+	}
+}
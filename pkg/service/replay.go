@@ -0,0 +1,127 @@
+package service
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	optconfig "github.com/llm-inferno/optimizer-light/pkg/config"
+)
+
+// Endpoint names recorded in a RecordedRequest.
+const (
+	RecordEndpointTune      = "tune"
+	RecordEndpointCalibrate = "calibrate"
+)
+
+// RecordedRequest is one /tune or /calibrate request body captured by the tunerservice traffic
+// recorder. A recording is a JSONL file with one RecordedRequest per line, in arrival order.
+type RecordedRequest struct {
+	Timestamp time.Time              `json:"timestamp"`
+	Endpoint  string                 `json:"endpoint"`
+	Specs     []optconfig.ServerSpec `json:"specs"`
+}
+
+// TrajectoryPoint is the stored parameter state of one (model, accelerator) pair after a replayed
+// request. Err carries the per-request error (e.g. still collecting init observations), in which
+// case the parameters are those left in the store by an earlier step, if any.
+type TrajectoryPoint struct {
+	Step        int       `json:"step"`
+	Timestamp   time.Time `json:"timestamp"`
+	Endpoint    string    `json:"endpoint"`
+	Model       string    `json:"model"`
+	Accelerator string    `json:"accelerator"`
+	Alpha       float32   `json:"alpha"`
+	Beta        float32   `json:"beta"`
+	Gamma       float32   `json:"gamma"`
+	UpdateCount int       `json:"updateCount"`
+	Err         string    `json:"err,omitempty"`
+}
+
+// ReadRecording parses a JSONL recording. Blank lines are skipped; a malformed line is reported
+// with its 1-based line number.
+func ReadRecording(r io.Reader) ([]RecordedRequest, error) {
+	var records []RecordedRequest
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		var rec RecordedRequest
+		if err := json.Unmarshal([]byte(text), &rec); err != nil {
+			return nil, fmt.Errorf("recording line %d: %w", line, err)
+		}
+		records = append(records, rec)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read recording: %w", err)
+	}
+	return records, nil
+}
+
+// Replay feeds recorded requests, in order, through ts (normally a freshly constructed
+// TunerService with the same configuration as the recorded one) and returns the resulting
+// parameter trajectory: one point per (model, accelerator) group per request, in step order and
+// sorted by key within a step. Per-request tuning errors are part of the trajectory, not fatal;
+// an unknown endpoint is. If w is non-nil each point is also printed to it as a table row.
+// Replay is deterministic: the estimators carry no randomness, and the groups of a request are
+// tuned in key order, so a transferred prior sees the same donor parameters on every run.
+func Replay(ts *TunerService, records []RecordedRequest, w io.Writer) ([]TrajectoryPoint, error) {
+	if w != nil {
+		_, _ = fmt.Fprintf(w, "%-5s %-9s %-40s %12s %12s %12s %6s %s\n",
+			"step", "endpoint", "model/accelerator", "alpha", "beta", "gamma", "count", "err")
+	}
+	var trajectory []TrajectoryPoint
+	for step, rec := range records {
+		var err error
+		switch rec.Endpoint {
+		case RecordEndpointTune:
+			_, err = ts.Tune(rec.Specs)
+		case RecordEndpointCalibrate:
+			_, err = ts.Calibrate(rec.Specs)
+		default:
+			return trajectory, fmt.Errorf("recording step %d: unknown endpoint %q", step, rec.Endpoint)
+		}
+
+		var keys []string
+		for key := range groupByModelAccelerator(rec.Specs) {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			model, accelerator := splitKey(key)
+			point := TrajectoryPoint{
+				Step:        step,
+				Timestamp:   rec.Timestamp,
+				Endpoint:    rec.Endpoint,
+				Model:       model,
+				Accelerator: accelerator,
+			}
+			if params := ts.GetParams(model, accelerator); params != nil {
+				point.Alpha = params.Alpha
+				point.Beta = params.Beta
+				point.Gamma = params.Gamma
+				point.UpdateCount = params.UpdateCount
+			}
+			if err != nil {
+				point.Err = err.Error()
+			}
+			trajectory = append(trajectory, point)
+			if w != nil {
+				_, _ = fmt.Fprintf(w, "%-5d %-9s %-40s %12.6g %12.6g %12.6g %6d %s\n",
+					point.Step, point.Endpoint, key, point.Alpha, point.Beta, point.Gamma,
+					point.UpdateCount, point.Err)
+			}
+		}
+	}
+	return trajectory, nil
+}
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	optconfig "github.com/llm-inferno/optimizer-light/pkg/config"
+)
+
+func makeTestRecording(t *testing.T) []byte {
+	t.Helper()
+	specs := []optconfig.ServerSpec{
+		makeTestSpec("llama", "H100", 15, 55, 6, 120, 700, 64),
+		makeTestSpec("llama", "H100", 30, 120, 12, 200, 1500, 64),
+		makeTestSpec("llama", "H100", 20, 70, 8, 150, 900, 64),
+		makeTestSpec("llama", "H100", 25, 90, 10, 180, 1100, 64),
+	}
+	var buf bytes.Buffer
+	start := time.Date(2026, 4, 1, 12, 0, 0, 0, time.UTC)
+	for i, spec := range specs {
+		line, err := json.Marshal(RecordedRequest{
+			Timestamp: start.Add(time.Duration(i) * time.Minute),
+			Endpoint:  RecordEndpointTune,
+			Specs:     []optconfig.ServerSpec{spec},
+		})
+		if err != nil {
+			t.Fatalf("marshal: %v", err)
+		}
+		buf.Write(line)
+		buf.WriteString("\n\n") // blank lines are tolerated
+	}
+	return buf.Bytes()
+}
+
+func TestReplay_DeterministicTrajectory(t *testing.T) {
+	records, err := ReadRecording(bytes.NewReader(makeTestRecording(t)))
+	if err != nil {
+		t.Fatalf("ReadRecording: %v", err)
+	}
+	if len(records) != 4 {
+		t.Fatalf("expected 4 records, got %d", len(records))
+	}
+
+	var out bytes.Buffer
+	first, err := Replay(NewTunerService(0, 2, false, true, 5, DefaultResidualThreshold, 0), records, &out)
+	if err != nil {
+		t.Fatalf("Replay: %v", err)
+	}
+	second, err := Replay(NewTunerService(0, 2, false, true, 5, DefaultResidualThreshold, 0), records, nil)
+	if err != nil {
+		t.Fatalf("Replay: %v", err)
+	}
+
+	if len(first) != 4 || len(second) != 4 {
+		t.Fatalf("expected one point per request, got %d and %d", len(first), len(second))
+	}
+	for i := range first {
+		if first[i] != second[i] {
+			t.Errorf("step %d differs between replays: %+v vs %+v", i, first[i], second[i])
+		}
+	}
+	// Step 0 is still collecting init observations; from step 1 parameters are stored.
+	if first[0].Err == "" || first[0].UpdateCount != 0 {
+		t.Errorf("step 0: expected collection error and no params, got %+v", first[0])
+	}
+	if last := first[3]; last.Err != "" || last.Alpha <= 0 || last.UpdateCount == 0 {
+		t.Errorf("step 3: expected tuned params, got %+v", last)
+	}
+	if !strings.Contains(out.String(), "llama/H100") {
+		t.Errorf("expected trajectory table to name the pair, got:\n%s", out.String())
+	}
+}
+
+func TestReadRecording_ReportsLineNumber(t *testing.T) {
+	_, err := ReadRecording(strings.NewReader("{\"endpoint\":\"tune\",\"specs\":[]}\nnot json\n"))
+	if err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Fatalf("expected error naming line 2, got %v", err)
+	}
+}
+
+func TestReplay_UnknownEndpoint(t *testing.T) {
+	ts := NewTunerService(0, 2, false, false, DefaultWindowSize, DefaultResidualThreshold, 0)
+	if _, err := Replay(ts, []RecordedRequest{{Endpoint: "merge"}}, nil); err == nil {
+		t.Fatal("expected error for unknown endpoint")
+	}
+}
@@ -0,0 +1,247 @@
+package service
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	estimator "github.com/llm-inferno/model-tuner/pkg/estimator"
+)
+
+// PairState is everything the primary estimator holds for one (model, accelerator) pair:
+// parameters, estimator windows and calibration state. Replicated to a standby instance, it lets
+// the standby continue the pair where this instance is. Version orders the states of a pair
+// across instances: it counts the changes of the state, and an instance applying a state takes
+// its version over, so a state is applied only over one with a lower version. Instances' clocks
+// are not compared, except UpdatedAt to order two states of equal version, which arise when two
+// instances change the pair from the same state.
+type PairState struct {
+	Model        string              `json:"model"`
+	Accelerator  string              `json:"accelerator"`
+	Version      int                 `json:"version"`
+	UpdatedAt    time.Time           `json:"updatedAt"`
+	LastObserved time.Time           `json:"lastObserved,omitzero"` // the last tune or calibration cycle
+	Raw          *LearnedParameters  `json:"raw,omitempty"`         // the estimator's own fit
+	Published    *LearnedParameters  `json:"published,omitempty"`   // what readers are served
+	RecentFits   [][]float64         `json:"recentFits,omitempty"`
+	Init         *estimator.Snapshot `json:"init,omitempty"`
+	Sliding      *estimator.Snapshot `json:"sliding,omitempty"`
+	EKFFallback  bool                `json:"ekfFallback"`
+	Calibrated   bool                `json:"calibrated"`
+	Prior        *ColdStartPrior     `json:"prior,omitempty"`
+}
+
+// touch records that the pair's state changed on this instance.
+func (ts *TunerService) touch(key string) {
+	ts.stateUpdated[key] = time.Now()
+	ts.stateVersion[key]++
+	delete(ts.replicated, key)
+}
+
+// PairStates returns the state of every pair whose state changed after since, sorted by model
+// and accelerator. Unless all is set, pairs last changed by ApplyPairStates are left out, so an
+// instance does not send a peer's states back. Shadow estimators are not included.
+func (ts *TunerService) PairStates(since time.Time, all bool) []PairState {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	var states []PairState
+	for key, updated := range ts.stateUpdated {
+		if !updated.After(since) || (!all && ts.replicated[key]) {
+			continue
+		}
+		states = append(states, ts.pairState(key, updated))
+	}
+	slices.SortFunc(states, func(a, b PairState) int {
+		return cmp.Or(cmp.Compare(a.Model, b.Model), cmp.Compare(a.Accelerator, b.Accelerator))
+	})
+	return states
+}
+
+func (ts *TunerService) pairState(key string, updated time.Time) PairState {
+	model, accelerator := splitKey(key)
+	s := PairState{
+		Model:        model,
+		Accelerator:  accelerator,
+		Version:      ts.stateVersion[key],
+		UpdatedAt:    updated,
+		RecentFits:   slices.Clone(ts.recentFits[key]),
+		EKFFallback:  ts.ekfFallbacks[key],
+		Calibrated:   ts.calibrated[key],
+		Prior:        ts.priors[key],
+		LastObserved: ts.lastObserved[key],
+	}
+	if raw := ts.paramStore.Get(model, accelerator); raw != nil {
+		copied := *raw
+		s.Raw = &copied
+	}
+	if published := ts.published.Get(model, accelerator); published != nil {
+		copied := *published
+		s.Published = &copied
+	}
+	if ie, ok := ts.estimators[key]; ok {
+		s.Init = ie.Snapshot()
+	}
+	if swe, ok := ts.slidingEstimators[key]; ok {
+		s.Sliding = swe.Snapshot()
+	}
+	return s
+}
+
+// ApplyPairStates makes each state the pair's state on this instance, unless this instance holds
+// a state of the pair as new or newer (see PairState), and returns the number applied. Estimators are rebuilt
+// from the states' windows under this instance's configuration; watchers receive the published
+// parameters. If any state is invalid (see PairState.Validate), none is applied.
+func (ts *TunerService) ApplyPairStates(states []PairState) (int, error) {
+	for i := range states {
+		if err := states[i].Validate(); err != nil {
+			return 0, fmt.Errorf("pair %d (%s): %w", i, makeKey(states[i].Model, states[i].Accelerator), err)
+		}
+	}
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	applied := 0
+	for _, s := range states {
+		key := makeKey(s.Model, s.Accelerator)
+		if version := ts.stateVersion[key]; s.Version < version ||
+			(s.Version == version && !s.UpdatedAt.After(ts.stateUpdated[key])) {
+			continue
+		}
+		ts.applyPairState(key, s)
+		ts.stateUpdated[key] = s.UpdatedAt
+		ts.stateVersion[key] = s.Version
+		ts.replicated[key] = true
+		applied++
+	}
+	return applied, nil
+}
+
+// Validate reports an error if s could not be applied safely: parameters that are not finite
+// and positive, vectors that are not three long, or covariances that are not 3x3.
+func (s *PairState) Validate() error {
+	if s.Model == "" || s.Accelerator == "" {
+		return fmt.Errorf("model and accelerator are required")
+	}
+	if s.Version < 0 {
+		return fmt.Errorf("version %d is negative", s.Version)
+	}
+	if s.Raw != nil {
+		if err := validateParameters(s.Raw); err != nil {
+			return fmt.Errorf("raw: %w", err)
+		}
+	}
+	if s.Published != nil {
+		if err := validateParameters(s.Published); err != nil {
+			return fmt.Errorf("published: %w", err)
+		}
+	}
+	for i, fit := range s.RecentFits {
+		if err := estimator.ValidateParams(fit); err != nil {
+			return fmt.Errorf("recentFits %d: %w", i, err)
+		}
+	}
+	if s.Init != nil {
+		if err := s.Init.Validate(); err != nil {
+			return fmt.Errorf("init: %w", err)
+		}
+	}
+	if s.Sliding != nil {
+		if err := s.Sliding.Validate(); err != nil {
+			return fmt.Errorf("sliding: %w", err)
+		}
+	}
+	if s.Prior != nil {
+		if err := validatePrior(s.Prior); err != nil {
+			return fmt.Errorf("prior: %w", err)
+		}
+	}
+	return nil
+}
+
+func validateParameters(p *LearnedParameters) error {
+	if err := estimator.ValidateParams([]float64{float64(p.Alpha), float64(p.Beta), float64(p.Gamma)}); err != nil {
+		return err
+	}
+	if math.IsNaN(p.NIS) || math.IsInf(p.NIS, 0) {
+		return fmt.Errorf("nis must be finite")
+	}
+	if err := estimator.ValidateCovariance(p.Covariance); err != nil {
+		return fmt.Errorf("covariance: %w", err)
+	}
+	if err := estimator.ValidateCovariance(p.FitCovariance); err != nil {
+		return fmt.Errorf("fitCovariance: %w", err)
+	}
+	return nil
+}
+
+func validatePrior(p *ColdStartPrior) error {
+	if err := estimator.ValidateParams(p.Seed); err != nil {
+		return fmt.Errorf("seed: %w", err)
+	}
+	names := []string{"relativeStd", "minState", "maxState"}
+	for i, v := range [][]float64{p.RelativeStd, p.MinState, p.MaxState} {
+		if v == nil {
+			continue
+		}
+		if err := estimator.ValidateParams(v); err != nil {
+			return fmt.Errorf("%s: %w", names[i], err)
+		}
+	}
+	if (p.MinState == nil) != (p.MaxState == nil) {
+		return fmt.Errorf("minState and maxState must be set together")
+	}
+	return nil
+}
+
+func (ts *TunerService) applyPairState(key string, s PairState) {
+	if s.LastObserved.After(ts.lastObserved[key]) {
+		ts.lastObserved[key] = s.LastObserved
+	}
+	if s.Prior != nil {
+		ts.priors[key] = s.Prior
+	} else {
+		delete(ts.priors, key)
+	}
+	setOrDelete(ts.recentFits, key, s.RecentFits, s.RecentFits != nil)
+	setOrDelete(ts.ekfFallbacks, key, true, s.EKFFallback)
+	setOrDelete(ts.calibrated, key, true, s.Calibrated)
+
+	if s.Init != nil {
+		ie := estimator.NewInitEstimator(ts.initObs, ts.holdBack)
+		ie.SetMaxConditionNumber(ts.maxConditionNumber)
+		ie.SetSeed(ts.priorFor(key).Seed)
+		ie.Restore(s.Init)
+		ts.estimators[key] = ie
+	} else {
+		delete(ts.estimators, key)
+	}
+	if s.Sliding != nil {
+		swe := estimator.NewSlidingWindowEstimator(ts.windowSize, ts.initObs, ts.residualThreshold)
+		swe.SetMaxConditionNumber(ts.maxConditionNumber)
+		swe.SetSeed(ts.priorFor(key).Seed)
+		swe.Restore(s.Sliding)
+		ts.slidingEstimators[key] = swe
+	} else {
+		delete(ts.slidingEstimators, key)
+	}
+
+	if s.Raw != nil {
+		ts.paramStore.Set(s.Model, s.Accelerator, s.Raw)
+	} else {
+		ts.paramStore.Delete(s.Model, s.Accelerator)
+	}
+	if s.Published != nil {
+		ts.published.Set(s.Model, s.Accelerator, s.Published)
+	} else {
+		ts.published.Delete(s.Model, s.Accelerator)
+	}
+}
+
+func setOrDelete[V any](m map[string]V, key string, v V, set bool) {
+	if set {
+		m[key] = v
+	} else {
+		delete(m, key)
+	}
+}
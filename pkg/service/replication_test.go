@@ -0,0 +1,175 @@
+package service
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+)
+
+// replicate sends the states of from, changed after since, to to through JSON, as a peer
+// receives them, and returns how many were applied.
+func replicate(t *testing.T, from, to *TunerService, since time.Time, all bool) int {
+	t.Helper()
+	data, err := json.Marshal(from.PairStates(since, all))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var states []PairState
+	if err := json.Unmarshal(data, &states); err != nil {
+		t.Fatal(err)
+	}
+	n, err := to.ApplyPairStates(states)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
+
+func TestReplication_StandbyContinuesWarm(t *testing.T) {
+	t.Setenv("CONFIG_DATA_DIR", "../../config-data")
+	for _, sliding := range []bool{false, true} {
+		active := NewTunerService(0, 3, true, sliding, DefaultWindowSize, DefaultResidualThreshold, 0)
+		standby := NewTunerService(0, 3, true, sliding, DefaultWindowSize, DefaultResidualThreshold, 0)
+		specs := shadowTestSpecs(t, 5)
+
+		// a pair still collecting observations is replicated too
+		if _, err := active.Tune(specs[0]); err == nil {
+			t.Fatal("first cycle should collect")
+		}
+		if n := replicate(t, active, standby, time.Time{}, false); n != 1 || standby.estimators["llama/H100"].ObsCount() != 1 {
+			t.Fatalf("sliding=%v: applied %d, want the collecting pair", sliding, n)
+		}
+
+		for _, s := range specs[1:4] {
+			_, _ = active.Tune(s)
+		}
+		if n := replicate(t, active, standby, time.Time{}, false); n != 1 {
+			t.Fatalf("sliding=%v: applied %d, want 1", sliding, n)
+		}
+		if got, want := standby.GetParams("llama", "H100"), active.GetParams("llama", "H100"); want == nil || got == nil || got.Alpha != want.Alpha || got.UpdateCount != want.UpdateCount {
+			t.Fatalf("sliding=%v: standby params %+v, want %+v", sliding, got, want)
+		}
+		if standby.IsWarmingUp() {
+			t.Errorf("sliding=%v: standby is warming up", sliding)
+		}
+
+		// failover: the standby's next cycle matches the one the active would have run
+		want, err := active.Tune(specs[4])
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := standby.Tune(specs[4])
+		if err != nil || got.PerfData[0].PerfParms != want.PerfData[0].PerfParms {
+			t.Errorf("sliding=%v: standby cycle = (%+v, %v), want %+v", sliding, got, err, want)
+		}
+	}
+}
+
+func TestReplication_NewerStateWins(t *testing.T) {
+	t.Setenv("CONFIG_DATA_DIR", "../../config-data")
+	a := NewTunerService(0, 1, false, false, DefaultWindowSize, DefaultResidualThreshold, 0)
+	b := NewTunerService(0, 1, false, false, DefaultWindowSize, DefaultResidualThreshold, 0)
+	specs := shadowTestSpecs(t, 3)
+	if _, err := a.Tune(specs[0]); err != nil {
+		t.Fatal(err)
+	}
+	if n := replicate(t, a, b, time.Time{}, false); n != 1 {
+		t.Fatalf("applied %d, want 1", n)
+	}
+	if n := replicate(t, a, b, time.Time{}, true); n != 0 {
+		t.Errorf("applied %d, want an unchanged state ignored", n)
+	}
+	if states := b.PairStates(time.Time{}, false); len(states) != 0 {
+		t.Errorf("states %+v, want replicated pairs left out", states)
+	}
+	if n := replicate(t, b, a, time.Time{}, true); n != 0 {
+		t.Errorf("applied %d, want the echoed state ignored", n)
+	}
+
+	// b takes over and tunes; its newer state replaces a's, and a's older one no longer applies
+	if _, err := b.Tune(specs[1]); err != nil {
+		t.Fatal(err)
+	}
+	if n := replicate(t, b, a, time.Time{}, false); n != 1 || a.GetParams("llama", "H100").UpdateCount != 2 {
+		t.Errorf("applied %d, want b's second update on a", n)
+	}
+	stale := a.PairStates(time.Time{}, true)
+	stale[0].UpdatedAt = stale[0].UpdatedAt.Add(-time.Minute)
+	if n, err := b.ApplyPairStates(stale); err != nil || n != 0 {
+		t.Errorf("applied %d, want an older state ignored", n)
+	}
+	if states := a.PairStates(time.Now(), true); len(states) != 0 {
+		t.Errorf("states %+v, want none changed after now", states)
+	}
+}
+
+func TestReplication_OrdersByVersionNotClock(t *testing.T) {
+	t.Setenv("CONFIG_DATA_DIR", "../../config-data")
+	a := NewTunerService(0, 1, false, false, DefaultWindowSize, DefaultResidualThreshold, 0)
+	b := NewTunerService(0, 1, false, false, DefaultWindowSize, DefaultResidualThreshold, 0)
+	specs := shadowTestSpecs(t, 2)
+	if _, err := a.Tune(specs[0]); err != nil {
+		t.Fatal(err)
+	}
+	// a's clock runs an hour ahead of b's
+	a.stateUpdated["llama/H100"] = a.stateUpdated["llama/H100"].Add(time.Hour)
+	if n := replicate(t, a, b, time.Time{}, false); n != 1 {
+		t.Fatalf("applied %d, want 1", n)
+	}
+
+	// b takes over; its change wins on a although stamped before a's
+	if _, err := b.Tune(specs[1]); err != nil {
+		t.Fatal(err)
+	}
+	if n := replicate(t, b, a, time.Time{}, false); n != 1 || a.GetParams("llama", "H100").UpdateCount != 2 {
+		t.Errorf("applied %d, want b's second update on a", n)
+	}
+
+	// a restarted instance's fresh state does not replace b's
+	restarted := NewTunerService(0, 1, false, false, DefaultWindowSize, DefaultResidualThreshold, 0)
+	if _, err := restarted.Tune(specs[0]); err != nil {
+		t.Fatal(err)
+	}
+	if n := replicate(t, restarted, b, time.Time{}, false); n != 0 || b.GetParams("llama", "H100").UpdateCount != 2 {
+		t.Errorf("applied %d, want the lower version ignored", n)
+	}
+}
+
+func TestReplication_RejectsMalformedState(t *testing.T) {
+	t.Setenv("CONFIG_DATA_DIR", "../../config-data")
+	active := NewTunerService(0, 3, true, true, DefaultWindowSize, DefaultResidualThreshold, 0)
+	standby := NewTunerService(0, 3, true, true, DefaultWindowSize, DefaultResidualThreshold, 0)
+	specs := shadowTestSpecs(t, 5)
+	for _, s := range specs[:4] {
+		_, _ = active.Tune(s)
+	}
+
+	corrupt := []func(s *PairState){
+		func(s *PairState) { s.Sliding.LastFit = []float64{1} },
+		func(s *PairState) { s.Sliding.LastCovariance = [][]float64{{1, 0, 0}, {0, 1}, {0, 0, 1}} },
+		func(s *PairState) { s.Raw.Covariance = [][]float64{{1}} },
+		func(s *PairState) { s.Published.Alpha = float32(math.NaN()) },
+		func(s *PairState) { s.RecentFits = [][]float64{{1, 2}} },
+		func(s *PairState) { s.Prior.Seed = []float64{1, -2, 3} },
+	}
+	for i, c := range corrupt {
+		states := active.PairStates(time.Time{}, true)
+		states = append(states, states[0])
+		states[1].Accelerator = "A100"
+		c(&states[1])
+		if n, err := standby.ApplyPairStates(states); err == nil || n != 0 {
+			t.Errorf("corruption %d: applied %d, err %v, want the request rejected", i, n, err)
+		}
+	}
+	if states := standby.PairStates(time.Time{}, true); len(states) != 0 {
+		t.Fatalf("standby holds %d states, want none", len(states))
+	}
+	// the pairs the malformed states named tune from scratch
+	for _, s := range specs {
+		_, _ = standby.Tune(s)
+	}
+	if standby.GetParams("llama", "H100") == nil {
+		t.Error("pair not tuned after a rejected replication")
+	}
+}
@@ -3,7 +3,10 @@ package service
 import (
 	"fmt"
 	"log/slog"
+	"maps"
 	"math"
+	"slices"
+	"sync"
 	"time"
 
 	optconfig "github.com/llm-inferno/optimizer-light/pkg/config"
@@ -15,8 +18,12 @@ import (
 )
 
 // TunerService groups replica metrics by (model, accelerator), runs EKF tuning per group,
-// maintains a ParameterStore for state continuity, and returns updated ModelData.
+// maintains a ParameterStore for state continuity, and returns updated ModelData. It is safe for
+// concurrent use: Tune, Calibrate and the status queries are serialized, except that shadow
+// estimators replay a cycle after the primary releases its lock.
 type TunerService struct {
+	replayMu           sync.Mutex // serializes cycles so shadows replay them in order; taken before mu
+	mu                 sync.Mutex
 	paramStore         *ParameterStore
 	warmUpCycles       int
 	estimators         map[string]*estimator.InitEstimator
@@ -31,12 +38,50 @@ type TunerService struct {
 	ekfFallbacks       map[string]bool
 	calibrated         map[string]bool
 	coldSeed           []float64
+	coldPercentChange  []float64
 	coldSeedLoaded     bool
+	transferPrior      bool
+	catalog            *Catalog
+	priors             map[string]*ColdStartPrior
+	history            HistorySource
+	name               string
+	shadows            []*TunerService
+	oneStepErrors      map[string]map[string][]float64 // estimator name -> pair key -> per-cycle errors
+	published          *ParameterStore                 // what readers are served; see PublicationPolicy
+	publication        PublicationPolicy
+	recentFits         map[string][][]float64 // pair key -> the raw fits smoothing draws on, oldest first
+	staleness          StalenessPolicy
+	stateUpdated       map[string]time.Time // pair key -> last change of its state; see PairState
+	stateVersion       map[string]int       // pair key -> version of its state; see PairState
+	replicated         map[string]bool      // pair keys last changed by ApplyPairStates
+	lastObserved       map[string]time.Time // pair key -> its last tune or calibration cycle; see StalenessPolicy
+}
+
+// HistorySource supplies past observations of a (model, accelerator) pair, oldest first. The
+// service uses it to bootstrap a newly seen pair (see SetHistorySource).
+type HistorySource interface {
+	History(model, accelerator string) ([]*core.EnvironmentPrefillDecode, error)
+}
+
+// SetHistorySource enables init bootstrap: the first time a (model, accelerator) pair is seen,
+// its history is pre-filled into the pair's InitEstimator (and from there the sliding window), so
+// a pair with enough history fits on its first tune cycle instead of collecting TUNER_INIT_OBS
+// cycles. nil disables bootstrap.
+func (ts *TunerService) SetHistorySource(h HistorySource) {
+	ts.replayMu.Lock()
+	defer ts.replayMu.Unlock()
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	ts.history = h
+	for _, shadow := range ts.shadows {
+		shadow.SetHistorySource(h)
+	}
 }
 
 // coldStartSeed returns the cold-start anchor [alpha, beta, gamma] used by the estimators'
-// GuessInitState fallback (issue #17): the config initState. Loaded once and cached; nil on
-// load failure (estimators then keep their legacy heuristic).
+// GuessInitState fallback (issue #17): the config initState. Loaded once and cached, with the
+// config percentChange; nil on load failure (estimators then keep their legacy heuristic). A pair
+// with a cross-pair prior is anchored to that instead (see priorFor).
 func (ts *TunerService) coldStartSeed() []float64 {
 	if ts.coldSeedLoaded {
 		return ts.coldSeed
@@ -44,6 +89,7 @@ func (ts *TunerService) coldStartSeed() []float64 {
 	ts.coldSeedLoaded = true
 	if configData, err := utils.LoadConfigForServer(config.DefaultConfigType); err == nil {
 		ts.coldSeed = configData.ModelData.InitState
+		ts.coldPercentChange = configData.ModelData.PercentChange
 	} else {
 		slog.Warn("cold-start seed unavailable: config load failed, estimators use legacy guess", "err", err)
 	}
@@ -54,7 +100,14 @@ func (ts *TunerService) coldStartSeed() []float64 {
 // created thereafter (> 0 enables; <= 0 disables). Wire this from configuration before the
 // service handles any tune requests.
 func (ts *TunerService) SetMaxConditionNumber(k float64) {
+	ts.replayMu.Lock()
+	defer ts.replayMu.Unlock()
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
 	ts.maxConditionNumber = k
+	for _, shadow := range ts.shadows {
+		shadow.SetMaxConditionNumber(k)
+	}
 }
 
 // NewTunerService creates a TunerService with an empty ParameterStore.
@@ -72,27 +125,101 @@ func NewTunerService(warmUpCycles, initObs int, holdBack bool, useSliding bool,
 		initFitThreshold:  initFitThreshold,
 		ekfFallbacks:      make(map[string]bool),
 		calibrated:        make(map[string]bool),
+		name:              DefaultEstimatorName,
+		oneStepErrors:     make(map[string]map[string][]float64),
+		published:         NewParameterStore(),
+		publication:       DefaultPublicationPolicy,
+		recentFits:        make(map[string][][]float64),
+		priors:            make(map[string]*ColdStartPrior),
+		stateUpdated:      make(map[string]time.Time),
+		stateVersion:      make(map[string]int),
+		replicated:        make(map[string]bool),
+		lastObserved:      make(map[string]time.Time),
 	}
 }
 
-func (ts *TunerService) estimatorFor(key string) *estimator.InitEstimator {
+func (ts *TunerService) estimatorFor(key string, history pairHistory) *estimator.InitEstimator {
 	if ie, ok := ts.estimators[key]; ok {
 		return ie
 	}
 	ie := estimator.NewInitEstimator(ts.initObs, ts.holdBack)
 	ie.SetMaxConditionNumber(ts.maxConditionNumber)
-	ie.SetSeed(ts.coldStartSeed())
+	ie.SetSeed(ts.priorFor(key).Seed)
+	if envs, ok := history[key]; ok {
+		ts.bootstrap(key, ie, envs)
+	}
 	ts.estimators[key] = ie
 	return ie
 }
 
+// pairHistory is the history fetched for the new pairs of a tune request, by pair key.
+type pairHistory map[string][]*core.EnvironmentPrefillDecode
+
+// fetchHistory fetches from the history source, if one is set, the history of the pairs in specs
+// that the service or one of its shadows has not seen yet. ts.mu is not held while fetching, so a
+// slow source delays only the request that brought the new pair. History errors are logged and
+// leave the pair on the normal collection path.
+func (ts *TunerService) fetchHistory(specs []optconfig.ServerSpec) pairHistory {
+	ts.replayMu.Lock()
+	ts.mu.Lock()
+	source := ts.history
+	var keys []string
+	if source != nil {
+		for key := range groupByModelAccelerator(specs) {
+			if ts.isNewPair(key) {
+				keys = append(keys, key)
+			}
+		}
+	}
+	ts.mu.Unlock()
+	ts.replayMu.Unlock()
+
+	history := make(pairHistory, len(keys))
+	for _, key := range keys {
+		model, accelerator := splitKey(key)
+		envs, err := source.History(model, accelerator)
+		if err != nil {
+			slog.Warn("init bootstrap: history unavailable, collecting observations",
+				"model", model, "accelerator", accelerator, "err", err)
+			continue
+		}
+		history[key] = envs
+	}
+	return history
+}
+
+// isNewPair reports whether the service or one of its shadows has no estimator for the pair key.
+// Called with ts.replayMu and ts.mu held.
+func (ts *TunerService) isNewPair(key string) bool {
+	if _, ok := ts.estimators[key]; !ok {
+		return true
+	}
+	return slices.ContainsFunc(ts.shadows, func(shadow *TunerService) bool {
+		shadow.mu.Lock()
+		defer shadow.mu.Unlock()
+		_, ok := shadow.estimators[key]
+		return !ok
+	})
+}
+
+// bootstrap pre-fills a new pair's InitEstimator with the pair's history.
+func (ts *TunerService) bootstrap(key string, ie *estimator.InitEstimator, envs []*core.EnvironmentPrefillDecode) {
+	model, accelerator := splitKey(key)
+	for _, env := range envs {
+		ie.AddObservation(env)
+	}
+	slog.Info("init bootstrap: pre-filled observations from history",
+		"estimator", ts.name, "model", model, "accelerator", accelerator,
+		"history", len(envs), "count", ie.ObsCount(), "minObs", ie.MinObs())
+}
+
 func (ts *TunerService) slidingEstimatorFor(key string, ie *estimator.InitEstimator) *estimator.SlidingWindowEstimator {
 	if swe, ok := ts.slidingEstimators[key]; ok {
 		return swe
 	}
 	swe := estimator.NewSlidingWindowEstimator(ts.windowSize, ts.initObs, ts.residualThreshold)
 	swe.SetMaxConditionNumber(ts.maxConditionNumber)
-	swe.SetSeed(ts.coldStartSeed())
+	swe.SetSeed(ts.priorFor(key).Seed)
 	swe.SeedFromEstimator(ie)
 	if fitted, err := ie.Fit(); err == nil {
 		fv := ie.LastFitFuncValue()
@@ -112,11 +239,12 @@ func (ts *TunerService) slidingEstimatorFor(key string, ie *estimator.InitEstima
 	return swe
 }
 
-func (ts *TunerService) tuneGroupSliding(model, accelerator, key string, ie *estimator.InitEstimator, env *core.EnvironmentPrefillDecode) error {
+func (ts *TunerService) tuneGroupSliding(model, accelerator, key string, ie *estimator.InitEstimator, env *core.EnvironmentPrefillDecode, outcome *GroupOutcome) error {
 	_, alreadyExists := ts.slidingEstimators[key]
 	swe := ts.slidingEstimatorFor(key, ie)
 
 	if ts.ekfFallbacks[key] {
+		outcome.Reason, outcome.Estimator, outcome.EKFFallback = OutcomeEKFFallback, EstimatorEKF, true
 		return fmt.Errorf("EKF fallback active for %s/%s: poor init fit (funcValue > %.1f)",
 			model, accelerator, ts.initFitThreshold)
 	}
@@ -124,8 +252,10 @@ func (ts *TunerService) tuneGroupSliding(model, accelerator, key string, ie *est
 	if alreadyExists {
 		swe.AddObservation(env)
 	}
+	outcome.WindowCount, outcome.WindowSize = swe.Len(), ts.windowSize
 
 	if !swe.IsReady() {
+		outcome.Reason = OutcomeWindowFilling
 		slog.Info("sliding window filling",
 			"model", model, "accelerator", accelerator,
 			"count", swe.Len(), "windowSize", ts.windowSize)
@@ -135,6 +265,7 @@ func (ts *TunerService) tuneGroupSliding(model, accelerator, key string, ie *est
 
 	fitted, err := swe.Fit()
 	if err != nil {
+		outcome.Reason = OutcomeError
 		return fmt.Errorf("SlidingWindowEstimator.Fit for %s/%s: %w", model, accelerator, err)
 	}
 
@@ -156,13 +287,15 @@ func (ts *TunerService) tuneGroupSliding(model, accelerator, key string, ie *est
 		updateCount = existing.UpdateCount
 	}
 	ts.paramStore.Set(model, accelerator, &LearnedParameters{
-		Alpha:       float32(fitted[0]),
-		Beta:        float32(fitted[1]),
-		Gamma:       float32(fitted[2]),
-		NIS:         0,
-		UpdateCount: updateCount + 1,
-		LastUpdated: time.Now(),
+		Alpha:         float32(fitted[0]),
+		Beta:          float32(fitted[1]),
+		Gamma:         float32(fitted[2]),
+		NIS:           0,
+		UpdateCount:   updateCount + 1,
+		FitCovariance: swe.LastFitCovariance(),
+		LastUpdated:   time.Now(),
 	})
+	ts.publish(model, accelerator)
 	slog.Info("sliding-window tuned parameters",
 		"model", model, "accelerator", accelerator,
 		"alpha", fitted[0], "beta", fitted[1], "gamma", fitted[2],
@@ -208,28 +341,76 @@ func (ts *TunerService) ekfExcursion(model, accelerator string, seed []float64,
 // Tune accepts per-replica ServerSpecs, runs EKF or SWNM tuning for each
 // (model, accelerator) group, and returns updated ModelData with tuned alpha/beta/gamma.
 func (ts *TunerService) Tune(replicaSpecs []optconfig.ServerSpec) (*optconfig.ModelData, error) {
+	modelData, _, err := ts.TuneWithOutcomes(replicaSpecs)
+	return modelData, err
+}
+
+// TuneWithOutcomes is Tune, also reporting the outcome of each group, sorted by model and
+// accelerator. The outcomes are returned even when no group has parameters yet (and the error
+// is non-nil), so callers can see how far warm-up has progressed.
+func (ts *TunerService) TuneWithOutcomes(replicaSpecs []optconfig.ServerSpec) (*optconfig.ModelData, []GroupOutcome, error) {
+	return ts.tuneWithHistory(replicaSpecs, ts.fetchHistory(replicaSpecs))
+}
+
+// tuneWithHistory is TuneWithOutcomes, bootstrapping new pairs from history. The shadows replay
+// the cycle after ts.mu is released, so their fits do not hold up readers of the primary.
+func (ts *TunerService) tuneWithHistory(replicaSpecs []optconfig.ServerSpec, history pairHistory) (*optconfig.ModelData, []GroupOutcome, error) {
+	ts.replayMu.Lock()
+	defer ts.replayMu.Unlock()
+	modelData, outcomes, err := ts.tunePrimary(replicaSpecs, history)
+	if outcomes != nil {
+		ts.tuneShadows(replicaSpecs, history)
+	}
+	return modelData, outcomes, err
+}
+
+// tunePrimary runs the primary estimator's part of a tune cycle under ts.mu.
+func (ts *TunerService) tunePrimary(replicaSpecs []optconfig.ServerSpec, history pairHistory) (*optconfig.ModelData, []GroupOutcome, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
 	groups := groupByModelAccelerator(replicaSpecs)
 	if len(groups) == 0 {
-		return nil, fmt.Errorf("no replicas with active traffic in request")
+		return nil, nil, fmt.Errorf("no replicas with active traffic in request")
 	}
+	ts.scoreEstimators(groups)
 
-	for key, replicas := range groups {
+	// Groups are tuned in key order: a new pair's transferred prior draws on the pairs tuned
+	// before it, so the order must not vary between runs.
+	outcomes := make([]GroupOutcome, 0, len(groups))
+	for _, key := range slices.Sorted(maps.Keys(groups)) {
+		replicas := groups[key]
 		model, accelerator := splitKey(key)
-		if err := ts.tuneGroup(model, accelerator, replicas); err != nil {
-			slog.Warn("tuning failed for group", "key", key, "err", err)
+		outcome := GroupOutcome{Model: model, Accelerator: accelerator, Replicas: len(replicas), Reason: OutcomeTuned}
+		if err := ts.tuneGroup(model, accelerator, replicas, history, &outcome); err != nil {
+			slog.Warn("tuning failed for group", "key", key, "reason", outcome.Reason, "err", err)
+			outcome.Message = err.Error()
 		}
+		ts.touch(key)
+		outcome.Fresh = outcome.Reason == OutcomeTuned
+		if params := ts.paramStore.Get(model, accelerator); params != nil {
+			outcome.HasParams = true
+			outcome.UpdateCount = params.UpdateCount
+		}
+		outcomes = append(outcomes, outcome)
 	}
 
 	modelData := ts.buildModelData(groups)
 	if len(modelData.PerfData) == 0 {
-		return nil, fmt.Errorf("tuning produced no results for any model/accelerator group")
+		return nil, outcomes, fmt.Errorf("tuning produced no results for any model/accelerator group")
 	}
-	return modelData, nil
+	return modelData, outcomes, nil
 }
 
-func (ts *TunerService) tuneGroup(model, accelerator string, replicas []optconfig.ServerSpec) error {
+// tuneGroup runs one cycle for a group, recording in outcome why it stored no parameters, if so.
+func (ts *TunerService) tuneGroup(model, accelerator string, replicas []optconfig.ServerSpec, history pairHistory, outcome *GroupOutcome) error {
+	outcome.Estimator = EstimatorEKF
+	if ts.useSliding {
+		outcome.Estimator = EstimatorSlidingWindow
+	}
+	ts.lastObserved[makeKey(model, accelerator)] = time.Now()
 	envs := buildEnvironments(replicas)
 	if len(envs) == 0 {
+		outcome.Reason = OutcomeNoValidObservations
 		return fmt.Errorf("no valid environments for %s/%s", model, accelerator)
 	}
 
@@ -248,10 +429,12 @@ func (ts *TunerService) tuneGroup(model, accelerator string, replicas []optconfi
 	}
 
 	key := makeKey(model, accelerator)
-	ie := ts.estimatorFor(key)
+	ie := ts.estimatorFor(key, history)
 	ie.AddObservation(envs[0])
+	outcome.ObsCount, outcome.ObsTarget = ie.ObsCount(), ie.MinObs()
 
 	if !ie.IsReady() {
+		outcome.Reason = OutcomeCollecting
 		slog.Info("collecting initial observations",
 			"model", model, "accelerator", accelerator,
 			"count", ie.ObsCount(), "minObs", ie.MinObs())
@@ -260,7 +443,10 @@ func (ts *TunerService) tuneGroup(model, accelerator string, replicas []optconfi
 	}
 
 	if ts.useSliding && !ts.ekfFallbacks[key] {
-		return ts.tuneGroupSliding(model, accelerator, key, ie, envs[0])
+		return ts.tuneGroupSliding(model, accelerator, key, ie, envs[0], outcome)
+	}
+	if ts.ekfFallbacks[key] {
+		outcome.Estimator, outcome.EKFFallback = EstimatorEKF, true
 	}
 
 	var fitInitState []float64
@@ -274,6 +460,7 @@ func (ts *TunerService) tuneGroup(model, accelerator string, replicas []optconfi
 
 	tuner, err := ts.createTuner(model, accelerator, envs[0], fitInitState)
 	if err != nil {
+		outcome.Reason = OutcomeError
 		return fmt.Errorf("create tuner for %s/%s: %w", model, accelerator, err)
 	}
 
@@ -284,6 +471,7 @@ func (ts *TunerService) tuneGroup(model, accelerator string, replicas []optconfi
 	skipNIS := updateCount < ts.warmUpCycles
 
 	var accepted *core.TunedResults
+	var nisRejected, invalid int
 	for _, env := range envs {
 		results, runErr := tuner.RunWithValidation(env, skipNIS)
 		if runErr != nil {
@@ -293,15 +481,27 @@ func (ts *TunerService) tuneGroup(model, accelerator string, replicas []optconfi
 		if results.ValidationFailed {
 			if results.NIS > 0 {
 				slog.Info("EKF update rejected: NIS gate", "model", model, "accelerator", accelerator, "NIS", results.NIS)
+				nisRejected++
 			} else {
 				slog.Info("EKF update rejected: state validation", "model", model, "accelerator", accelerator)
+				invalid++
 			}
 			continue
 		}
 		accepted = results
+		outcome.Accepted++
 	}
+	outcome.Rejected = nisRejected + invalid
 
 	if accepted == nil {
+		switch {
+		case nisRejected > 0:
+			outcome.Reason = OutcomeNISRejected
+		case invalid > 0:
+			outcome.Reason = OutcomeValidationRejected
+		default:
+			outcome.Reason = OutcomeError
+		}
 		return fmt.Errorf("no accepted results for %s/%s", model, accelerator)
 	}
 
@@ -314,6 +514,7 @@ func (ts *TunerService) tuneGroup(model, accelerator string, replicas []optconfi
 		Covariance:  covToSlice(accepted.Covariance),
 		LastUpdated: time.Now(),
 	})
+	ts.publish(model, accelerator)
 	slog.Info("tuned parameters",
 		"model", model,
 		"accelerator", accelerator,
@@ -341,6 +542,7 @@ func (ts *TunerService) createTuner(model, accelerator string, firstEnv *core.En
 			float64(existing.Beta),
 			float64(existing.Gamma),
 		})
+		ts.priors[makeKey(model, accelerator)].bound(&configData.ModelData)
 		if cov := existing.CovarianceMatrix(); cov != nil {
 			tuner, err := core.NewTunerWithCovariance(configData, firstEnv, cov)
 			if err != nil {
@@ -349,11 +551,21 @@ func (ts *TunerService) createTuner(model, accelerator string, firstEnv *core.En
 			return attachQueueModelObsFunc(tuner)
 		}
 	} else {
+		prior := ts.priorFor(makeKey(model, accelerator))
 		if fitInitState != nil {
 			setInitState(&configData.ModelData, fitInitState)
-		} else if initState := estimator.GuessInitState(firstEnv, configData.ModelData.InitState); initState != nil {
+		} else if initState := estimator.GuessInitState(firstEnv, prior.Seed); initState != nil {
 			setInitState(&configData.ModelData, initState)
 		}
+		prior.bound(&configData.ModelData)
+		// A catalog or transferred prior also sets how far the filter trusts its starting point.
+		if cov := prior.covarianceAt(configData.ModelData.InitState); cov != nil {
+			tuner, err := core.NewTunerWithCovariance(configData, firstEnv, cov)
+			if err != nil {
+				return nil, err
+			}
+			return attachQueueModelObsFunc(tuner)
+		}
 	}
 
 	tuner, err := core.NewTuner(configData, firstEnv)
@@ -408,7 +620,7 @@ func (ts *TunerService) buildModelData(groups map[string][]optconfig.ServerSpec)
 	var entries []optconfig.ModelAcceleratorPerfData
 	for key, replicas := range groups {
 		model, accelerator := splitKey(key)
-		params := ts.paramStore.Get(model, accelerator)
+		params := ts.published.Get(model, accelerator)
 		if params == nil {
 			continue
 		}
@@ -427,14 +639,23 @@ func (ts *TunerService) buildModelData(groups map[string][]optconfig.ServerSpec)
 	return &optconfig.ModelData{PerfData: entries}
 }
 
-// GetParams returns the most recently tuned parameters for a model/accelerator pair,
-// or nil if no tuning has been performed for that pair yet.
+// GetParams returns the published parameters for a model/accelerator pair (see
+// PublicationPolicy), or nil if no tuning has been performed for that pair yet. Their Raw* fields
+// hold the estimator's own fit.
 func (ts *TunerService) GetParams(model, accelerator string) *LearnedParameters {
-	return ts.paramStore.Get(model, accelerator)
+	return ts.published.Get(model, accelerator)
+}
+
+// WatchParams returns the published parameters of every pair tuned so far and a channel
+// receiving each later publication; see ParameterStore.Watch.
+func (ts *TunerService) WatchParams(buffer int) (snapshot []ParameterUpdate, updates <-chan ParameterUpdate, cancel func()) {
+	return ts.published.Watch(buffer)
 }
 
 // IsWarmingUp returns true if any known pair has not yet completed its init or warm-up phase.
 func (ts *TunerService) IsWarmingUp() bool {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
 	for _, ie := range ts.estimators {
 		if !ie.IsReady() && ie.HoldBack() {
 			return true
@@ -473,53 +694,11 @@ func (ts *TunerService) IsWarmingUp() bool {
 	return false
 }
 
-// Merge accepts the Controller's current ModelData and returns it with PerfParms overlaid
-// from the ParameterStore for any matching (name, accelerator) pairs.
+// Merge accepts the Controller's current ModelData and returns it with the published PerfParms
+// overlaid for any matching (name, accelerator) pairs; see MergeWithFreshness.
 func (ts *TunerService) Merge(modelData *optconfig.ModelData) *optconfig.ModelData {
-	if modelData == nil {
-		modelData = &optconfig.ModelData{}
-	}
-
-	allParams := ts.paramStore.GetAll()
-	matched := make(map[string]bool, len(allParams))
-
-	result := make([]optconfig.ModelAcceleratorPerfData, len(modelData.PerfData))
-	for i, entry := range modelData.PerfData {
-		result[i] = entry
-		key := makeKey(entry.Name, entry.Acc)
-		if params, ok := allParams[key]; ok {
-			result[i].PerfParms = optconfig.PerfParms{
-				Alpha: params.Alpha,
-				Beta:  params.Beta,
-				Gamma: params.Gamma,
-			}
-			if matched[key] {
-				slog.Warn("duplicate model/accelerator key in input ModelData", "key", key)
-			} else {
-				matched[key] = true
-			}
-		}
-	}
-
-	for key, params := range allParams {
-		if matched[key] {
-			continue
-		}
-		model, acc := splitKey(key)
-		result = append(result, optconfig.ModelAcceleratorPerfData{
-			Name:         model,
-			Acc:          acc,
-			AccCount:     DefaultAccCount,
-			MaxBatchSize: DefaultMaxBatchSize,
-			PerfParms: optconfig.PerfParms{
-				Alpha: params.Alpha,
-				Beta:  params.Beta,
-				Gamma: params.Gamma,
-			},
-		})
-	}
-
-	return &optconfig.ModelData{PerfData: result}
+	merged, _ := ts.MergeWithFreshness(modelData)
+	return merged
 }
 
 // CalibrationStatus reports, for one (model, accelerator) pair the tuner has seen, the facts the
@@ -545,6 +724,8 @@ type CalibrationStatus struct {
 // has begun collecting observations for. Pairs not yet seen by /tune are absent (the controller
 // cannot judge excitation before any observation exists).
 func (ts *TunerService) CalibrationStatuses() []CalibrationStatus {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
 	out := make([]CalibrationStatus, 0, len(ts.estimators))
 	for key, ie := range ts.estimators {
 		model, accelerator := splitKey(key)
@@ -578,21 +759,34 @@ func (ts *TunerService) CalibrationStatuses() []CalibrationStatus {
 // re-warming. Reuses the same InitEstimator multi-point Nelder-Mead fit and condition-number guard
 // as the normal path. Returns the calibrated ModelData; errors if no group could be calibrated.
 func (ts *TunerService) Calibrate(specs []optconfig.ServerSpec) (*optconfig.ModelData, error) {
-	groups := groupByModelAccelerator(specs)
-	if len(groups) == 0 {
+	ts.replayMu.Lock()
+	defer ts.replayMu.Unlock()
+	if len(groupByModelAccelerator(specs)) == 0 {
 		return nil, fmt.Errorf("no calibration points with active traffic in request")
 	}
+	modelData, err := ts.calibratePrimary(specs)
+	ts.calibrateShadows(specs)
+	return modelData, err
+}
+
+// calibratePrimary runs the primary estimator's part of Calibrate under ts.mu.
+func (ts *TunerService) calibratePrimary(specs []optconfig.ServerSpec) (*optconfig.ModelData, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	groups := groupByModelAccelerator(specs)
 
 	// Build the response from only the groups calibrated in THIS call. buildModelData reads params
 	// from the store, so passing groups that failed calibration would leak stale params (from an
 	// earlier /tune or /calibrate) into the response as if freshly calibrated.
 	calibratedGroups := make(map[string][]optconfig.ServerSpec)
-	for key, replicas := range groups {
+	for _, key := range slices.Sorted(maps.Keys(groups)) {
+		replicas := groups[key]
 		model, accelerator := splitKey(key)
 		if err := ts.calibrateGroup(model, accelerator, key, replicas); err != nil {
 			slog.Warn("calibration failed for group", "key", key, "err", err)
 			continue
 		}
+		ts.touch(key)
 		calibratedGroups[key] = replicas
 	}
 	if len(calibratedGroups) == 0 {
@@ -611,6 +805,7 @@ func (ts *TunerService) Calibrate(specs []optconfig.ServerSpec) (*optconfig.Mode
 // fit. A still-ill-conditioned fit (the sweep grid lacked operating-point spread) is rejected
 // rather than stored.
 func (ts *TunerService) calibrateGroup(model, accelerator, key string, replicas []optconfig.ServerSpec) error {
+	ts.lastObserved[key] = time.Now()
 	envs := buildEnvironments(replicas)
 	if len(envs) < 2 {
 		return fmt.Errorf("need >= 2 calibration points for %s/%s, got %d", model, accelerator, len(envs))
@@ -618,7 +813,7 @@ func (ts *TunerService) calibrateGroup(model, accelerator, key string, replicas
 
 	ie := estimator.NewInitEstimator(len(envs), false)
 	ie.SetMaxConditionNumber(ts.maxConditionNumber)
-	ie.SetSeed(ts.coldStartSeed())
+	ie.SetSeed(ts.priorFor(key).Seed)
 	for _, env := range envs {
 		ie.AddObservation(env)
 	}
@@ -643,12 +838,14 @@ func (ts *TunerService) calibrateGroup(model, accelerator, key string, replicas
 
 	// Store graduated so the warm-up gate no longer blocks this pair (UpdateCount >= warmUpCycles).
 	ts.paramStore.Set(model, accelerator, &LearnedParameters{
-		Alpha:       float32(fitted[0]),
-		Beta:        float32(fitted[1]),
-		Gamma:       float32(fitted[2]),
-		UpdateCount: ts.warmUpCycles,
-		LastUpdated: time.Now(),
+		Alpha:         float32(fitted[0]),
+		Beta:          float32(fitted[1]),
+		Gamma:         float32(fitted[2]),
+		UpdateCount:   ts.warmUpCycles,
+		FitCovariance: ie.LastFitCovariance(),
+		LastUpdated:   time.Now(),
 	})
+	ts.publishNow(model, accelerator)
 
 	// Seed the per-pair estimators from the sweep so subsequent Tune cycles track drift from the
 	// calibrated fit (rich warm-up in one shot) rather than re-collecting init observations.
@@ -657,7 +854,7 @@ func (ts *TunerService) calibrateGroup(model, accelerator, key string, replicas
 	if ts.useSliding {
 		swe := estimator.NewSlidingWindowEstimator(ts.windowSize, ts.initObs, ts.residualThreshold)
 		swe.SetMaxConditionNumber(ts.maxConditionNumber)
-		swe.SetSeed(ts.coldStartSeed())
+		swe.SetSeed(ts.priorFor(key).Seed)
 		swe.SeedFromEstimator(ie)
 		swe.SeedLastFit(fitted)
 		ts.slidingEstimators[key] = swe
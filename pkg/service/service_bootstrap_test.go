@@ -0,0 +1,150 @@
+package service
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	optconfig "github.com/llm-inferno/optimizer-light/pkg/config"
+
+	"github.com/llm-inferno/model-tuner/pkg/core"
+)
+
+// fakeHistory is a HistorySource returning fixed environments (or an error) and recording the
+// pairs it was asked for.
+type fakeHistory struct {
+	envs  []*core.EnvironmentPrefillDecode
+	err   error
+	calls []string
+}
+
+func (h *fakeHistory) History(model, accelerator string) ([]*core.EnvironmentPrefillDecode, error) {
+	h.calls = append(h.calls, makeKey(model, accelerator))
+	return h.envs, h.err
+}
+
+// historyEnvs builds ground-truth environments over an arrival-rate ramp.
+func historyEnvs(t *testing.T, maxBatch int, p [3]float64, rpms ...float64) []*core.EnvironmentPrefillDecode {
+	envs := make([]*core.EnvironmentPrefillDecode, 0, len(rpms))
+	for _, rpm := range rpms {
+		ttft, itl := groundTruthMetrics(t, rpm, 512, 256, maxBatch, p)
+		envs = append(envs, core.NewEnvironmentPrefillDecode(float32(rpm), 0, 0, maxBatch, 512, 256, ttft, itl))
+	}
+	return envs
+}
+
+func TestTunerService_BootstrapFromHistory(t *testing.T) {
+	t.Setenv("CONFIG_DATA_DIR", "../../config-data")
+	const model, acc = "llama", "H100"
+	const maxBatch = 128
+	truth := [3]float64{12.0, 0.04, 0.00006}
+
+	history := &fakeHistory{envs: historyEnvs(t, maxBatch, truth, 30, 60, 90, 120)}
+	// No EKF warm-up, so IsWarmingUp reflects only the init phase.
+	ts := NewTunerService(0, 5, true, false, DefaultWindowSize, DefaultResidualThreshold, 0)
+	ts.SetHistorySource(history)
+
+	// Four history observations plus the first live one complete the init phase.
+	if _, err := ts.Tune([]optconfig.ServerSpec{sweepSpec(t, model, acc, 75, 512, 256, maxBatch, truth)}); err != nil {
+		t.Fatalf("Tune: %v", err)
+	}
+	if ts.GetParams(model, acc) == nil {
+		t.Fatal("expected parameters after the first tune cycle of a bootstrapped pair")
+	}
+	if ts.IsWarmingUp() {
+		t.Error("bootstrapped pair should not be warming up")
+	}
+
+	// History is consulted once per pair, not every cycle.
+	if _, err := ts.Tune([]optconfig.ServerSpec{sweepSpec(t, model, acc, 80, 512, 256, maxBatch, truth)}); err != nil {
+		t.Fatalf("second Tune: %v", err)
+	}
+	if len(history.calls) != 1 || history.calls[0] != makeKey(model, acc) {
+		t.Errorf("expected one history call for %s, got %v", makeKey(model, acc), history.calls)
+	}
+}
+
+func TestTunerService_BootstrapHistoryErrorCollectsNormally(t *testing.T) {
+	t.Setenv("CONFIG_DATA_DIR", "../../config-data")
+	const model, acc = "llama", "H100"
+	truth := [3]float64{12.0, 0.04, 0.00006}
+
+	ts := NewTunerService(3, 5, true, false, DefaultWindowSize, DefaultResidualThreshold, 0)
+	ts.SetHistorySource(&fakeHistory{err: errors.New("prometheus unavailable")})
+
+	_, _ = ts.Tune([]optconfig.ServerSpec{sweepSpec(t, model, acc, 60, 512, 256, 128, truth)})
+	if ts.GetParams(model, acc) != nil {
+		t.Fatal("expected no parameters while collecting")
+	}
+	if got := ts.estimators[makeKey(model, acc)].ObsCount(); got != 1 {
+		t.Errorf("expected 1 collected observation, got %d", got)
+	}
+	if !ts.IsWarmingUp() {
+		t.Error("expected pair to be warming up after a failed bootstrap")
+	}
+}
+
+// blockingHistory is a HistorySource that blocks until released and counts its calls.
+type blockingHistory struct {
+	envs             []*core.EnvironmentPrefillDecode
+	called, released chan struct{}
+	calls            atomic.Int32
+}
+
+func (h *blockingHistory) History(string, string) ([]*core.EnvironmentPrefillDecode, error) {
+	if h.calls.Add(1) == 1 {
+		close(h.called)
+	}
+	<-h.released
+	return h.envs, nil
+}
+
+func TestTunerService_BootstrapFetchesOutsideLock(t *testing.T) {
+	t.Setenv("CONFIG_DATA_DIR", "../../config-data")
+	const model, acc = "llama", "H100"
+	const maxBatch = 128
+	truth := [3]float64{12.0, 0.04, 0.00006}
+
+	history := &blockingHistory{
+		envs:     historyEnvs(t, maxBatch, truth, 30, 60, 90, 120),
+		called:   make(chan struct{}),
+		released: make(chan struct{}),
+	}
+	ts := NewTunerService(0, 5, true, false, DefaultWindowSize, DefaultResidualThreshold, 0)
+	if err := ts.AddShadow(EstimatorConfig{Name: "wide", Mode: EstimatorSlidingWindow, InitObs: 5, WindowSize: 10}); err != nil {
+		t.Fatal(err)
+	}
+	ts.SetHistorySource(history)
+
+	tuned := make(chan error, 1)
+	go func() {
+		_, err := ts.Tune([]optconfig.ServerSpec{sweepSpec(t, model, acc, 75, 512, 256, maxBatch, truth)})
+		tuned <- err
+	}()
+	<-history.called
+
+	// the service keeps answering while the history of the new pair is fetched
+	answered := make(chan bool, 1)
+	go func() { answered <- ts.IsWarmingUp() }()
+	select {
+	case <-answered:
+	case <-time.After(5 * time.Second):
+		t.Fatal("service blocked while fetching history")
+	}
+
+	close(history.released)
+	if err := <-tuned; err != nil {
+		t.Fatalf("Tune: %v", err)
+	}
+	if n := history.calls.Load(); n != 1 {
+		t.Errorf("history fetched %d times, want once for the primary and its shadow", n)
+	}
+	key := makeKey(model, acc)
+	if got := ts.shadows[0].estimators[key].ObsCount(); got != 5 {
+		t.Errorf("shadow collected %d observations, want the 4 from history and the live one", got)
+	}
+	if ts.GetParams(model, acc) == nil {
+		t.Error("expected parameters after the first tune cycle of a bootstrapped pair")
+	}
+}
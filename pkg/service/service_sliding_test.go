@@ -79,10 +79,14 @@ func TestTunerService_SWNM_SWENotReady_RetainsPreviousParams(t *testing.T) {
 	ts.slidingEstimators[key] = swe
 
 	env := makeTestEnv(15, 55, 6, 120, 700, 64)
-	err := ts.tuneGroupSliding(model, acc, key, ie, env)
+	var outcome GroupOutcome
+	err := ts.tuneGroupSliding(model, acc, key, ie, env, &outcome)
 	if err == nil {
 		t.Fatal("expected error when SWE not ready, got nil")
 	}
+	if outcome.Reason != OutcomeWindowFilling || outcome.WindowCount != 1 {
+		t.Errorf("outcome = %+v, want %s with 1 windowed observation", outcome, OutcomeWindowFilling)
+	}
 
 	params := ts.paramStore.Get(model, acc)
 	if params == nil {
@@ -216,7 +220,7 @@ func TestTunerService_SWNM_IllConditioned_ExcursionEmitsFeasibleParams(t *testin
 	ts.slidingEstimators[key] = swe
 
 	env := makeTestEnv(15, 55, 6, 120, 700, 64)
-	if err := ts.tuneGroupSliding(model, acc, key, ie, env); err != nil {
+	if err := ts.tuneGroupSliding(model, acc, key, ie, env, &GroupOutcome{}); err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 	if !swe.HeldLastGoodFit() {
@@ -0,0 +1,373 @@
+package service
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	optconfig "github.com/llm-inferno/optimizer-light/pkg/config"
+
+	"github.com/llm-inferno/model-tuner/pkg/core"
+)
+
+// DefaultEstimatorName names the estimator configuration a TunerService starts with.
+const DefaultEstimatorName = "default"
+
+// ShadowErrorWindow is the number of tune cycles per pair behind the rolling one-step-ahead
+// prediction error.
+const ShadowErrorWindow = 20
+
+// Errors of PromoteShadow, returned wrapped.
+var (
+	ErrUnknownShadow  = errors.New("no such shadow estimator")
+	ErrShadowNotReady = errors.New("shadow estimator not ready")
+)
+
+// EstimatorConfig is the configuration of one estimator: the primary, whose parameters the
+// optimizer gets, or a shadow run alongside it on the same observations.
+type EstimatorConfig struct {
+	Name              string  `json:"name"`
+	Mode              string  `json:"mode"` // EstimatorEKF or EstimatorSlidingWindow
+	InitObs           int     `json:"initObs"`
+	WindowSize        int     `json:"windowSize"`
+	ResidualThreshold float64 `json:"residualThreshold"`
+	InitFitThreshold  float64 `json:"initFitThreshold"`
+}
+
+// Validate reports the first field out of range.
+func (c EstimatorConfig) Validate() error {
+	switch {
+	case c.Name == "":
+		return fmt.Errorf("estimator name is required")
+	case c.Mode != EstimatorEKF && c.Mode != EstimatorSlidingWindow:
+		return fmt.Errorf("estimator %s: mode must be %q or %q", c.Name, EstimatorEKF, EstimatorSlidingWindow)
+	case c.InitObs < 1:
+		return fmt.Errorf("estimator %s: initObs must be positive", c.Name)
+	case c.WindowSize < 1:
+		return fmt.Errorf("estimator %s: windowSize must be positive", c.Name)
+	case c.ResidualThreshold < 0 || c.InitFitThreshold < 0:
+		return fmt.Errorf("estimator %s: thresholds must not be negative", c.Name)
+	}
+	return nil
+}
+
+// ParseShadowEstimators parses the TUNER_SHADOW_ESTIMATORS format: configurations separated by
+// ";", each a name followed by ":" and comma-separated key=value overrides of base, with keys
+// mode, initObs, windowSize, residualThreshold and initFitThreshold. E.g.
+//
+//	wide:windowSize=20;ekf:mode=ekf,initObs=3
+//
+// An empty spec yields no shadows.
+func ParseShadowEstimators(spec string, base EstimatorConfig) ([]EstimatorConfig, error) {
+	var configs []EstimatorConfig
+	for entry := range strings.SplitSeq(spec, ";") {
+		if entry = strings.TrimSpace(entry); entry == "" {
+			continue
+		}
+		name, overrides, _ := strings.Cut(entry, ":")
+		c := base
+		c.Name = strings.TrimSpace(name)
+		for kv := range strings.SplitSeq(overrides, ",") {
+			if kv = strings.TrimSpace(kv); kv == "" {
+				continue
+			}
+			key, value, ok := strings.Cut(kv, "=")
+			if !ok {
+				return nil, fmt.Errorf("estimator %s: %q is not key=value", c.Name, kv)
+			}
+			var err error
+			switch key {
+			case "mode":
+				c.Mode = value
+			case "initObs":
+				c.InitObs, err = strconv.Atoi(value)
+			case "windowSize":
+				c.WindowSize, err = strconv.Atoi(value)
+			case "residualThreshold":
+				c.ResidualThreshold, err = strconv.ParseFloat(value, 64)
+			case "initFitThreshold":
+				c.InitFitThreshold, err = strconv.ParseFloat(value, 64)
+			default:
+				return nil, fmt.Errorf("estimator %s: unknown key %q", c.Name, key)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("estimator %s: %s: %w", c.Name, key, err)
+			}
+		}
+		if err := c.Validate(); err != nil {
+			return nil, err
+		}
+		configs = append(configs, c)
+	}
+	return configs, nil
+}
+
+// EstimatorConfig returns the configuration of the primary estimator.
+func (ts *TunerService) EstimatorConfig() EstimatorConfig {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return ts.estimatorConfig()
+}
+
+func (ts *TunerService) estimatorConfig() EstimatorConfig {
+	mode := EstimatorEKF
+	if ts.useSliding {
+		mode = EstimatorSlidingWindow
+	}
+	return EstimatorConfig{
+		Name:              ts.name,
+		Mode:              mode,
+		InitObs:           ts.initObs,
+		WindowSize:        ts.windowSize,
+		ResidualThreshold: ts.residualThreshold,
+		InitFitThreshold:  ts.initFitThreshold,
+	}
+}
+
+// AddShadow runs an estimator with configuration c alongside the primary: every Tune and
+// Calibrate is replayed into it, its parameters are stored separately, and before each cycle
+// both are scored on how well their current parameters predict the new observations. Shadows
+// never change what Tune returns. Wire shadows before the service handles any tune requests.
+func (ts *TunerService) AddShadow(c EstimatorConfig) error {
+	if err := c.Validate(); err != nil {
+		return err
+	}
+	ts.replayMu.Lock()
+	defer ts.replayMu.Unlock()
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	if c.Name == ts.name || slices.ContainsFunc(ts.shadows, func(s *TunerService) bool { return s.name == c.Name }) {
+		return fmt.Errorf("estimator %s already exists", c.Name)
+	}
+	shadow := NewTunerService(ts.warmUpCycles, c.InitObs, ts.holdBack, c.Mode == EstimatorSlidingWindow,
+		c.WindowSize, c.ResidualThreshold, c.InitFitThreshold)
+	shadow.name = c.Name
+	shadow.maxConditionNumber = ts.maxConditionNumber
+	shadow.history = ts.history
+	shadow.transferPrior = ts.transferPrior
+	shadow.catalog = ts.catalog
+	ts.shadows = append(ts.shadows, shadow)
+	return nil
+}
+
+// scoreEstimators records the one-step-ahead error of the primary and every shadow on the
+// groups' observations. Called with ts.mu held, before any estimator tunes on them.
+func (ts *TunerService) scoreEstimators(groups map[string][]optconfig.ServerSpec) {
+	if len(ts.shadows) == 0 {
+		return
+	}
+	for key, replicas := range groups {
+		envs := buildEnvironments(replicas)
+		ts.scoreOneStep(ts.name, key, ts.paramStore, envs)
+		for _, shadow := range ts.shadows {
+			ts.scoreOneStep(shadow.name, key, shadow.paramStore, envs)
+		}
+	}
+}
+
+// tuneShadows replays a tune cycle into every shadow, bootstrapping the pairs new to a shadow
+// from the history fetched for the primary. Called with ts.replayMu held and ts.mu released, so
+// readers of the primary do not wait for the shadows' fits.
+func (ts *TunerService) tuneShadows(specs []optconfig.ServerSpec, history pairHistory) {
+	for _, shadow := range ts.shadows {
+		if _, _, err := shadow.tuneWithHistory(specs, history); err != nil {
+			slog.Debug("shadow estimator produced no results", "estimator", shadow.name, "err", err)
+		}
+	}
+}
+
+// calibrateShadows replays a calibration sweep into every shadow. Called with ts.replayMu held
+// and ts.mu released.
+func (ts *TunerService) calibrateShadows(specs []optconfig.ServerSpec) {
+	for _, shadow := range ts.shadows {
+		if _, err := shadow.Calibrate(specs); err != nil {
+			slog.Debug("shadow estimator calibration failed", "estimator", shadow.name, "err", err)
+		}
+	}
+}
+
+// scoreOneStep records the one-step-ahead error of the parameters in store for the pair key on
+// envs: the mean squared relative TTFT and ITL error over the points the model can evaluate.
+// Nothing is recorded if the store has no parameters for the pair or no point evaluates.
+func (ts *TunerService) scoreOneStep(name, key string, store *ParameterStore, envs []*core.EnvironmentPrefillDecode) {
+	params := store.Get(splitKey(key))
+	if params == nil {
+		return
+	}
+	var sumSq float64
+	var n int
+	for _, env := range envs {
+		metrics, err := core.AnalyzePrefillDecode(env, params.Alpha, params.Beta, params.Gamma)
+		if err != nil || metrics.AvgTTFT <= 0 || metrics.AvgTokenTime <= 0 {
+			continue
+		}
+		dTTFT := float64((metrics.AvgTTFT - env.AvgTTFT) / env.AvgTTFT)
+		dITL := float64((metrics.AvgTokenTime - env.AvgITL) / env.AvgITL)
+		sumSq += dTTFT*dTTFT + dITL*dITL
+		n += 2
+	}
+	if n == 0 {
+		return
+	}
+	if ts.oneStepErrors[name] == nil {
+		ts.oneStepErrors[name] = make(map[string][]float64)
+	}
+	window := append(ts.oneStepErrors[name][key], sumSq/float64(n))
+	if len(window) > ShadowErrorWindow {
+		window = window[len(window)-ShadowErrorWindow:]
+	}
+	ts.oneStepErrors[name][key] = window
+}
+
+// ShadowComparison compares the primary estimator with its shadows on their rolling one-step-
+// ahead prediction error: how well the parameters each held before a cycle predicted the TTFT and
+// ITL observed in it, as the RMS relative error over the last ShadowErrorWindow cycles per pair.
+type ShadowComparison struct {
+	Window     int              `json:"window"`     // cycles per pair in the rolling error
+	Estimators []EstimatorScore `json:"estimators"` // the primary first, then the shadows
+	Pairs      []PairComparison `json:"pairs"`      // sorted by model and accelerator
+}
+
+// EstimatorScore is the rolling error of one estimator over all pairs.
+type EstimatorScore struct {
+	EstimatorConfig
+	Primary  bool    `json:"primary"`
+	Cycles   int     `json:"cycles"`   // pair-cycles scored
+	RMSError float64 `json:"rmsError"` // RMS relative TTFT/ITL error; 0 if no cycle was scored
+}
+
+// PairComparison holds the estimators' parameters and rolling errors for one pair.
+type PairComparison struct {
+	Model       string      `json:"model"`
+	Accelerator string      `json:"accelerator"`
+	Estimators  []PairScore `json:"estimators"` // in the order of ShadowComparison.Estimators
+}
+
+// PairScore is one estimator's current parameters and rolling error for one pair. Only cycles
+// the estimator entered with parameters are scored, so Cycles can differ between estimators.
+type PairScore struct {
+	Name      string  `json:"name"`
+	HasParams bool    `json:"hasParams"`
+	Alpha     float32 `json:"alpha"`
+	Beta      float32 `json:"beta"`
+	Gamma     float32 `json:"gamma"`
+	Cycles    int     `json:"cycles"`
+	RMSError  float64 `json:"rmsError"`
+}
+
+// CompareShadows reports the rolling one-step-ahead error of the primary and every shadow,
+// overall and per pair. Without shadows only the primary is listed, with no cycles scored.
+func (ts *TunerService) CompareShadows() *ShadowComparison {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return ts.compareShadows()
+}
+
+func (ts *TunerService) compareShadows() *ShadowComparison {
+	estimators := append([]*TunerService{ts}, ts.shadows...)
+	keys := map[string]bool{}
+	for _, e := range estimators {
+		for key := range e.paramStore.GetAll() {
+			keys[key] = true
+		}
+	}
+
+	c := &ShadowComparison{Window: ShadowErrorWindow}
+	for _, e := range estimators {
+		score := EstimatorScore{EstimatorConfig: e.estimatorConfig(), Primary: e == ts}
+		var sum float64
+		for _, window := range ts.oneStepErrors[e.name] {
+			for _, v := range window {
+				sum += v
+			}
+			score.Cycles += len(window)
+		}
+		score.RMSError = rms(sum, score.Cycles)
+		c.Estimators = append(c.Estimators, score)
+	}
+	for key := range keys {
+		model, accelerator := splitKey(key)
+		pair := PairComparison{Model: model, Accelerator: accelerator}
+		for _, e := range estimators {
+			score := PairScore{Name: e.name}
+			if params := e.paramStore.Get(model, accelerator); params != nil {
+				score.HasParams = true
+				score.Alpha, score.Beta, score.Gamma = params.Alpha, params.Beta, params.Gamma
+			}
+			window := ts.oneStepErrors[e.name][key]
+			var sum float64
+			for _, v := range window {
+				sum += v
+			}
+			score.Cycles, score.RMSError = len(window), rms(sum, len(window))
+			pair.Estimators = append(pair.Estimators, score)
+		}
+		c.Pairs = append(c.Pairs, pair)
+	}
+	slices.SortFunc(c.Pairs, func(a, b PairComparison) int {
+		return cmp.Or(cmp.Compare(a.Model, b.Model), cmp.Compare(a.Accelerator, b.Accelerator))
+	})
+	return c
+}
+
+// rms returns the root of the mean of n values summing to sumSq; 0 for n = 0.
+func rms(sumSq float64, n int) float64 {
+	if n == 0 {
+		return 0
+	}
+	return math.Sqrt(sumSq / float64(n))
+}
+
+// PromoteShadow makes the shadow estimator name the primary. Its configuration, estimator state
+// and parameters take the primary's place, so the next Merge or Tune serves its parameters and
+// watchers receive them; the former primary continues as a shadow under its own name, so
+// promoting it back rolls the change back. Rolling errors stay with their estimator. The
+// shadow must hold parameters for every pair the primary has, so no pair loses its parameters.
+func (ts *TunerService) PromoteShadow(name string) (*ShadowComparison, error) {
+	ts.replayMu.Lock()
+	defer ts.replayMu.Unlock()
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	i := slices.IndexFunc(ts.shadows, func(s *TunerService) bool { return s.name == name })
+	if i < 0 {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownShadow, name)
+	}
+	shadow := ts.shadows[i]
+	shadow.mu.Lock()
+	defer shadow.mu.Unlock()
+
+	challenger := shadow.paramStore.GetAll()
+	for key := range ts.paramStore.GetAll() {
+		if challenger[key] == nil {
+			return nil, fmt.Errorf("%w: %s has no parameters for %s yet", ErrShadowNotReady, name, key)
+		}
+	}
+	primary := ts.paramStore.GetAll()
+
+	ts.name, shadow.name = shadow.name, ts.name
+	ts.initObs, shadow.initObs = shadow.initObs, ts.initObs
+	ts.useSliding, shadow.useSliding = shadow.useSliding, ts.useSliding
+	ts.windowSize, shadow.windowSize = shadow.windowSize, ts.windowSize
+	ts.residualThreshold, shadow.residualThreshold = shadow.residualThreshold, ts.residualThreshold
+	ts.initFitThreshold, shadow.initFitThreshold = shadow.initFitThreshold, ts.initFitThreshold
+	ts.estimators, shadow.estimators = shadow.estimators, ts.estimators
+	ts.slidingEstimators, shadow.slidingEstimators = shadow.slidingEstimators, ts.slidingEstimators
+	ts.ekfFallbacks, shadow.ekfFallbacks = shadow.ekfFallbacks, ts.ekfFallbacks
+	ts.calibrated, shadow.calibrated = shadow.calibrated, ts.calibrated
+	ts.priors, shadow.priors = shadow.priors, ts.priors
+	ts.paramStore.Replace(challenger)
+	shadow.paramStore.Replace(primary)
+	ts.republish()
+	shadow.republish()
+	for key := range ts.paramStore.GetAll() {
+		ts.touch(key)
+	}
+
+	slog.Info("promoted shadow estimator", "estimator", ts.name, "previous", shadow.name)
+	return ts.compareShadows(), nil
+}
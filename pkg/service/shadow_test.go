@@ -0,0 +1,194 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	optconfig "github.com/llm-inferno/optimizer-light/pkg/config"
+
+	"github.com/llm-inferno/model-tuner/pkg/core"
+)
+
+func TestParseShadowEstimators(t *testing.T) {
+	base := EstimatorConfig{Name: DefaultEstimatorName, Mode: EstimatorEKF, InitObs: 5, WindowSize: 10,
+		ResidualThreshold: 0.5, InitFitThreshold: 10}
+	got, err := ParseShadowEstimators(" wide:mode=sliding-window,windowSize=20 ; strict:residualThreshold=0.2,initObs=3;plain", base)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 3 {
+		t.Fatalf("got %+v, want three configurations", got)
+	}
+	if want := (EstimatorConfig{Name: "wide", Mode: EstimatorSlidingWindow, InitObs: 5, WindowSize: 20,
+		ResidualThreshold: 0.5, InitFitThreshold: 10}); got[0] != want {
+		t.Errorf("wide = %+v, want %+v", got[0], want)
+	}
+	if got[1].ResidualThreshold != 0.2 || got[1].InitObs != 3 || got[1].Mode != EstimatorEKF {
+		t.Errorf("strict = %+v, want overrides on the base", got[1])
+	}
+	if got[2].Name != "plain" || got[2].WindowSize != 10 {
+		t.Errorf("plain = %+v, want the base under a new name", got[2])
+	}
+	if got, err := ParseShadowEstimators("", base); err != nil || got != nil {
+		t.Errorf("empty spec = (%+v, %v), want none", got, err)
+	}
+	for _, bad := range []string{":windowSize=3", "a:mode=kalman", "a:windowSize=x", "a:color=red", "a:windowSize", "a:initObs=0"} {
+		if _, err := ParseShadowEstimators(bad, base); err == nil {
+			t.Errorf("expected error for %q", bad)
+		}
+	}
+}
+
+// shadowTestSpecs returns observations generated by the model itself at spread token profiles.
+func shadowTestSpecs(t *testing.T, cycles int) [][]optconfig.ServerSpec {
+	t.Helper()
+	profiles := [][2]int{{512, 128}, {1024, 256}, {2048, 512}, {1024, 128}, {768, 384}}
+	out := make([][]optconfig.ServerSpec, cycles)
+	for i := range out {
+		tokens := profiles[i%len(profiles)]
+		env := makeTestEnv(60, 0, 0, float32(tokens[0]), float32(tokens[1]), 64)
+		metrics, err := core.AnalyzePrefillDecode(env, 7.7, 0.067, 5.5e-5)
+		if err != nil {
+			t.Fatal(err)
+		}
+		out[i] = []optconfig.ServerSpec{makeTestSpec("llama", "H100", 60, metrics.AvgTTFT, metrics.AvgTokenTime, tokens[0], tokens[1], 64)}
+	}
+	return out
+}
+
+func TestShadows_DoNotChangeTune(t *testing.T) {
+	t.Setenv("CONFIG_DATA_DIR", "../../config-data")
+	alone := NewTunerService(0, 1, false, false, DefaultWindowSize, DefaultResidualThreshold, 0)
+	ts := NewTunerService(0, 1, false, false, DefaultWindowSize, DefaultResidualThreshold, 0)
+	if err := ts.AddShadow(EstimatorConfig{Name: "wide", Mode: EstimatorSlidingWindow, InitObs: 3, WindowSize: 10}); err != nil {
+		t.Fatal(err)
+	}
+	if err := ts.AddShadow(EstimatorConfig{Name: DefaultEstimatorName, Mode: EstimatorEKF, InitObs: 1, WindowSize: 1}); err == nil {
+		t.Error("expected error for a shadow named like the primary")
+	}
+
+	for i, specs := range shadowTestSpecs(t, 6) {
+		want, errAlone := alone.Tune(specs)
+		got, err := ts.Tune(specs)
+		if (err == nil) != (errAlone == nil) || (err == nil && got.PerfData[0].PerfParms != want.PerfData[0].PerfParms) {
+			t.Fatalf("cycle %d: Tune = (%+v, %v) with shadows, (%+v, %v) without", i, got, err, want, errAlone)
+		}
+	}
+
+	c := ts.CompareShadows()
+	if len(c.Estimators) != 2 || !c.Estimators[0].Primary || c.Estimators[0].Name != DefaultEstimatorName || c.Estimators[1].Name != "wide" {
+		t.Fatalf("estimators = %+v, want the primary then the shadow", c.Estimators)
+	}
+	// the primary has parameters after the first cycle, the shadow after its third
+	if c.Estimators[0].Cycles != 5 || c.Estimators[1].Cycles != 3 {
+		t.Errorf("cycles = %d, %d, want 5 and 3", c.Estimators[0].Cycles, c.Estimators[1].Cycles)
+	}
+	if len(c.Pairs) != 1 || len(c.Pairs[0].Estimators) != 2 || !c.Pairs[0].Estimators[1].HasParams {
+		t.Fatalf("pairs = %+v, want one pair scored by both", c.Pairs)
+	}
+	// the sliding window fits the model's own observations almost exactly
+	if wide := c.Pairs[0].Estimators[1]; wide.RMSError > 0.05 || wide.RMSError >= c.Pairs[0].Estimators[0].RMSError {
+		t.Errorf("pair scores = %+v, want the shadow ahead of the primary", c.Pairs[0].Estimators)
+	}
+}
+
+func TestShadows_ReplayOutsidePrimaryLock(t *testing.T) {
+	t.Setenv("CONFIG_DATA_DIR", "../../config-data")
+	ts := NewTunerService(0, 1, false, false, DefaultWindowSize, DefaultResidualThreshold, 0)
+	if err := ts.AddShadow(EstimatorConfig{Name: "wide", Mode: EstimatorEKF, InitObs: 1, WindowSize: 10}); err != nil {
+		t.Fatal(err)
+	}
+	shadow := ts.shadows[0]
+
+	// Hold the shadow so the cycle's replay waits on it; the primary's readers must not.
+	shadow.mu.Lock()
+	locked := true
+	defer func() {
+		if locked {
+			shadow.mu.Unlock()
+		}
+	}()
+	done := make(chan error, 1)
+	go func() {
+		_, err := ts.Tune(shadowTestSpecs(t, 1)[0])
+		done <- err
+	}()
+	for deadline := time.Now().Add(5 * time.Second); ; time.Sleep(5 * time.Millisecond) {
+		read := make(chan bool, 1)
+		go func() {
+			read <- ts.Prior("llama", "H100") != nil && ts.Freshness("llama", "H100") != "" &&
+				len(ts.CompareShadows().Pairs) == 1
+		}()
+		select {
+		case tuned := <-read:
+			if !tuned {
+				continue
+			}
+		case <-time.After(time.Until(deadline)):
+			t.Fatal("readers blocked behind the shadow replay")
+		}
+		break
+	}
+	select {
+	case <-done:
+		t.Fatal("tune returned before its shadow replay")
+	default:
+	}
+
+	shadow.mu.Unlock()
+	locked = false
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	if shadow.GetParams("llama", "H100") == nil {
+		t.Error("shadow did not replay the cycle")
+	}
+}
+
+func TestPromoteShadow(t *testing.T) {
+	t.Setenv("CONFIG_DATA_DIR", "../../config-data")
+	ts := NewTunerService(0, 1, false, false, DefaultWindowSize, DefaultResidualThreshold, 0)
+	if err := ts.AddShadow(EstimatorConfig{Name: "wide", Mode: EstimatorSlidingWindow, InitObs: 3, WindowSize: 10}); err != nil {
+		t.Fatal(err)
+	}
+	specs := shadowTestSpecs(t, 5)
+	ts.Tune(specs[0])
+
+	if _, err := ts.PromoteShadow("narrow"); !errors.Is(err, ErrUnknownShadow) {
+		t.Errorf("unknown shadow: %v, want ErrUnknownShadow", err)
+	}
+	if _, err := ts.PromoteShadow("wide"); !errors.Is(err, ErrShadowNotReady) {
+		t.Errorf("shadow without parameters: %v, want ErrShadowNotReady", err)
+	}
+	for _, s := range specs[1:] {
+		ts.Tune(s)
+	}
+
+	_, updates, cancel := ts.WatchParams(4)
+	defer cancel()
+	c, err := ts.PromoteShadow("wide")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if primary := c.Estimators[0]; !primary.Primary || primary.Name != "wide" || primary.Mode != EstimatorSlidingWindow || primary.Cycles != 2 {
+		t.Errorf("primary after promotion = %+v, want the sliding-window challenger with its own history", primary)
+	}
+	if previous := c.Estimators[1]; previous.Name != DefaultEstimatorName || previous.Mode != EstimatorEKF || previous.Cycles != 4 {
+		t.Errorf("shadow after promotion = %+v, want the former primary", previous)
+	}
+	update := <-updates
+	if want := c.Pairs[0].Estimators[0]; update.Params.Alpha != want.Alpha || ts.GetParams("llama", "H100").Alpha != want.Alpha {
+		t.Errorf("served alpha %g, want the challenger's %g", ts.GetParams("llama", "H100").Alpha, want.Alpha)
+	}
+
+	// the promoted estimator keeps tuning as the primary
+	if _, outcomes, err := ts.TuneWithOutcomes(specs[0]); err != nil || outcomes[0].Estimator != EstimatorSlidingWindow || !outcomes[0].Fresh {
+		t.Errorf("Tune after promotion = (%+v, %v), want a fresh sliding-window fit", outcomes, err)
+	}
+
+	// and promoting the former primary rolls back
+	if c, err := ts.PromoteShadow(DefaultEstimatorName); err != nil || c.Estimators[0].Name != DefaultEstimatorName {
+		t.Errorf("rollback = (%+v, %v), want the default estimator primary again", c, err)
+	}
+}
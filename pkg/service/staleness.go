@@ -0,0 +1,240 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"log/slog"
+	"maps"
+	"slices"
+	"time"
+
+	optconfig "github.com/llm-inferno/optimizer-light/pkg/config"
+)
+
+// Freshness states of a pair's parameters.
+const (
+	FreshnessFresh = "fresh" // observed within the staleness TTL, or no TTL is set
+	FreshnessStale = "stale" // not observed for longer than the staleness TTL
+)
+
+// StalenessPolicy ages the parameters of pairs that stopped being observed, e.g. because their
+// model was scaled to zero. A pair's age runs from its last tune cycle, whatever the outcome (a
+// fit rejected by the NIS gate or a window still filling counts), or from its last parameter
+// update if later. Pairs older than StaleAfter are reported stale, and Merge leaves them out if
+// ExcludeStale is set; pairs older than ExpireAfter are evicted together with their estimators,
+// so the pair starts over when it comes back. Zero durations disable either.
+type StalenessPolicy struct {
+	StaleAfter   time.Duration
+	ExpireAfter  time.Duration
+	ExcludeStale bool
+}
+
+// Validate checks that the TTLs are non-negative and a pair turns stale before it expires.
+func (p StalenessPolicy) Validate() error {
+	if p.StaleAfter < 0 || p.ExpireAfter < 0 {
+		return errors.New("staleness TTLs must be non-negative")
+	}
+	if p.StaleAfter > 0 && p.ExpireAfter > 0 && p.ExpireAfter < p.StaleAfter {
+		return errors.New("expiry TTL must not be shorter than the staleness TTL")
+	}
+	return nil
+}
+
+// SetStalenessPolicy sets how parameters age. Call it before the service is used concurrently.
+func (ts *TunerService) SetStalenessPolicy(p StalenessPolicy) error {
+	if err := p.Validate(); err != nil {
+		return err
+	}
+	ts.staleness = p
+	return nil
+}
+
+// Freshness returns the freshness state of the pair under the staleness policy.
+func (ts *TunerService) Freshness(model, accelerator string) string {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return ts.freshness(makeKey(model, accelerator))
+}
+
+// freshness is Freshness by pair key. Called with ts.mu held.
+func (ts *TunerService) freshness(key string) string {
+	if ts.staleness.StaleAfter > 0 && time.Since(ts.lastSeen(key)) > ts.staleness.StaleAfter {
+		return FreshnessStale
+	}
+	return FreshnessFresh
+}
+
+// lastSeen returns when the pair was last observed, or its parameters last updated if later. Called
+// with ts.mu held.
+func (ts *TunerService) lastSeen(key string) time.Time {
+	seen := ts.lastObserved[key]
+	if params := ts.paramStore.Get(splitKey(key)); params != nil && params.LastUpdated.After(seen) {
+		seen = params.LastUpdated
+	}
+	return seen
+}
+
+// PairFreshness is the freshness of one pair's parameters in a Merge.
+type PairFreshness struct {
+	Model       string    `json:"model"`
+	Accelerator string    `json:"accelerator"`
+	Freshness   string    `json:"freshness"` // FreshnessFresh or FreshnessStale
+	LastUpdated time.Time `json:"lastUpdated"`
+	Merged      bool      `json:"merged"` // false if the stale parameters were left out
+}
+
+// EvictExpired drops every pair, in the primary and the shadows, that was not observed for the
+// policy's ExpireAfter: its parameters, estimators, cold-start prior, calibration state and
+// rolling errors. Pairs that never got parameters are evicted too. Watchers are not notified. It
+// returns the keys of the pairs evicted from the primary.
+func (ts *TunerService) EvictExpired() []string {
+	if ts.staleness.ExpireAfter <= 0 {
+		return nil
+	}
+	ts.replayMu.Lock()
+	defer ts.replayMu.Unlock()
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	cutoff := time.Now().Add(-ts.staleness.ExpireAfter)
+	evicted := ts.evictExpired(cutoff)
+	for _, shadow := range ts.shadows {
+		shadow.mu.Lock()
+		shadow.evictExpired(cutoff)
+		shadow.mu.Unlock()
+	}
+	for _, key := range evicted {
+		for _, errs := range ts.oneStepErrors {
+			delete(errs, key)
+		}
+	}
+	return evicted
+}
+
+// evictExpired drops the pairs last seen before cutoff and returns their keys, sorted.
+func (ts *TunerService) evictExpired(cutoff time.Time) []string {
+	keys := make(map[string]bool)
+	for key := range ts.paramStore.GetAll() {
+		keys[key] = true
+	}
+	for key := range ts.estimators {
+		keys[key] = true
+	}
+	for key := range ts.slidingEstimators {
+		keys[key] = true
+	}
+	var evicted []string
+	for _, key := range slices.Sorted(maps.Keys(keys)) {
+		if !ts.lastSeen(key).Before(cutoff) {
+			continue
+		}
+		model, accelerator := splitKey(key)
+		ts.paramStore.Delete(model, accelerator)
+		ts.published.Delete(model, accelerator)
+		delete(ts.estimators, key)
+		delete(ts.slidingEstimators, key)
+		delete(ts.ekfFallbacks, key)
+		delete(ts.calibrated, key)
+		delete(ts.recentFits, key)
+		delete(ts.priors, key)
+		delete(ts.stateUpdated, key)
+		delete(ts.stateVersion, key)
+		delete(ts.replicated, key)
+		delete(ts.lastObserved, key)
+		evicted = append(evicted, key)
+	}
+	return evicted
+}
+
+// RunEviction evicts expired pairs every interval until ctx is done.
+func (ts *TunerService) RunEviction(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for _, key := range ts.EvictExpired() {
+				slog.Info("evicted expired parameters", "key", key, "expireAfter", ts.staleness.ExpireAfter)
+			}
+		}
+	}
+}
+
+// MergeWithFreshness is Merge, also reporting the freshness of every pair with parameters that
+// matched or was appended, sorted by model and accelerator. Under ExcludeStale, stale parameters
+// are neither overlaid nor appended: a matching entry keeps the caller's PerfParms.
+func (ts *TunerService) MergeWithFreshness(modelData *optconfig.ModelData) (*optconfig.ModelData, []PairFreshness) {
+	if modelData == nil {
+		modelData = &optconfig.ModelData{}
+	}
+
+	allParams := ts.published.GetAll()
+	freshness := make(map[string]string, len(allParams))
+	ts.mu.Lock()
+	for key := range allParams {
+		freshness[key] = ts.freshness(key)
+	}
+	ts.mu.Unlock()
+	merge := func(key string) bool {
+		return !ts.staleness.ExcludeStale || freshness[key] == FreshnessFresh
+	}
+	matched := make(map[string]bool, len(allParams))
+	var pairs []PairFreshness
+	report := func(key string) {
+		model, accelerator := splitKey(key)
+		pairs = append(pairs, PairFreshness{Model: model, Accelerator: accelerator, Freshness: freshness[key],
+			LastUpdated: allParams[key].LastUpdated, Merged: merge(key)})
+	}
+
+	result := make([]optconfig.ModelAcceleratorPerfData, len(modelData.PerfData))
+	for i, entry := range modelData.PerfData {
+		result[i] = entry
+		key := makeKey(entry.Name, entry.Acc)
+		params, ok := allParams[key]
+		if !ok {
+			continue
+		}
+		if merge(key) {
+			result[i].PerfParms = optconfig.PerfParms{
+				Alpha: params.Alpha,
+				Beta:  params.Beta,
+				Gamma: params.Gamma,
+			}
+		}
+		if matched[key] {
+			slog.Warn("duplicate model/accelerator key in input ModelData", "key", key)
+		} else {
+			matched[key] = true
+			report(key)
+		}
+	}
+
+	for key, params := range allParams {
+		if matched[key] {
+			continue
+		}
+		report(key)
+		if !merge(key) {
+			continue
+		}
+		model, acc := splitKey(key)
+		result = append(result, optconfig.ModelAcceleratorPerfData{
+			Name:         model,
+			Acc:          acc,
+			AccCount:     DefaultAccCount,
+			MaxBatchSize: DefaultMaxBatchSize,
+			PerfParms: optconfig.PerfParms{
+				Alpha: params.Alpha,
+				Beta:  params.Beta,
+				Gamma: params.Gamma,
+			},
+		})
+	}
+
+	slices.SortFunc(pairs, func(a, b PairFreshness) int {
+		return cmp.Or(cmp.Compare(a.Model, b.Model), cmp.Compare(a.Accelerator, b.Accelerator))
+	})
+	return &optconfig.ModelData{PerfData: result}, pairs
+}
@@ -0,0 +1,150 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	optconfig "github.com/llm-inferno/optimizer-light/pkg/config"
+)
+
+func TestStalenessPolicy_Validate(t *testing.T) {
+	for _, good := range []StalenessPolicy{{}, {StaleAfter: time.Hour}, {StaleAfter: time.Hour, ExpireAfter: time.Hour}} {
+		if err := good.Validate(); err != nil {
+			t.Errorf("%+v: %v", good, err)
+		}
+	}
+	for _, bad := range []StalenessPolicy{{StaleAfter: -time.Second}, {StaleAfter: time.Hour, ExpireAfter: time.Minute}} {
+		if err := bad.Validate(); err == nil {
+			t.Errorf("expected error for %+v", bad)
+		}
+	}
+}
+
+func TestMergeWithFreshness(t *testing.T) {
+	ts := NewTunerService(0, 1, false, false, DefaultWindowSize, DefaultResidualThreshold, 0)
+	setParams(ts, "llama", "H100", &LearnedParameters{Alpha: 7.7, Beta: 0.067, Gamma: 5.5e-5, LastUpdated: time.Now()})
+	setParams(ts, "llama", "A100", &LearnedParameters{Alpha: 10, Beta: 0.05, Gamma: 1e-3, LastUpdated: time.Now().Add(-2 * time.Hour)})
+	setParams(ts, "granite", "H100", &LearnedParameters{Alpha: 5, Beta: 0.04, Gamma: 1e-4, LastUpdated: time.Now().Add(-2 * time.Hour)})
+	input := &optconfig.ModelData{PerfData: []optconfig.ModelAcceleratorPerfData{
+		{Name: "llama", Acc: "A100", PerfParms: optconfig.PerfParms{Alpha: 1, Beta: 1, Gamma: 1}},
+	}}
+
+	// without a TTL every pair is fresh
+	if _, pairs := ts.MergeWithFreshness(input); len(pairs) != 3 || pairs[1].Freshness != FreshnessFresh {
+		t.Fatalf("pairs = %+v, want three fresh pairs", pairs)
+	}
+
+	if err := ts.SetStalenessPolicy(StalenessPolicy{StaleAfter: time.Hour}); err != nil {
+		t.Fatal(err)
+	}
+	merged, pairs := ts.MergeWithFreshness(input)
+	if len(merged.PerfData) != 3 || merged.PerfData[0].PerfParms.Alpha != 10 {
+		t.Errorf("merged = %+v, want stale parameters still merged", merged.PerfData)
+	}
+	if pairs[0].Model != "granite" || pairs[0].Freshness != FreshnessStale || !pairs[0].Merged ||
+		pairs[1].Accelerator != "A100" || pairs[1].Freshness != FreshnessStale ||
+		pairs[2].Accelerator != "H100" || pairs[2].Freshness != FreshnessFresh {
+		t.Errorf("pairs = %+v, want granite and llama/A100 stale, sorted", pairs)
+	}
+	if got := ts.Freshness("llama", "A100"); got != FreshnessStale {
+		t.Errorf("Freshness = %q, want stale", got)
+	}
+
+	if err := ts.SetStalenessPolicy(StalenessPolicy{StaleAfter: time.Hour, ExcludeStale: true}); err != nil {
+		t.Fatal(err)
+	}
+	merged, pairs = ts.MergeWithFreshness(input)
+	if len(merged.PerfData) != 2 || merged.PerfData[0].PerfParms.Alpha != 1 || merged.PerfData[1].Name != "llama" {
+		t.Errorf("merged = %+v, want the caller's llama/A100 and the fresh llama/H100 only", merged.PerfData)
+	}
+	if len(pairs) != 3 || pairs[0].Merged || pairs[1].Merged || !pairs[2].Merged {
+		t.Errorf("pairs = %+v, want the stale pairs left out", pairs)
+	}
+}
+
+func TestEvictExpired(t *testing.T) {
+	t.Setenv("CONFIG_DATA_DIR", "../../config-data")
+	ts := NewTunerService(0, 1, false, false, DefaultWindowSize, DefaultResidualThreshold, 0)
+	if err := ts.AddShadow(EstimatorConfig{Name: "wide", Mode: EstimatorSlidingWindow, InitObs: 1, WindowSize: 10}); err != nil {
+		t.Fatal(err)
+	}
+	specs := shadowTestSpecs(t, 2)
+	for _, s := range specs {
+		if _, err := ts.Tune(s); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if ts.EvictExpired() != nil {
+		t.Error("evicted without an expiry TTL")
+	}
+	if err := ts.SetStalenessPolicy(StalenessPolicy{ExpireAfter: time.Hour}); err != nil {
+		t.Fatal(err)
+	}
+	if evicted := ts.EvictExpired(); evicted != nil {
+		t.Errorf("evicted %v, want nothing yet", evicted)
+	}
+
+	for _, s := range []*TunerService{ts, ts.shadows[0]} {
+		old := *s.paramStore.Get("llama", "H100")
+		old.LastUpdated = time.Now().Add(-2 * time.Hour)
+		s.paramStore.Set("llama", "H100", &old)
+		s.lastObserved["llama/H100"] = old.LastUpdated
+	}
+	if evicted := ts.EvictExpired(); len(evicted) != 1 || evicted[0] != "llama/H100" {
+		t.Fatalf("evicted %v, want llama/H100", evicted)
+	}
+	if ts.GetParams("llama", "H100") != nil || len(ts.estimators) != 0 || len(ts.CalibrationStatuses()) != 0 {
+		t.Error("evicted pair still has parameters or estimators")
+	}
+	if c := ts.CompareShadows(); len(c.Pairs) != 0 || c.Estimators[0].Cycles != 0 || c.Estimators[1].Cycles != 0 {
+		t.Errorf("comparison %+v, want the pair gone from the primary and the shadow", c)
+	}
+
+	// the pair starts over when it comes back
+	if _, outcomes, err := ts.TuneWithOutcomes(specs[0]); err != nil || outcomes[0].UpdateCount != 1 {
+		t.Errorf("Tune after eviction = (%+v, %v), want a first update", outcomes, err)
+	}
+}
+
+func TestStaleness_FromLastObservation(t *testing.T) {
+	t.Setenv("CONFIG_DATA_DIR", "../../config-data")
+	ts := NewTunerService(0, 3, true, false, DefaultWindowSize, DefaultResidualThreshold, 0)
+	if err := ts.SetStalenessPolicy(StalenessPolicy{StaleAfter: time.Hour, ExpireAfter: time.Hour}); err != nil {
+		t.Fatal(err)
+	}
+	specs := shadowTestSpecs(t, 4)
+	for _, s := range specs[:3] {
+		_, _ = ts.Tune(s)
+	}
+	if ts.GetParams("llama", "H100") == nil {
+		t.Fatal("expected parameters after the init phase")
+	}
+
+	// the parameters stop updating, e.g. every EKF update is rejected, while traffic continues
+	old := *ts.paramStore.Get("llama", "H100")
+	old.LastUpdated = time.Now().Add(-2 * time.Hour)
+	ts.paramStore.Set("llama", "H100", &old)
+	if got := ts.Freshness("llama", "H100"); got != FreshnessFresh {
+		t.Errorf("Freshness = %q, want an observed pair fresh", got)
+	}
+	if evicted := ts.EvictExpired(); evicted != nil {
+		t.Errorf("evicted %v, want an observed pair kept", evicted)
+	}
+
+	// a pair still collecting, with estimators and no parameters, expires once unobserved
+	collecting := specs[3]
+	for i := range collecting {
+		collecting[i].CurrentAlloc.Accelerator = "A100"
+	}
+	_, _ = ts.Tune(collecting)
+	if ts.estimators["llama/A100"] == nil || ts.GetParams("llama", "A100") != nil {
+		t.Fatal("expected llama/A100 to be collecting")
+	}
+	ts.lastObserved["llama/A100"] = time.Now().Add(-2 * time.Hour)
+	if evicted := ts.EvictExpired(); len(evicted) != 1 || evicted[0] != "llama/A100" {
+		t.Fatalf("evicted %v, want the collecting llama/A100", evicted)
+	}
+	if ts.estimators["llama/A100"] != nil || ts.estimators["llama/H100"] == nil {
+		t.Error("eviction dropped the wrong estimators")
+	}
+}
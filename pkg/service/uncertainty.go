@@ -0,0 +1,130 @@
+package service
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+
+	"gonum.org/v1/gonum/mat"
+
+	"github.com/llm-inferno/model-tuner/pkg/core"
+)
+
+// Sources of the parameter covariance behind a PredictionInterval.
+const (
+	UncertaintyEKF = "ekf" // the EKF state covariance
+	UncertaintyFit = "fit" // the Gauss-Newton covariance of a sliding-window or calibration fit
+)
+
+// PredictionSamples is the number of parameter draws Predict evaluates per operating point to
+// form a PredictionInterval.
+const PredictionSamples = 400
+
+// minUsableSamples is the fraction of draws that must evaluate for an interval to be reported.
+const minUsableSamples = 0.5
+
+// PredictionInterval bounds the TTFT and ITL of a Prediction at a confidence level: the central
+// quantiles of the queueing model evaluated over draws from the parameter uncertainty.
+type PredictionInterval struct {
+	Confidence float64 `json:"confidence"`
+	Source     string  `json:"source"`   // UncertaintyEKF or UncertaintyFit
+	TTFTLow    float32 `json:"ttftLow"`  // msec
+	TTFTHigh   float32 `json:"ttftHigh"` // msec
+	ITLLow     float32 `json:"itlLow"`   // msec
+	ITLHigh    float32 `json:"itlHigh"`  // msec
+}
+
+// predictionCovariance returns the covariance to propagate into prediction intervals, preferring
+// the EKF's, and its source; nil if the parameters carry none.
+func (lp *LearnedParameters) predictionCovariance() ([][]float64, string) {
+	switch {
+	case len(lp.Covariance) == 3:
+		return lp.Covariance, UncertaintyEKF
+	case len(lp.FitCovariance) == 3:
+		return lp.FitCovariance, UncertaintyFit
+	}
+	return nil, ""
+}
+
+// sampleParameters draws n parameter vectors around x with covariance cov, log-normally so every
+// draw is positive: the log-parameters are Gaussian with covariance cov_ij / (x_i x_j), the
+// first-order equivalent of cov. A covariance that is not positive semi-definite is clipped to
+// its non-negative eigenvalues. Returns nil if cov cannot be factorized.
+func sampleParameters(x []float64, cov [][]float64, n int, rng *rand.Rand) [][]float64 {
+	dim := len(x)
+	logCov := mat.NewSymDense(dim, nil)
+	for i := range dim {
+		for j := i; j < dim; j++ {
+			logCov.SetSym(i, j, (cov[i][j]+cov[j][i])/2/(x[i]*x[j]))
+		}
+	}
+	var eig mat.EigenSym
+	if !eig.Factorize(logCov, true) {
+		return nil
+	}
+	values := eig.Values(nil)
+	var vectors mat.Dense
+	eig.VectorsTo(&vectors)
+	// logCov = L L^T with L = V sqrt(diag(max(values, 0)))
+	scale := mat.NewDense(dim, dim, nil)
+	for j, v := range values {
+		for i := range dim {
+			scale.Set(i, j, vectors.At(i, j)*math.Sqrt(max(v, 0)))
+		}
+	}
+
+	draws := make([][]float64, n)
+	z := mat.NewVecDense(dim, nil)
+	var dz mat.VecDense
+	for s := range draws {
+		for i := range dim {
+			z.SetVec(i, rng.NormFloat64())
+		}
+		dz.MulVec(scale, z)
+		draw := make([]float64, dim)
+		for i := range dim {
+			draw[i] = x[i] * math.Exp(dz.AtVec(i))
+		}
+		draws[s] = draw
+	}
+	return draws
+}
+
+// predictionInterval evaluates env at each parameter draw and returns the central confidence
+// interval of TTFT and ITL, or nil if too few draws could be evaluated.
+func predictionInterval(env *core.EnvironmentPrefillDecode, draws [][]float64, confidence float64) *PredictionInterval {
+	ttfts := make([]float32, 0, len(draws))
+	itls := make([]float32, 0, len(draws))
+	for _, d := range draws {
+		metrics, err := core.AnalyzePrefillDecode(env, float32(d[0]), float32(d[1]), float32(d[2]))
+		if err != nil {
+			continue
+		}
+		ttfts = append(ttfts, metrics.AvgTTFT)
+		itls = append(itls, metrics.AvgTokenTime)
+	}
+	if len(ttfts) == 0 || float64(len(ttfts)) < minUsableSamples*float64(len(draws)) {
+		return nil
+	}
+	slices.Sort(ttfts)
+	slices.Sort(itls)
+	lo, hi := (1-confidence)/2, (1+confidence)/2
+	return &PredictionInterval{
+		Confidence: confidence,
+		TTFTLow:    quantile(ttfts, lo),
+		TTFTHigh:   quantile(ttfts, hi),
+		ITLLow:     quantile(itls, lo),
+		ITLHigh:    quantile(itls, hi),
+	}
+}
+
+// quantile returns the q-quantile of sorted values, interpolating between neighbours.
+func quantile(sorted []float32, q float64) float32 {
+	pos := q * float64(len(sorted)-1)
+	i := int(pos)
+	if i >= len(sorted)-1 {
+		return sorted[len(sorted)-1]
+	}
+	frac := float32(pos - float64(i))
+	return sorted[i] + frac*(sorted[i+1]-sorted[i])
+}
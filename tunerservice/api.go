@@ -0,0 +1,124 @@
+package tunerservice
+
+import (
+	"time"
+
+	optconfig "github.com/llm-inferno/optimizer-light/pkg/config"
+
+	pkgsvc "github.com/llm-inferno/model-tuner/pkg/service"
+)
+
+// Request and response bodies of the REST API, beyond the optimizer-light config types
+// ([]config.ServerSpec and config.ModelData) that /tune, /calibrate, /merge and /observe use.
+// The OpenAPI document (openapi.yaml, served at GET /openapi.yaml) describes the same shapes.
+
+// TuneResponse is the response of POST /tune: the ModelData (its "models" field inlined, so the
+// body still decodes as a config.ModelData) and the outcome of every group in the request.
+type TuneResponse struct {
+	optconfig.ModelData
+	Groups []pkgsvc.GroupOutcome `json:"groups"`
+}
+
+// MergeResponse is the response of POST /merge: the merged ModelData (its "models" field inlined,
+// so the body still decodes as a config.ModelData) and the freshness of every tuned pair in it.
+type MergeResponse struct {
+	optconfig.ModelData
+	Freshness []pkgsvc.PairFreshness `json:"freshness"`
+}
+
+// ParamsResponse is the response of GET /getparams.
+type ParamsResponse struct {
+	Model       string    `json:"model"`
+	Accelerator string    `json:"accelerator"`
+	Alpha       float32   `json:"alpha"`
+	Beta        float32   `json:"beta"`
+	Gamma       float32   `json:"gamma"`
+	NIS         float64   `json:"nis"`
+	UpdateCount int       `json:"updateCount"`
+	LastUpdated time.Time `json:"lastUpdated"`
+	Freshness   string    `json:"freshness"` // pkgsvc.FreshnessFresh or pkgsvc.FreshnessStale
+	// RawAlpha, RawBeta and RawGamma are the estimator's own fit, which the publication policy
+	// smoothed or limited into alpha, beta and gamma.
+	RawAlpha float32 `json:"rawAlpha"`
+	RawBeta  float32 `json:"rawBeta"`
+	RawGamma float32 `json:"rawGamma"`
+	// PriorSource is where the pair's cold-start prior came from (pkgsvc.PriorConfig,
+	// PriorCatalog, PriorSameModel or PriorSameAccelerator), and CatalogEntry the name of the
+	// matching catalog entry; both are empty for pairs restored without a prior.
+	PriorSource  string `json:"priorSource,omitempty"`
+	CatalogEntry string `json:"catalogEntry,omitempty"`
+}
+
+// WarmUpResponse is the response of GET /warmup.
+type WarmUpResponse struct {
+	WarmingUp bool `json:"warmingUp"`
+}
+
+// CalibrationStatusResponse is the response of GET /calibration-status.
+type CalibrationStatusResponse struct {
+	Statuses []pkgsvc.CalibrationStatus `json:"statuses"`
+}
+
+// ObserveResponse is the response of POST /observe.
+type ObserveResponse struct {
+	Pending int `json:"pending"`
+}
+
+// PredictRequest is the request of POST /predict.
+type PredictRequest struct {
+	Model       string                  `json:"model"`
+	Accelerator string                  `json:"accelerator"`
+	Points      []pkgsvc.OperatingPoint `json:"points"`
+	Confidence  float64                 `json:"confidence,omitempty"` // e.g. 0.9 for 90% intervals; 0 for none
+}
+
+// PredictResponse is the response of POST /predict: one prediction per requested point, in order.
+type PredictResponse struct {
+	Model       string              `json:"model"`
+	Accelerator string              `json:"accelerator"`
+	Predictions []pkgsvc.Prediction `json:"predictions"`
+}
+
+// CapacityRequest is the request of POST /capacity: a pair, the replica configuration and the
+// SLO targets.
+type CapacityRequest struct {
+	Model       string `json:"model"`
+	Accelerator string `json:"accelerator"`
+	pkgsvc.CapacityQuery
+}
+
+// CapacityResponse is the response of POST /capacity.
+type CapacityResponse struct {
+	Model       string `json:"model"`
+	Accelerator string `json:"accelerator"`
+	pkgsvc.Capacity
+}
+
+// DiagnosticsResponse is the response of GET /diagnostics.
+type DiagnosticsResponse struct {
+	Model       string `json:"model"`
+	Accelerator string `json:"accelerator"`
+	pkgsvc.Diagnostics
+}
+
+// PromoteShadowRequest is the request of POST /shadows/promote.
+type PromoteShadowRequest struct {
+	Name string `json:"name"` // the shadow estimator to make primary
+}
+
+// ReplicationRequest is the request of POST /replicate: pair states pushed by a peer instance.
+type ReplicationRequest struct {
+	Pairs []pkgsvc.PairState `json:"pairs"`
+}
+
+// ReplicationResponse is the response of POST /replicate.
+type ReplicationResponse struct {
+	Applied int `json:"applied"` // the pairs applied; the others were not newer than this instance's
+}
+
+// ErrorResponse is the body of every 4xx and 5xx response. A 422 of POST /tune also carries the
+// group outcomes, telling e.g. warm-up progress from rejected updates.
+type ErrorResponse struct {
+	Error  string                `json:"error"`
+	Groups []pkgsvc.GroupOutcome `json:"groups,omitempty"`
+}
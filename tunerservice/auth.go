@@ -0,0 +1,253 @@
+package tunerservice
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	tunerv1 "github.com/llm-inferno/model-tuner/api/tuner/v1"
+)
+
+// Scope is the permission an endpoint requires. ScopeMutate implies ScopeRead. The route table
+// in NewTunerServer assigns the REST scopes, and grpcScopes the gRPC ones.
+type Scope int
+
+const (
+	// ScopeRead admits the queries: /getparams, /warmup, /diagnostics, /calibration-status,
+	// /shadows, /predict, /capacity and /openapi.yaml.
+	ScopeRead Scope = iota
+	// ScopeMutate admits the requests that change tuner state: /tune, /merge, /calibrate,
+	// /shadows/promote, /observe and /replicate.
+	ScopeMutate
+)
+
+func (s Scope) String() string {
+	if s == ScopeMutate {
+		return "mutate"
+	}
+	return "read"
+}
+
+// AnyClient in a client list admits every client certificate that verifies against the client CA.
+const AnyClient = "*"
+
+// Authorization failures: no credentials accepted at all, or credentials without the scope.
+var (
+	errUnauthenticated = errors.New("missing or invalid credentials")
+	errForbidden       = errors.New("credentials lack the required scope")
+)
+
+// AuthConfig lists the credentials granted each scope: bearer tokens, and identities of verified
+// client certificates (subject common name, DNS or URI SAN, or AnyClient).
+type AuthConfig struct {
+	ReadTokens    []string
+	MutateTokens  []string
+	ReadClients   []string
+	MutateClients []string
+}
+
+// Authorizer checks the credentials of HTTP and gRPC requests against an AuthConfig. A nil
+// *Authorizer admits everything.
+type Authorizer struct {
+	readTokens, mutateTokens   [][sha256.Size]byte
+	readClients, mutateClients []string
+}
+
+// NewAuthorizer creates an Authorizer; config must grant at least one credential.
+func NewAuthorizer(config AuthConfig) (*Authorizer, error) {
+	a := &Authorizer{
+		readTokens:    hashTokens(config.ReadTokens),
+		mutateTokens:  hashTokens(config.MutateTokens),
+		readClients:   config.ReadClients,
+		mutateClients: config.MutateClients,
+	}
+	if len(a.readTokens)+len(a.mutateTokens)+len(a.readClients)+len(a.mutateClients) == 0 {
+		return nil, fmt.Errorf("authorization requires at least one token or client")
+	}
+	return a, nil
+}
+
+// RequiresClientCerts reports whether any scope is granted to client certificates.
+func (a *Authorizer) RequiresClientCerts() bool {
+	return a != nil && len(a.readClients)+len(a.mutateClients) > 0
+}
+
+// authorize checks a bearer token (may be empty) and the verified client certificate chains of
+// a request for scope.
+func (a *Authorizer) authorize(token string, chains [][]*x509.Certificate, scope Scope) error {
+	if a == nil {
+		return nil
+	}
+	var ids []string
+	if len(chains) > 0 && len(chains[0]) > 0 {
+		ids = certIdentities(chains[0][0])
+	}
+	if token == "" && len(ids) == 0 {
+		return errUnauthenticated
+	}
+	mutate := matchToken(a.mutateTokens, token) || matchClient(a.mutateClients, ids)
+	read := mutate || matchToken(a.readTokens, token) || matchClient(a.readClients, ids)
+	switch {
+	case mutate || (read && scope == ScopeRead):
+		return nil
+	case read:
+		return errForbidden
+	}
+	return errUnauthenticated
+}
+
+// authorizeHTTP returns a gin middleware admitting requests with scope. It consults ts.auth at
+// request time, so SetAuthorizer may be called after the routes are registered.
+func (ts *TunerServer) authorizeHTTP(scope Scope) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var chains [][]*x509.Certificate
+		if c.Request.TLS != nil {
+			chains = c.Request.TLS.VerifiedChains
+		}
+		token, _ := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		switch err := ts.auth.authorize(token, chains, scope); {
+		case errors.Is(err, errUnauthenticated):
+			c.Header("WWW-Authenticate", `Bearer realm="model-tuner"`)
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrorResponse{Error: err.Error()})
+		case err != nil:
+			c.AbortWithStatusJSON(http.StatusForbidden, ErrorResponse{Error: err.Error() + ": " + scope.String()})
+		}
+	}
+}
+
+// grpcScopes maps the tuner RPCs that change state to ScopeMutate; all others (including
+// server reflection) require ScopeRead.
+var grpcScopes = map[string]Scope{
+	tunerv1.Tuner_Tune_FullMethodName:          ScopeMutate,
+	tunerv1.Tuner_Merge_FullMethodName:         ScopeMutate,
+	tunerv1.Tuner_Calibrate_FullMethodName:     ScopeMutate,
+	tunerv1.Tuner_PromoteShadow_FullMethodName: ScopeMutate,
+}
+
+// authorizeGRPC checks the credentials of an RPC against a.
+func (a *Authorizer) authorizeGRPC(ctx context.Context, method string) error {
+	if a == nil {
+		return nil
+	}
+	var token string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if v := md.Get("authorization"); len(v) > 0 {
+			token, _ = strings.CutPrefix(v[0], "Bearer ")
+		}
+	}
+	var chains [][]*x509.Certificate
+	if p, ok := peer.FromContext(ctx); ok {
+		if info, ok := p.AuthInfo.(credentials.TLSInfo); ok {
+			chains = info.State.VerifiedChains
+		}
+	}
+	scope := grpcScopes[method]
+	switch err := a.authorize(token, chains, scope); {
+	case errors.Is(err, errUnauthenticated):
+		return status.Error(codes.Unauthenticated, err.Error())
+	case err != nil:
+		return status.Error(codes.PermissionDenied, err.Error()+": "+scope.String())
+	}
+	return nil
+}
+
+// grpcInterceptors returns interceptors that authorize every RPC against ts.auth.
+func (ts *TunerServer) grpcInterceptors() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			if err := ts.auth.authorizeGRPC(ctx, info.FullMethod); err != nil {
+				return nil, err
+			}
+			return handler(ctx, req)
+		}),
+		grpc.ChainStreamInterceptor(func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			if err := ts.auth.authorizeGRPC(ss.Context(), info.FullMethod); err != nil {
+				return err
+			}
+			return handler(srv, ss)
+		}),
+	}
+}
+
+// ReadTokensFile reads bearer tokens from a file, one per line; blank lines and lines starting
+// with '#' are skipped.
+func ReadTokensFile(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tokens: %w", err)
+	}
+	var tokens []string
+	for line := range strings.Lines(string(data)) {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "#") {
+			tokens = append(tokens, line)
+		}
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("no tokens in %s", path)
+	}
+	return tokens, nil
+}
+
+func hashTokens(tokens []string) [][sha256.Size]byte {
+	out := make([][sha256.Size]byte, 0, len(tokens))
+	for _, t := range tokens {
+		if t != "" {
+			out = append(out, sha256.Sum256([]byte(t)))
+		}
+	}
+	return out
+}
+
+// matchToken compares token against every granted token in constant time.
+func matchToken(hashes [][sha256.Size]byte, token string) bool {
+	if token == "" {
+		return false
+	}
+	sum := sha256.Sum256([]byte(token))
+	match := 0
+	for _, h := range hashes {
+		match |= subtle.ConstantTimeCompare(h[:], sum[:])
+	}
+	return match == 1
+}
+
+func matchClient(allowed, ids []string) bool {
+	if len(ids) == 0 {
+		return false
+	}
+	for _, a := range allowed {
+		if a == AnyClient || slices.Contains(ids, a) {
+			return true
+		}
+	}
+	return false
+}
+
+// certIdentities returns the names a client certificate can be granted under.
+func certIdentities(cert *x509.Certificate) []string {
+	var ids []string
+	if cert.Subject.CommonName != "" {
+		ids = append(ids, cert.Subject.CommonName)
+	}
+	ids = append(ids, cert.DNSNames...)
+	for _, u := range cert.URIs {
+		ids = append(ids, u.String())
+	}
+	return ids
+}
@@ -0,0 +1,154 @@
+package tunerservice
+
+import (
+	"context"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	tunerv1 "github.com/llm-inferno/model-tuner/api/tuner/v1"
+)
+
+func testAuthorizer(t *testing.T) *Authorizer {
+	t.Helper()
+	a, err := NewAuthorizer(AuthConfig{
+		ReadTokens:    []string{"reader"},
+		MutateTokens:  []string{"writer"},
+		ReadClients:   []string{"dashboard"},
+		MutateClients: []string{"spiffe://cluster/controller"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return a
+}
+
+func TestAuthorizer_Scopes(t *testing.T) {
+	a := testAuthorizer(t)
+	chain := func(cert *x509.Certificate) [][]*x509.Certificate { return [][]*x509.Certificate{{cert}} }
+	dashboard := chain(&x509.Certificate{Subject: pkix.Name{CommonName: "dashboard"}})
+	controller := chain(&x509.Certificate{URIs: []*url.URL{{Scheme: "spiffe", Host: "cluster", Path: "/controller"}}})
+	stranger := chain(&x509.Certificate{DNSNames: []string{"stranger.local"}})
+
+	tests := []struct {
+		name         string
+		token        string
+		chains       [][]*x509.Certificate
+		read, mutate error
+	}{
+		{"no credentials", "", nil, errUnauthenticated, errUnauthenticated},
+		{"unknown token", "guess", nil, errUnauthenticated, errUnauthenticated},
+		{"read token", "reader", nil, nil, errForbidden},
+		{"mutate token", "writer", nil, nil, nil},
+		{"read client", "", dashboard, nil, errForbidden},
+		{"mutate client", "", controller, nil, nil},
+		{"unknown client", "", stranger, errUnauthenticated, errUnauthenticated},
+		{"read client with mutate token", "writer", dashboard, nil, nil},
+	}
+	for _, tt := range tests {
+		if err := a.authorize(tt.token, tt.chains, ScopeRead); !errors.Is(err, tt.read) {
+			t.Errorf("%s: read = %v, want %v", tt.name, err, tt.read)
+		}
+		if err := a.authorize(tt.token, tt.chains, ScopeMutate); !errors.Is(err, tt.mutate) {
+			t.Errorf("%s: mutate = %v, want %v", tt.name, err, tt.mutate)
+		}
+	}
+
+	var open *Authorizer
+	if err := open.authorize("", nil, ScopeMutate); err != nil {
+		t.Errorf("nil authorizer: %v, want everything admitted", err)
+	}
+	if _, err := NewAuthorizer(AuthConfig{}); err == nil {
+		t.Error("expected error for an empty config")
+	}
+}
+
+func TestHandlers_Authorization(t *testing.T) {
+	ts := newTestServer(t)
+	ts.SetAuthorizer(testAuthorizer(t))
+	request := func(method, path, token string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(method, path, nil)
+		if method == http.MethodPost {
+			req = httptest.NewRequest(method, path, strings.NewReader(`{"models": []}`))
+		}
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		ts.router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := request(http.MethodGet, "/warmup", "")
+	if w.Code != http.StatusUnauthorized || w.Header().Get("WWW-Authenticate") == "" {
+		t.Errorf("no token: %d, want 401 with a challenge", w.Code)
+	}
+	if w := request(http.MethodGet, "/warmup", "reader"); w.Code != http.StatusOK {
+		t.Errorf("read token on /warmup: %d, want 200", w.Code)
+	}
+	if w := request(http.MethodPost, "/merge", "reader"); w.Code != http.StatusForbidden {
+		t.Errorf("read token on /merge: %d, want 403", w.Code)
+	}
+	if w := request(http.MethodPost, "/merge", "writer"); w.Code != http.StatusOK {
+		t.Errorf("mutate token on /merge: %d %s, want 200", w.Code, w.Body.String())
+	}
+	if w := request(http.MethodGet, "/calibration-status", "writer"); w.Code != http.StatusOK {
+		t.Errorf("mutate token on /calibration-status: %d, want 200", w.Code)
+	}
+}
+
+func TestGRPCServer_Authorization(t *testing.T) {
+	client := newTestGRPCClient(t, func(ts *TunerServer) { ts.SetAuthorizer(testAuthorizer(t)) })
+	withToken := func(token string) context.Context {
+		return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+	}
+
+	if _, err := client.WarmUp(context.Background(), &tunerv1.WarmUpRequest{}); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("no token: %v, want Unauthenticated", err)
+	}
+	if _, err := client.WarmUp(withToken("reader"), &tunerv1.WarmUpRequest{}); err != nil {
+		t.Errorf("read token on WarmUp: %v", err)
+	}
+	if _, err := client.Merge(withToken("reader"), &tunerv1.ModelData{}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("read token on Merge: %v, want PermissionDenied", err)
+	}
+	if _, err := client.Merge(withToken("writer"), &tunerv1.ModelData{}); err != nil {
+		t.Errorf("mutate token on Merge: %v", err)
+	}
+	stream, err := client.WatchParams(context.Background(), &tunerv1.WatchParamsRequest{})
+	if err == nil {
+		_, err = stream.Recv()
+	}
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("WatchParams without token: %v, want Unauthenticated", err)
+	}
+}
+
+func TestReadTokensFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tokens")
+	if err := os.WriteFile(path, []byte("# controller\nabc\n\n  def  \n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	tokens, err := ReadTokensFile(path)
+	if err != nil || !reflect.DeepEqual(tokens, []string{"abc", "def"}) {
+		t.Errorf("ReadTokensFile = (%v, %v), want [abc def]", tokens, err)
+	}
+	if err := os.WriteFile(path, []byte("# none\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadTokensFile(path); err == nil {
+		t.Error("expected error for a file without tokens")
+	}
+}
@@ -0,0 +1,345 @@
+// Package client is a typed Go client of the tunerservice REST API.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	optconfig "github.com/llm-inferno/optimizer-light/pkg/config"
+
+	pkgsvc "github.com/llm-inferno/model-tuner/pkg/service"
+	"github.com/llm-inferno/model-tuner/tunerservice"
+)
+
+// Defaults of a Client.
+const (
+	DefaultTimeout    = 30 * time.Second
+	DefaultMaxRetries = 3
+	DefaultBackoff    = 200 * time.Millisecond
+)
+
+// Errors matched by an *APIError through errors.Is, one per status class the API returns.
+var (
+	ErrBadRequest   = errors.New("bad request")         // 400: invalid body or query
+	ErrUnauthorized = errors.New("unauthorized")        // 401: missing or invalid credentials
+	ErrForbidden    = errors.New("forbidden")           // 403: credentials lack the endpoint's scope
+	ErrNotFound     = errors.New("not found")           // 404: pair not tuned yet, or no such shadow
+	ErrConflict     = errors.New("conflict")            // 409: shadow not ready for promotion
+	ErrUnprocessed  = errors.New("unprocessable")       // 422: nothing could be tuned or calibrated
+	ErrUnavailable  = errors.New("service unavailable") // 503: e.g. push ingestion disabled
+)
+
+// APIError is a non-2xx response of the tuner.
+type APIError struct {
+	Method     string
+	Path       string
+	StatusCode int
+	Message    string                // the "error" field of the response body, or the body itself
+	Groups     []pkgsvc.GroupOutcome // group outcomes of a failed Tune, e.g. warm-up progress
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%s %s: %d %s: %s", e.Method, e.Path, e.StatusCode, http.StatusText(e.StatusCode), e.Message)
+}
+
+// Is reports whether the status code corresponds to target, e.g. errors.Is(err, ErrNotFound).
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrBadRequest:
+		return e.StatusCode == http.StatusBadRequest
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	case ErrUnprocessed:
+		return e.StatusCode == http.StatusUnprocessableEntity
+	case ErrUnavailable:
+		return e.StatusCode == http.StatusServiceUnavailable
+	}
+	return false
+}
+
+// Client calls the tuner REST API at a base URL. It is safe for concurrent use.
+//
+// Failed requests are retried up to maxRetries times with exponential backoff. Read-only
+// requests (/getparams, /warmup, /calibration-status, /merge and /predict) are retried on any transport
+// error and on 429 and 5xx responses. Requests that change tuner state (/tune, /calibrate and
+// /observe) are retried only when the tuner cannot have processed them: on connection failures
+// and on 429 and 503 responses, so an observation is never tuned twice.
+type Client struct {
+	baseURL    *url.URL
+	httpClient *http.Client
+	maxRetries int
+	backoff    time.Duration
+	token      string
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithHTTPClient sets the HTTP client, e.g. for TLS or a custom timeout.
+func WithHTTPClient(c *http.Client) Option {
+	return func(cl *Client) { cl.httpClient = c }
+}
+
+// WithToken sends token as a bearer token with every request, for a tuner with authorization
+// enabled. Use WithHTTPClient to present a client certificate instead.
+func WithToken(token string) Option {
+	return func(cl *Client) { cl.token = token }
+}
+
+// WithRetries sets the number of retries and the backoff before the first; the backoff doubles
+// on each further retry. maxRetries 0 disables retrying.
+func WithRetries(maxRetries int, backoff time.Duration) Option {
+	return func(cl *Client) {
+		cl.maxRetries = max(maxRetries, 0)
+		cl.backoff = backoff
+	}
+}
+
+// New creates a Client for the tuner at baseURL, e.g. "http://model-tuner:8081".
+func New(baseURL string, opts ...Option) (*Client, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base url: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid base url %q: scheme must be http or https", baseURL)
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/")
+	c := &Client{
+		baseURL:    u,
+		httpClient: &http.Client{Timeout: DefaultTimeout},
+		maxRetries: DefaultMaxRetries,
+		backoff:    DefaultBackoff,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c, nil
+}
+
+// Tune runs one tuning cycle over replica observations (POST /tune) and returns the tuned
+// ModelData with the outcome of every group. The error matches ErrUnprocessed if no group has
+// parameters yet; its Groups then report why.
+func (c *Client) Tune(ctx context.Context, specs []optconfig.ServerSpec) (*tunerservice.TuneResponse, error) {
+	out := &tunerservice.TuneResponse{}
+	if err := c.do(ctx, http.MethodPost, "/tune", nil, specs, false, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Merge overlays the tuned parameters onto modelData (POST /merge), reporting the freshness of
+// every tuned pair.
+func (c *Client) Merge(ctx context.Context, modelData *optconfig.ModelData) (*tunerservice.MergeResponse, error) {
+	out := &tunerservice.MergeResponse{}
+	if err := c.do(ctx, http.MethodPost, "/merge", nil, modelData, true, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetParams returns the tuned parameters of a pair (GET /getparams). The error matches
+// ErrNotFound if the pair has not been tuned yet.
+func (c *Client) GetParams(ctx context.Context, model, accelerator string) (*tunerservice.ParamsResponse, error) {
+	query := url.Values{"model": {model}, "accelerator": {accelerator}}
+	out := &tunerservice.ParamsResponse{}
+	if err := c.do(ctx, http.MethodGet, "/getparams", query, nil, true, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Diagnostics evaluates the tuned parameters of a pair against its retained observations
+// (GET /diagnostics). The error matches ErrNotFound if the pair has not been tuned yet.
+func (c *Client) Diagnostics(ctx context.Context, model, accelerator string) (*tunerservice.DiagnosticsResponse, error) {
+	query := url.Values{"model": {model}, "accelerator": {accelerator}}
+	out := &tunerservice.DiagnosticsResponse{}
+	if err := c.do(ctx, http.MethodGet, "/diagnostics", query, nil, true, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// WarmUp reports whether any pair is still warming up (GET /warmup).
+func (c *Client) WarmUp(ctx context.Context) (bool, error) {
+	var out tunerservice.WarmUpResponse
+	if err := c.do(ctx, http.MethodGet, "/warmup", nil, nil, true, &out); err != nil {
+		return false, err
+	}
+	return out.WarmingUp, nil
+}
+
+// Calibrate fits pairs jointly from swept operating points (POST /calibrate). The error
+// matches ErrUnprocessed if no pair could be calibrated.
+func (c *Client) Calibrate(ctx context.Context, specs []optconfig.ServerSpec) (*optconfig.ModelData, error) {
+	out := &optconfig.ModelData{}
+	if err := c.do(ctx, http.MethodPost, "/calibrate", nil, specs, false, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CalibrationStatus returns the calibration status of every pair seen (GET /calibration-status).
+func (c *Client) CalibrationStatus(ctx context.Context) ([]pkgsvc.CalibrationStatus, error) {
+	var out tunerservice.CalibrationStatusResponse
+	if err := c.do(ctx, http.MethodGet, "/calibration-status", nil, nil, true, &out); err != nil {
+		return nil, err
+	}
+	return out.Statuses, nil
+}
+
+// Predict evaluates the tuned parameters of a pair at operating points (POST /predict),
+// returning one prediction per point. A confidence in (0, 1) adds TTFT and ITL intervals at that
+// level; 0 omits them. The error matches ErrNotFound if the pair has not been tuned yet.
+func (c *Client) Predict(ctx context.Context, model, accelerator string, points []pkgsvc.OperatingPoint, confidence float64) ([]pkgsvc.Prediction, error) {
+	req := tunerservice.PredictRequest{Model: model, Accelerator: accelerator, Points: points, Confidence: confidence}
+	var out tunerservice.PredictResponse
+	if err := c.do(ctx, http.MethodPost, "/predict", nil, req, true, &out); err != nil {
+		return nil, err
+	}
+	return out.Predictions, nil
+}
+
+// Capacity searches the max arrival rate a replica of a pair sustains within the query's SLO
+// targets (POST /capacity). The error matches ErrNotFound if the pair has not been tuned yet.
+func (c *Client) Capacity(ctx context.Context, model, accelerator string, q pkgsvc.CapacityQuery) (*pkgsvc.Capacity, error) {
+	req := tunerservice.CapacityRequest{Model: model, Accelerator: accelerator, CapacityQuery: q}
+	var out tunerservice.CapacityResponse
+	if err := c.do(ctx, http.MethodPost, "/capacity", nil, req, true, &out); err != nil {
+		return nil, err
+	}
+	return &out.Capacity, nil
+}
+
+// CompareShadows reports the rolling one-step-ahead error of the primary estimator and its
+// shadows (GET /shadows).
+func (c *Client) CompareShadows(ctx context.Context) (*pkgsvc.ShadowComparison, error) {
+	out := &pkgsvc.ShadowComparison{}
+	if err := c.do(ctx, http.MethodGet, "/shadows", nil, nil, true, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PromoteShadow makes the named shadow estimator the primary (POST /shadows/promote). The error
+// matches ErrNotFound for an unknown shadow and ErrConflict if it has not tuned every pair yet.
+func (c *Client) PromoteShadow(ctx context.Context, name string) (*pkgsvc.ShadowComparison, error) {
+	out := &pkgsvc.ShadowComparison{}
+	if err := c.do(ctx, http.MethodPost, "/shadows/promote", nil, tunerservice.PromoteShadowRequest{Name: name}, false, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Observe pushes one replica observation (POST /observe) and returns the number buffered for
+// that replica, which spec.Name must identify. The error matches ErrUnavailable if push
+// ingestion is not enabled.
+func (c *Client) Observe(ctx context.Context, spec optconfig.ServerSpec) (int, error) {
+	var out tunerservice.ObserveResponse
+	if err := c.do(ctx, http.MethodPost, "/observe", nil, spec, false, &out); err != nil {
+		return 0, err
+	}
+	return out.Pending, nil
+}
+
+// do sends one request, with retries, and decodes a 2xx response body into out.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body any, readOnly bool, out any) error {
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return err
+		}
+	}
+	u := *c.baseURL
+	u.Path += path
+	u.RawQuery = query.Encode()
+
+	for attempt := 0; ; attempt++ {
+		err := c.send(ctx, method, u.String(), path, payload, out)
+		if err == nil || attempt >= c.maxRetries || !retryable(err, readOnly) || ctx.Err() != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(c.backoff << attempt):
+		}
+	}
+}
+
+func (c *Client) send(ctx context.Context, method, target, path string, payload []byte, out any) error {
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, body)
+	if err != nil {
+		return err
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		apiErr := &APIError{Method: method, Path: path, StatusCode: resp.StatusCode, Message: strings.TrimSpace(string(data))}
+		var errBody tunerservice.ErrorResponse
+		if json.Unmarshal(data, &errBody) == nil && errBody.Error != "" {
+			apiErr.Message, apiErr.Groups = errBody.Error, errBody.Groups
+		}
+		return apiErr
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("%s %s: decoding response: %w", method, path, err)
+	}
+	return nil
+}
+
+// retryable reports whether a failed request may be sent again; see Client.
+func retryable(err error, readOnly bool) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		switch {
+		case apiErr.StatusCode == http.StatusTooManyRequests || apiErr.StatusCode == http.StatusServiceUnavailable:
+			return true
+		case apiErr.StatusCode >= 500:
+			return readOnly
+		}
+		return false
+	}
+	var urlErr *url.Error
+	if !errors.As(err, &urlErr) {
+		return false // not a transport error, e.g. an undecodable response
+	}
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+	return readOnly
+}
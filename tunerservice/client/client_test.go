@@ -0,0 +1,200 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	optconfig "github.com/llm-inferno/optimizer-light/pkg/config"
+
+	pkgsvc "github.com/llm-inferno/model-tuner/pkg/service"
+	"github.com/llm-inferno/model-tuner/tunerservice"
+)
+
+// newTestTuner serves a TunerServer whose service tunes from the first observation, behind
+// wrap (nil for none), and returns a client for it.
+func newTestTuner(t *testing.T, wrap func(http.Handler) http.Handler, opts ...Option) *Client {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	t.Setenv("CONFIG_DATA_DIR", "../../config-data")
+	service := pkgsvc.NewTunerService(0, 1, false, false, pkgsvc.DefaultWindowSize, pkgsvc.DefaultResidualThreshold, 0)
+	handler := tunerservice.NewTunerServer(service).Handler()
+	if wrap != nil {
+		handler = wrap(handler)
+	}
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	c, err := New(srv.URL, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func testSpec(model string) optconfig.ServerSpec {
+	return optconfig.ServerSpec{
+		Name:         model + "-0",
+		Model:        model,
+		MaxBatchSize: 64,
+		CurrentAlloc: optconfig.AllocationData{
+			Accelerator: "H100",
+			MaxBatch:    64,
+			TTFTAverage: 50,
+			ITLAverage:  8,
+			Load:        optconfig.ServerLoadSpec{ArrivalRate: 30, AvgInTokens: 512, AvgOutTokens: 128},
+		},
+	}
+}
+
+func TestClient_Endpoints(t *testing.T) {
+	c := newTestTuner(t, nil)
+	ctx := context.Background()
+
+	if _, err := c.GetParams(ctx, "llama", "H100"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetParams before tuning: %v, want ErrNotFound", err)
+	}
+	if _, err := c.Tune(ctx, nil); !errors.Is(err, ErrBadRequest) {
+		t.Errorf("empty Tune: %v, want ErrBadRequest", err)
+	}
+	if _, err := c.Observe(ctx, testSpec("llama")); !errors.Is(err, ErrUnavailable) {
+		t.Errorf("Observe without ingestion: %v, want ErrUnavailable", err)
+	}
+
+	modelData, err := c.Tune(ctx, []optconfig.ServerSpec{testSpec("llama")})
+	if err != nil {
+		t.Fatalf("Tune: %v", err)
+	}
+	if len(modelData.PerfData) != 1 || modelData.PerfData[0].PerfParms.Alpha <= 0 {
+		t.Fatalf("Tune = %+v, want one tuned pair", modelData)
+	}
+	if len(modelData.Groups) != 1 || !modelData.Groups[0].Fresh {
+		t.Errorf("Tune groups = %+v, want one fresh group", modelData.Groups)
+	}
+	point := pkgsvc.OperatingPoint{ArrivalRate: 300, AvgInTokens: 2048, AvgOutTokens: 128, MaxBatchSize: 64}
+	if predictions, err := c.Predict(ctx, "llama", "H100", []pkgsvc.OperatingPoint{point}, 0.9); err != nil || len(predictions) != 1 || predictions[0].Interval == nil {
+		t.Errorf("Predict = (%+v, %v), want one prediction with an interval", predictions, err)
+	}
+	if _, err := c.Predict(ctx, "granite", "H100", []pkgsvc.OperatingPoint{point}, 0); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Predict untuned pair: %v, want ErrNotFound", err)
+	}
+	if diagnostics, err := c.Diagnostics(ctx, "llama", "H100"); err != nil || diagnostics.Init == nil || len(diagnostics.Init.Observations) != 1 {
+		t.Errorf("Diagnostics = (%+v, %v), want the one init observation", diagnostics, err)
+	}
+	q := pkgsvc.CapacityQuery{AvgInTokens: 2048, AvgOutTokens: 128, MaxBatchSize: 64, TargetITL: 30}
+	if capacity, err := c.Capacity(ctx, "llama", "H100", q); err != nil || capacity.MaxRate <= 0 || capacity.Binding == "" {
+		t.Errorf("Capacity = (%+v, %v), want a positive capacity", capacity, err)
+	}
+	if _, err := c.Capacity(ctx, "granite", "H100", q); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Capacity untuned pair: %v, want ErrNotFound", err)
+	}
+	if comparison, err := c.CompareShadows(ctx); err != nil || len(comparison.Estimators) != 1 || !comparison.Estimators[0].Primary {
+		t.Errorf("CompareShadows = (%+v, %v), want the primary alone", comparison, err)
+	}
+	if _, err := c.PromoteShadow(ctx, "wide"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("PromoteShadow unknown shadow: %v, want ErrNotFound", err)
+	}
+	params, err := c.GetParams(ctx, "llama", "H100")
+	if err != nil {
+		t.Fatalf("GetParams: %v", err)
+	}
+	if params.Alpha != modelData.PerfData[0].PerfParms.Alpha || params.RawAlpha != params.Alpha || params.UpdateCount != 1 || params.LastUpdated.IsZero() {
+		t.Errorf("GetParams = %+v, want the tuned alpha after one update", params)
+	}
+	if warm, err := c.WarmUp(ctx); err != nil || warm {
+		t.Errorf("WarmUp = (%v, %v), want false", warm, err)
+	}
+	statuses, err := c.CalibrationStatus(ctx)
+	if err != nil || len(statuses) != 1 || statuses[0].Model != "llama" {
+		t.Errorf("CalibrationStatus = (%+v, %v), want llama", statuses, err)
+	}
+	merged, err := c.Merge(ctx, &optconfig.ModelData{})
+	if err != nil || len(merged.PerfData) != 1 || len(merged.Freshness) != 1 || !merged.Freshness[0].Merged {
+		t.Errorf("Merge = (%+v, %v), want the tuned pair appended", merged, err)
+	}
+	if _, err := c.Calibrate(ctx, []optconfig.ServerSpec{testSpec("llama")}); !errors.Is(err, ErrUnprocessed) {
+		t.Errorf("Calibrate from one point: %v, want ErrUnprocessed", err)
+	}
+}
+
+// failFirst answers the first n requests with status, then passes requests through.
+func failFirst(n int32, status int, calls *atomic.Int32) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if calls.Add(1) <= n {
+				http.Error(w, "try again", status)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func TestClient_Retries(t *testing.T) {
+	ctx := context.Background()
+
+	var calls atomic.Int32
+	c := newTestTuner(t, failFirst(2, http.StatusBadGateway, &calls), WithRetries(2, time.Millisecond))
+	if _, err := c.WarmUp(ctx); err != nil || calls.Load() != 3 {
+		t.Errorf("read-only request after two 502s: err %v after %d calls, want success after 3", err, calls.Load())
+	}
+
+	calls.Store(0)
+	c = newTestTuner(t, failFirst(1, http.StatusBadGateway, &calls), WithRetries(2, time.Millisecond))
+	var apiErr *APIError
+	if _, err := c.Tune(ctx, []optconfig.ServerSpec{testSpec("llama")}); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadGateway || calls.Load() != 1 {
+		t.Errorf("Tune after a 502: err %v after %d calls, want the 502 without retrying", err, calls.Load())
+	}
+
+	calls.Store(0)
+	c = newTestTuner(t, failFirst(1, http.StatusServiceUnavailable, &calls), WithRetries(2, time.Millisecond))
+	if _, err := c.Tune(ctx, []optconfig.ServerSpec{testSpec("llama")}); err != nil || calls.Load() != 2 {
+		t.Errorf("Tune after a 503: err %v after %d calls, want success after 2", err, calls.Load())
+	}
+
+	calls.Store(0)
+	c = newTestTuner(t, failFirst(10, http.StatusServiceUnavailable, &calls), WithRetries(5, time.Hour))
+	ctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	if _, err := c.WarmUp(ctx); !errors.Is(err, ErrUnavailable) || calls.Load() != 1 {
+		t.Errorf("cancelled during backoff: err %v after %d calls, want the 503 after 1", err, calls.Load())
+	}
+}
+
+func TestNew_RejectsInvalidURL(t *testing.T) {
+	for _, u := range []string{"", "model-tuner:8081", "ftp://model-tuner"} {
+		if _, err := New(u); err == nil {
+			t.Errorf("New(%q): expected error", u)
+		}
+	}
+}
+
+func TestClient_Token(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Setenv("CONFIG_DATA_DIR", "../../config-data")
+	server := tunerservice.NewTunerServer(pkgsvc.NewTunerService(0, 1, false, false, pkgsvc.DefaultWindowSize, pkgsvc.DefaultResidualThreshold, 0))
+	auth, err := tunerservice.NewAuthorizer(tunerservice.AuthConfig{ReadTokens: []string{"reader"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	server.SetAuthorizer(auth)
+	srv := httptest.NewServer(server.Handler())
+	defer srv.Close()
+	ctx := context.Background()
+
+	anonymous, _ := New(srv.URL, WithRetries(0, 0))
+	if _, err := anonymous.WarmUp(ctx); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("without token: %v, want ErrUnauthorized", err)
+	}
+	reader, _ := New(srv.URL, WithToken("reader"), WithRetries(0, 0))
+	if _, err := reader.WarmUp(ctx); err != nil {
+		t.Errorf("WarmUp with read token: %v", err)
+	}
+	if _, err := reader.Merge(ctx, &optconfig.ModelData{}); !errors.Is(err, ErrForbidden) {
+		t.Errorf("Merge with read token: %v, want ErrForbidden", err)
+	}
+}
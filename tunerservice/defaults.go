@@ -8,3 +8,91 @@ const (
 	DefaultTunerHost = "localhost"
 	DefaultTunerPort = "8081"
 )
+
+// Environment variable name and default for the port of the gRPC API, served on TUNER_HOST
+// alongside the REST server. "off" disables it.
+const (
+	TunerGRPCPortEnvName = "TUNER_GRPC_PORT"
+
+	DefaultTunerGRPCPort = "8082"
+	GRPCPortDisabled     = "off"
+)
+
+// Environment variable names for TLS, served when TUNER_TLS_CERT_FILE and TUNER_TLS_KEY_FILE
+// are set and reloaded when the files change. TUNER_TLS_CLIENT_CA_FILE verifies client
+// certificates for mTLS.
+const (
+	TLSCertFileEnvName     = "TUNER_TLS_CERT_FILE"
+	TLSKeyFileEnvName      = "TUNER_TLS_KEY_FILE"
+	TLSClientCAFileEnvName = "TUNER_TLS_CLIENT_CA_FILE"
+)
+
+// Environment variable names for authorization, enforced when any is set. Token files hold one
+// bearer token per line; client lists are comma-separated client certificate identities (or
+// "*"). Mutate credentials also grant read.
+const (
+	AuthReadTokensFileEnvName   = "TUNER_AUTH_READ_TOKENS_FILE"
+	AuthMutateTokensFileEnvName = "TUNER_AUTH_MUTATE_TOKENS_FILE"
+	AuthReadClientsEnvName      = "TUNER_AUTH_READ_CLIENTS"
+	AuthMutateClientsEnvName    = "TUNER_AUTH_MUTATE_CLIENTS"
+)
+
+// Environment variable name and default for the time a SIGINT or SIGTERM gives requests in
+// flight to finish before they are cut off and the state is flushed. The default stays below the
+// 30s Kubernetes termination grace period.
+const (
+	ShutdownTimeoutEnvName = "TUNER_SHUTDOWN_TIMEOUT"
+
+	DefaultShutdownTimeout = "25s"
+)
+
+// Environment variable names and defaults for the optional /tune and /calibrate traffic
+// recorder. Recording is disabled unless TUNER_RECORD_PATH is set.
+const (
+	RecordPathEnvName     = "TUNER_RECORD_PATH"
+	RecordMaxBytesEnvName = "TUNER_RECORD_MAX_BYTES"
+	RecordMaxFilesEnvName = "TUNER_RECORD_MAX_FILES"
+
+	DefaultRecordMaxBytes = 64 * 1024 * 1024
+	DefaultRecordMaxFiles = 5
+)
+
+// Environment variable names and defaults for the HTTP sink of active mode. When
+// TUNER_SINK_URL is set, the ModelData of every active tune cycle is POSTed to it as JSON,
+// with TUNER_SINK_TOKEN (if set) as a bearer token.
+const (
+	SinkURLEnvName     = "TUNER_SINK_URL"
+	SinkTokenEnvName   = "TUNER_SINK_TOKEN"
+	SinkTimeoutEnvName = "TUNER_SINK_TIMEOUT"
+
+	DefaultSinkTimeout = "10s"
+)
+
+// Environment variable names and defaults for the ModelData file exporter, enabled when
+// TUNER_EXPORT_PATH is set. TUNER_EXPORT_MODELS and TUNER_EXPORT_ACCELERATORS are
+// comma-separated glob patterns limiting the exported pairs.
+const (
+	ExportPathEnvName         = "TUNER_EXPORT_PATH"
+	ExportIntervalEnvName     = "TUNER_EXPORT_INTERVAL"
+	ExportKeepEnvName         = "TUNER_EXPORT_KEEP"
+	ExportModelsEnvName       = "TUNER_EXPORT_MODELS"
+	ExportAcceleratorsEnvName = "TUNER_EXPORT_ACCELERATORS"
+
+	DefaultExportInterval = "1m"
+	DefaultExportKeep     = 5
+)
+
+// Environment variable names and defaults for peer replication, enabled when
+// TUNER_REPLICATION_PEERS (comma-separated peer base URLs) is set. TUNER_REPLICATION_TOKEN is
+// sent to the peers as a bearer token.
+const (
+	ReplicationPeersEnvName    = "TUNER_REPLICATION_PEERS"
+	ReplicationTokenEnvName    = "TUNER_REPLICATION_TOKEN"
+	ReplicationIntervalEnvName = "TUNER_REPLICATION_INTERVAL"
+	ReplicationFullSyncEnvName = "TUNER_REPLICATION_FULL_SYNC"
+	ReplicationTimeoutEnvName  = "TUNER_REPLICATION_TIMEOUT"
+
+	DefaultReplicationInterval = "5s"
+	DefaultReplicationFullSync = "1m"
+	DefaultReplicationTimeout  = "10s"
+)
@@ -7,14 +7,17 @@
 //	  Response: config.ModelData      (updated alpha/beta/gamma per model/accelerator)
 //
 //	GET /getparams?model=<name>&accelerator=<acc>
-//	  Response: JSON with alpha, beta, gamma, NIS, updateCount, lastUpdated
+//	  Response: ParamsResponse (alpha, beta, gamma, NIS, updateCount, lastUpdated, raw fit)
 //
 //	GET /warmup
-//	  Response: {"warmingUp": bool}
+//	  Response: WarmUpResponse {"warmingUp": bool}
 //
 //	POST /merge
 //	  Body:     config.ModelData
 //	  Response: config.ModelData with PerfParms overlaid from the parameter store
 //
+// The full API, including /calibrate, /calibration-status and /observe, is described by the
+// OpenAPI document openapi.yaml (served at GET /openapi.yaml); package client is a typed Go client.
+//
 // All estimation logic lives in pkg/estimator and pkg/service.
 package tunerservice
@@ -0,0 +1,182 @@
+package tunerservice
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"time"
+
+	optconfig "github.com/llm-inferno/optimizer-light/pkg/config"
+
+	pkgsvc "github.com/llm-inferno/model-tuner/pkg/service"
+)
+
+// Exporter writes the service's Merge output, an optimizer ModelData, as JSON to a file for tools
+// outside the control loop. It exports on every parameter publication and every interval, and
+// writes only when the content changed: the new version replaces the file atomically (a temporary
+// file renamed over it), and the previous versions are kept as path.1 (the latest) to path.N.
+type Exporter struct {
+	service      *pkgsvc.TunerService
+	path         string
+	interval     time.Duration
+	keep         int
+	models       []*regexp.Regexp
+	accelerators []*regexp.Regexp
+	last         []byte
+}
+
+// NewExporter creates an exporter writing to path every interval and keeping keep previous
+// versions (keep <= 0 keeps none). models and accelerators are glob patterns (see
+// pkg/service.CompileGlob) limiting the exported pairs; an empty list matches all.
+func NewExporter(service *pkgsvc.TunerService, path string, interval time.Duration, keep int, models, accelerators []string) (*Exporter, error) {
+	if path == "" {
+		return nil, fmt.Errorf("export path is required")
+	}
+	if interval <= 0 {
+		return nil, fmt.Errorf("export interval must be positive")
+	}
+	e := &Exporter{service: service, path: path, interval: interval, keep: keep}
+	for _, pattern := range models {
+		re, err := pkgsvc.CompileGlob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("export model pattern %q: %w", pattern, err)
+		}
+		e.models = append(e.models, re)
+	}
+	for _, pattern := range accelerators {
+		re, err := pkgsvc.CompileGlob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("export accelerator pattern %q: %w", pattern, err)
+		}
+		e.accelerators = append(e.accelerators, re)
+	}
+	if data, err := os.ReadFile(path); err == nil {
+		e.last = data
+	}
+	return e, nil
+}
+
+// Run exports once, then on every publication and every interval until ctx is done. Export
+// errors are logged.
+func (e *Exporter) Run(ctx context.Context) {
+	_, updates, cancel := e.service.WatchParams(64)
+	defer cancel()
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+	e.exportAndLog()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-updates:
+			// A tune cycle publishes every pair it tuned; export once for the lot.
+			for drained := false; !drained; {
+				select {
+				case <-updates:
+				default:
+					drained = true
+				}
+			}
+		}
+		e.exportAndLog()
+	}
+}
+
+func (e *Exporter) exportAndLog() {
+	written, err := e.Export()
+	if err != nil {
+		slog.Warn("ModelData export failed", "path", e.path, "err", err)
+	} else if written {
+		slog.Debug("exported ModelData", "path", e.path)
+	}
+}
+
+// Export writes the current ModelData if it differs from the last version written, and reports
+// whether it did.
+func (e *Exporter) Export() (bool, error) {
+	data, err := json.MarshalIndent(e.modelData(), "", "  ")
+	if err != nil {
+		return false, fmt.Errorf("encode ModelData: %w", err)
+	}
+	data = append(data, '\n')
+	if bytes.Equal(data, e.last) {
+		return false, nil
+	}
+	if e.last != nil {
+		if err := e.retain(e.last); err != nil {
+			return false, err
+		}
+	}
+	if err := writeFileAtomic(e.path, data); err != nil {
+		return false, err
+	}
+	e.last = data
+	return true, nil
+}
+
+// modelData returns the merged parameters of the matching pairs, sorted by model and accelerator
+// so that unchanged parameters export identically.
+func (e *Exporter) modelData() *optconfig.ModelData {
+	merged := e.service.Merge(nil)
+	perfData := slices.DeleteFunc(merged.PerfData, func(p optconfig.ModelAcceleratorPerfData) bool {
+		return !matchesAny(e.models, p.Name) || !matchesAny(e.accelerators, p.Acc)
+	})
+	slices.SortFunc(perfData, func(a, b optconfig.ModelAcceleratorPerfData) int {
+		return cmp.Or(cmp.Compare(a.Name, b.Name), cmp.Compare(a.Acc, b.Acc))
+	})
+	return &optconfig.ModelData{PerfData: perfData}
+}
+
+// retain shifts path.(i) to path.(i+1), dropping the oldest, and writes previous as path.1.
+func (e *Exporter) retain(previous []byte) error {
+	if e.keep <= 0 {
+		return nil
+	}
+	_ = os.Remove(rotatedName(e.path, e.keep))
+	for i := e.keep - 1; i >= 1; i-- {
+		if err := os.Rename(rotatedName(e.path, i), rotatedName(e.path, i+1)); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("rotate export %s: %w", e.path, err)
+		}
+	}
+	return writeFileAtomic(rotatedName(e.path, 1), previous)
+}
+
+func matchesAny(patterns []*regexp.Regexp, name string) bool {
+	return len(patterns) == 0 || slices.ContainsFunc(patterns, func(re *regexp.Regexp) bool { return re.MatchString(name) })
+}
+
+// writeFileAtomic writes data to a temporary file next to path and renames it over path, so
+// readers see either the old or the new content.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return fmt.Errorf("create temporary file for %s: %w", path, err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("write %s: %w", tmp.Name(), err)
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("sync %s: %w", tmp.Name(), err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("close %s: %w", tmp.Name(), err)
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return fmt.Errorf("chmod %s: %w", tmp.Name(), err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("rename %s to %s: %w", tmp.Name(), path, err)
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package tunerservice
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	optconfig "github.com/llm-inferno/optimizer-light/pkg/config"
+)
+
+func readModelData(t *testing.T, path string) *optconfig.ModelData {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var md optconfig.ModelData
+	if err := json.Unmarshal(data, &md); err != nil {
+		t.Fatalf("%s: %v", path, err)
+	}
+	return &md
+}
+
+func TestExporter_Export(t *testing.T) {
+	ts := newTestServer(t)
+	path := filepath.Join(t.TempDir(), "model-data.json")
+	if _, err := NewExporter(ts.service, "", time.Minute, 1, nil, nil); err == nil {
+		t.Error("expected error without a path")
+	}
+	if _, err := NewExporter(ts.service, path, 0, 1, nil, nil); err == nil {
+		t.Error("expected error without an interval")
+	}
+	exporter, err := NewExporter(ts.service, path, time.Minute, 1, []string{"LLAMA*"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if written, err := exporter.Export(); err != nil || !written {
+		t.Fatalf("Export = (%v, %v), want the empty ModelData written", written, err)
+	}
+	for range 5 {
+		post(ts, "/tune", "["+observeBody+"]")
+	}
+	if written, err := exporter.Export(); err != nil || !written {
+		t.Fatalf("Export = (%v, %v), want the tuned pair written", written, err)
+	}
+	md := readModelData(t, path)
+	if len(md.PerfData) != 1 || md.PerfData[0].Name != "llama" || md.PerfData[0].PerfParms.Alpha != ts.service.GetParams("llama", "H100").Alpha {
+		t.Errorf("exported %+v, want llama/H100 as merged", md.PerfData)
+	}
+	if previous := readModelData(t, path+".1"); len(previous.PerfData) != 0 {
+		t.Errorf("previous version %+v, want the empty ModelData", previous.PerfData)
+	}
+	if written, err := exporter.Export(); err != nil || written {
+		t.Errorf("Export = (%v, %v), want nothing written for unchanged parameters", written, err)
+	}
+
+	a100 := strings.ReplaceAll(observeBody, "H100", "A100")
+	for range 5 {
+		post(ts, "/tune", "["+a100+"]")
+	}
+	if written, err := exporter.Export(); err != nil || !written {
+		t.Fatalf("Export = (%v, %v), want the new pair written", written, err)
+	}
+	if md := readModelData(t, path); len(md.PerfData) != 2 || md.PerfData[0].Acc != "A100" {
+		t.Errorf("exported %+v, want both pairs sorted by accelerator", md.PerfData)
+	}
+	if previous := readModelData(t, path+".1"); len(previous.PerfData) != 1 {
+		t.Errorf("previous version %+v, want the H100 pair only", previous.PerfData)
+	}
+	if _, err := os.Stat(path + ".2"); !os.IsNotExist(err) {
+		t.Errorf("%s.2 exists, want one version kept", path)
+	}
+	if matches, _ := filepath.Glob(path + ".tmp*"); len(matches) != 0 {
+		t.Errorf("temporary files left behind: %v", matches)
+	}
+
+	filtered, err := NewExporter(ts.service, filepath.Join(t.TempDir(), "l40s.json"), time.Minute, 0, nil, []string{"L40S"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if md := filtered.modelData(); len(md.PerfData) != 0 {
+		t.Errorf("exported %+v, want no L40S pair", md.PerfData)
+	}
+}
+
+func TestExporter_RunExportsOnPublication(t *testing.T) {
+	ts := newTestServer(t)
+	path := filepath.Join(t.TempDir(), "model-data.json")
+	exporter, err := NewExporter(ts.service, path, time.Hour, 0, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		exporter.Run(ctx)
+		close(done)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	for range 5 {
+		post(ts, "/tune", "["+observeBody+"]")
+	}
+	for deadline := time.Now().Add(5 * time.Second); ; time.Sleep(10 * time.Millisecond) {
+		if data, err := os.ReadFile(path); err == nil {
+			var md optconfig.ModelData
+			if json.Unmarshal(data, &md) == nil && len(md.PerfData) == 1 {
+				break
+			}
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("tuned pair not exported")
+		}
+	}
+}
@@ -0,0 +1,558 @@
+package tunerservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"sync"
+
+	optconfig "github.com/llm-inferno/optimizer-light/pkg/config"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	tunerv1 "github.com/llm-inferno/model-tuner/api/tuner/v1"
+	"github.com/llm-inferno/model-tuner/pkg/estimator"
+	pkgsvc "github.com/llm-inferno/model-tuner/pkg/service"
+)
+
+// watchBuffer is the number of parameter updates buffered per WatchParams stream; a stream that
+// falls further behind misses updates.
+const watchBuffer = 64
+
+// GRPCServer serves the tuner gRPC API (api/tuner/v1) over the TunerService and recorder of a
+// TunerServer, so REST and gRPC clients share one parameter store.
+type GRPCServer struct {
+	tunerv1.UnimplementedTunerServer
+
+	rest     *TunerServer
+	server   *grpc.Server
+	stopping chan struct{}
+	stopOnce sync.Once
+}
+
+// NewGRPCServer creates a GRPCServer backed by rest's service, with rest's TLS configuration and
+// authorization. The server reflection service is registered too, for tools like grpcurl.
+// rest.Shutdown stops it along with the REST server.
+func NewGRPCServer(rest *TunerServer, opts ...grpc.ServerOption) *GRPCServer {
+	opts = append(opts, rest.grpcInterceptors()...)
+	if rest.tls != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(rest.tls)))
+	}
+	gs := &GRPCServer{rest: rest, server: grpc.NewServer(opts...), stopping: make(chan struct{})}
+	tunerv1.RegisterTunerServer(gs.server, gs)
+	reflection.Register(gs.server)
+	rest.mu.Lock()
+	rest.grpcServers = append(rest.grpcServers, gs)
+	rest.mu.Unlock()
+	return gs
+}
+
+// Run serves gRPC on host:port (blocks until the server stops).
+func (gs *GRPCServer) Run(host, port string) error {
+	addr := fmt.Sprintf("%s:%s", host, port)
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	slog.Info("starting gRPC TunerServer", "addr", addr)
+	return gs.server.Serve(lis)
+}
+
+// Serve serves gRPC on lis (blocks until the server stops).
+func (gs *GRPCServer) Serve(lis net.Listener) error {
+	return gs.server.Serve(lis)
+}
+
+// GracefulStop ends open WatchParams streams, stops accepting connections and waits for pending
+// RPCs to finish.
+func (gs *GRPCServer) GracefulStop() {
+	gs.stopOnce.Do(func() { close(gs.stopping) })
+	gs.server.GracefulStop()
+}
+
+// Shutdown is GracefulStop bounded by ctx: once ctx is done, the pending RPCs are cancelled and
+// the connections closed.
+func (gs *GRPCServer) Shutdown(ctx context.Context) error {
+	stopped := make(chan struct{})
+	go func() {
+		gs.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		gs.server.Stop()
+		<-stopped
+		return fmt.Errorf("drain gRPC requests: %w", ctx.Err())
+	}
+}
+
+// Tune mirrors POST /tune. A FailedPrecondition error carries the group outcomes as a
+// TuneResponse status detail.
+func (gs *GRPCServer) Tune(_ context.Context, req *tunerv1.TuneRequest) (*tunerv1.TuneResponse, error) {
+	if len(req.GetReplicaSpecs()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "replicaSpecs must not be empty")
+	}
+	specs := serverSpecsFromProto(req.GetReplicaSpecs())
+	gs.rest.record(pkgsvc.RecordEndpointTune, specs)
+
+	modelData, groups, err := gs.rest.service.TuneWithOutcomes(specs)
+	if err != nil {
+		st := status.New(codes.FailedPrecondition, err.Error())
+		if withGroups, detailErr := st.WithDetails(&tunerv1.TuneResponse{Groups: groupOutcomesToProto(groups)}); detailErr == nil {
+			st = withGroups
+		}
+		return nil, st.Err()
+	}
+	return &tunerv1.TuneResponse{
+		Models: modelDataToProto(modelData).GetModels(),
+		Groups: groupOutcomesToProto(groups),
+	}, nil
+}
+
+// Merge mirrors POST /merge.
+func (gs *GRPCServer) Merge(_ context.Context, req *tunerv1.ModelData) (*tunerv1.ModelData, error) {
+	merged, freshness := gs.rest.service.MergeWithFreshness(modelDataFromProto(req))
+	out := modelDataToProto(merged)
+	for _, f := range freshness {
+		out.Freshness = append(out.Freshness, &tunerv1.PairFreshness{
+			Model:       f.Model,
+			Accelerator: f.Accelerator,
+			Freshness:   f.Freshness,
+			LastUpdated: timestamppb.New(f.LastUpdated),
+			Merged:      f.Merged,
+		})
+	}
+	return out, nil
+}
+
+// GetParams mirrors GET /getparams.
+func (gs *GRPCServer) GetParams(_ context.Context, req *tunerv1.GetParamsRequest) (*tunerv1.Parameters, error) {
+	model, accelerator := req.GetModel(), req.GetAccelerator()
+	if err := validateKey(model, accelerator); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	params := gs.rest.service.GetParams(model, accelerator)
+	if params == nil {
+		return nil, status.Errorf(codes.NotFound, "no parameters found for model=%s accelerator=%s", model, accelerator)
+	}
+	return gs.parametersToProto(model, accelerator, params), nil
+}
+
+// Diagnostics mirrors GET /diagnostics.
+func (gs *GRPCServer) Diagnostics(_ context.Context, req *tunerv1.DiagnosticsRequest) (*tunerv1.DiagnosticsResponse, error) {
+	model, accelerator := req.GetModel(), req.GetAccelerator()
+	if err := validateKey(model, accelerator); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	d, err := gs.rest.service.Diagnostics(model, accelerator)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	return &tunerv1.DiagnosticsResponse{
+		Model:       model,
+		Accelerator: accelerator,
+		Alpha:       d.Alpha,
+		Beta:        d.Beta,
+		Gamma:       d.Gamma,
+		Init:        fitDiagnosticsToProto(d.Init),
+		Sliding:     fitDiagnosticsToProto(d.Sliding),
+	}, nil
+}
+
+// WarmUp mirrors GET /warmup.
+func (gs *GRPCServer) WarmUp(context.Context, *tunerv1.WarmUpRequest) (*tunerv1.WarmUpResponse, error) {
+	return &tunerv1.WarmUpResponse{WarmingUp: gs.rest.service.IsWarmingUp()}, nil
+}
+
+// Calibrate mirrors POST /calibrate.
+func (gs *GRPCServer) Calibrate(_ context.Context, req *tunerv1.CalibrateRequest) (*tunerv1.ModelData, error) {
+	if len(req.GetSpecs()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "calibration points must not be empty")
+	}
+	specs := serverSpecsFromProto(req.GetSpecs())
+	gs.rest.record(pkgsvc.RecordEndpointCalibrate, specs)
+
+	modelData, err := gs.rest.service.Calibrate(specs)
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return modelDataToProto(modelData), nil
+}
+
+// CalibrationStatus mirrors GET /calibration-status.
+func (gs *GRPCServer) CalibrationStatus(context.Context, *tunerv1.CalibrationStatusRequest) (*tunerv1.CalibrationStatusResponse, error) {
+	statuses := gs.rest.service.CalibrationStatuses()
+	resp := &tunerv1.CalibrationStatusResponse{Statuses: make([]*tunerv1.PairCalibrationStatus, len(statuses))}
+	for i, s := range statuses {
+		resp.Statuses[i] = &tunerv1.PairCalibrationStatus{
+			Model:            s.Model,
+			Accelerator:      s.Accelerator,
+			StorePresent:     s.StorePresent,
+			Calibrated:       s.Calibrated,
+			ObsCount:         int32(s.ObsCount),
+			ObsTarget:        int32(s.ObsTarget),
+			ConditionNumber:  s.ConditionNumber,
+			IllConditioned:   s.IllConditioned,
+			NeedsCalibration: s.NeedsCalibration,
+		}
+	}
+	return resp, nil
+}
+
+// Predict mirrors POST /predict.
+func (gs *GRPCServer) Predict(_ context.Context, req *tunerv1.PredictRequest) (*tunerv1.PredictResponse, error) {
+	points := make([]pkgsvc.OperatingPoint, len(req.GetPoints()))
+	for i, p := range req.GetPoints() {
+		points[i] = operatingPointFromProto(p)
+	}
+	if err := validatePredictRequest(req.GetModel(), req.GetAccelerator(), points, req.GetConfidence()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	predictions, err := gs.rest.service.Predict(req.GetModel(), req.GetAccelerator(), points, req.GetConfidence())
+	switch {
+	case errors.Is(err, pkgsvc.ErrNoParams):
+		return nil, status.Error(codes.NotFound, err.Error())
+	case err != nil:
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	resp := &tunerv1.PredictResponse{
+		Model:       req.GetModel(),
+		Accelerator: req.GetAccelerator(),
+		Predictions: make([]*tunerv1.Prediction, len(predictions)),
+	}
+	for i, p := range predictions {
+		resp.Predictions[i] = &tunerv1.Prediction{
+			Point:       req.GetPoints()[i],
+			Ttft:        p.TTFT,
+			Itl:         p.ITL,
+			WaitTime:    p.WaitTime,
+			Concurrency: p.Concurrency,
+			Utilization: p.Utilization,
+			Throughput:  p.Throughput,
+			MaxRate:     p.MaxRate,
+			Overloaded:  p.Overloaded,
+		}
+		if in := p.Interval; in != nil {
+			resp.Predictions[i].Interval = &tunerv1.PredictionInterval{
+				Confidence: in.Confidence,
+				Source:     in.Source,
+				TtftLow:    in.TTFTLow,
+				TtftHigh:   in.TTFTHigh,
+				ItlLow:     in.ITLLow,
+				ItlHigh:    in.ITLHigh,
+			}
+		}
+	}
+	return resp, nil
+}
+
+// Capacity mirrors POST /capacity.
+func (gs *GRPCServer) Capacity(_ context.Context, req *tunerv1.CapacityRequest) (*tunerv1.CapacityResponse, error) {
+	q := pkgsvc.CapacityQuery{
+		AvgInTokens:  int(req.GetAvgInTokens()),
+		AvgOutTokens: int(req.GetAvgOutTokens()),
+		MaxBatchSize: int(req.GetMaxBatchSize()),
+		MaxQueueSize: int(req.GetMaxQueueSize()),
+		TargetTTFT:   req.GetTargetTtft(),
+		TargetITL:    req.GetTargetItl(),
+		Confidence:   req.GetConfidence(),
+	}
+	if err := validateCapacityRequest(req.GetModel(), req.GetAccelerator(), q); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	c, err := gs.rest.service.Capacity(req.GetModel(), req.GetAccelerator(), q)
+	switch {
+	case errors.Is(err, pkgsvc.ErrNoParams):
+		return nil, status.Error(codes.NotFound, err.Error())
+	case err != nil:
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return &tunerv1.CapacityResponse{
+		Model:         req.GetModel(),
+		Accelerator:   req.GetAccelerator(),
+		MaxRate:       c.MaxRate,
+		Binding:       c.Binding,
+		RateTtft:      c.RateTTFT,
+		RateItl:       c.RateITL,
+		RateStability: c.RateStability,
+		Ttft:          c.TTFT,
+		Itl:           c.ITL,
+		Concurrency:   c.Concurrency,
+		Sensitivity: &tunerv1.ParameterSensitivity{
+			Alpha: c.Sensitivity.Alpha,
+			Beta:  c.Sensitivity.Beta,
+			Gamma: c.Sensitivity.Gamma,
+		},
+		Source: c.Source,
+	}, nil
+}
+
+// CompareShadows mirrors GET /shadows.
+func (gs *GRPCServer) CompareShadows(context.Context, *tunerv1.CompareShadowsRequest) (*tunerv1.ShadowComparison, error) {
+	return shadowComparisonToProto(gs.rest.service.CompareShadows()), nil
+}
+
+// PromoteShadow mirrors POST /shadows/promote.
+func (gs *GRPCServer) PromoteShadow(_ context.Context, req *tunerv1.PromoteShadowRequest) (*tunerv1.ShadowComparison, error) {
+	if req.GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+	comparison, err := gs.rest.service.PromoteShadow(req.GetName())
+	switch {
+	case errors.Is(err, pkgsvc.ErrUnknownShadow):
+		return nil, status.Error(codes.NotFound, err.Error())
+	case err != nil:
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return shadowComparisonToProto(comparison), nil
+}
+
+// WatchParams sends the current parameters of the matching pairs, then each update as it is
+// stored, until the client cancels or the server stops.
+func (gs *GRPCServer) WatchParams(req *tunerv1.WatchParamsRequest, stream grpc.ServerStreamingServer[tunerv1.Parameters]) error {
+	match := func(u pkgsvc.ParameterUpdate) bool {
+		return (req.GetModel() == "" || req.GetModel() == u.Model) &&
+			(req.GetAccelerator() == "" || req.GetAccelerator() == u.Accelerator)
+	}
+	snapshot, updates, cancel := gs.rest.service.WatchParams(watchBuffer)
+	defer cancel()
+	for _, u := range snapshot {
+		if !match(u) {
+			continue
+		}
+		if err := stream.Send(gs.parametersToProto(u.Model, u.Accelerator, u.Params)); err != nil {
+			return err
+		}
+	}
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-gs.stopping:
+			return nil
+		case u, ok := <-updates:
+			if !ok {
+				return nil
+			}
+			if !match(u) {
+				continue
+			}
+			if err := stream.Send(gs.parametersToProto(u.Model, u.Accelerator, u.Params)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (gs *GRPCServer) parametersToProto(model, accelerator string, params *pkgsvc.LearnedParameters) *tunerv1.Parameters {
+	out := &tunerv1.Parameters{
+		Model:       model,
+		Accelerator: accelerator,
+		Alpha:       params.Alpha,
+		Beta:        params.Beta,
+		Gamma:       params.Gamma,
+		Nis:         params.NIS,
+		UpdateCount: int32(params.UpdateCount),
+		LastUpdated: timestamppb.New(params.LastUpdated),
+		Freshness:   gs.rest.service.Freshness(model, accelerator),
+		RawAlpha:    params.RawAlpha,
+		RawBeta:     params.RawBeta,
+		RawGamma:    params.RawGamma,
+	}
+	if prior := gs.rest.service.Prior(model, accelerator); prior != nil {
+		out.PriorSource, out.CatalogEntry = prior.Source, prior.CatalogEntry
+	}
+	return out
+}
+
+func serverSpecsFromProto(in []*tunerv1.ServerSpec) []optconfig.ServerSpec {
+	out := make([]optconfig.ServerSpec, len(in))
+	for i, s := range in {
+		out[i] = optconfig.ServerSpec{
+			Name:            s.GetName(),
+			Class:           s.GetClass(),
+			Model:           s.GetModel(),
+			KeepAccelerator: s.GetKeepAccelerator(),
+			MinNumReplicas:  int(s.GetMinNumReplicas()),
+			MaxBatchSize:    int(s.GetMaxBatchSize()),
+			MaxQueueSize:    int(s.GetMaxQueueSize()),
+			CurrentAlloc:    allocationFromProto(s.GetCurrentAlloc()),
+			DesiredAlloc:    allocationFromProto(s.GetDesiredAlloc()),
+		}
+	}
+	return out
+}
+
+func allocationFromProto(a *tunerv1.AllocationData) optconfig.AllocationData {
+	load := a.GetLoad()
+	return optconfig.AllocationData{
+		Accelerator: a.GetAccelerator(),
+		NumReplicas: int(a.GetNumReplicas()),
+		MaxBatch:    int(a.GetMaxBatch()),
+		Cost:        a.GetCost(),
+		ITLAverage:  a.GetItlAverage(),
+		TTFTAverage: a.GetTtftAverage(),
+		Load: optconfig.ServerLoadSpec{
+			ArrivalRate:  load.GetArrivalRate(),
+			Throughput:   load.GetThroughput(),
+			AvgInTokens:  int(load.GetAvgInTokens()),
+			AvgOutTokens: int(load.GetAvgOutTokens()),
+		},
+	}
+}
+
+func fitDiagnosticsToProto(d *estimator.FitDiagnostics) *tunerv1.FitDiagnostics {
+	if d == nil {
+		return nil
+	}
+	out := &tunerv1.FitDiagnostics{
+		Observations: make([]*tunerv1.ObservationFit, len(d.Observations)),
+		RmsResidual:  d.RMSResidual,
+	}
+	for i, o := range d.Observations {
+		out.Observations[i] = &tunerv1.ObservationFit{
+			ArrivalRate:   o.ArrivalRate,
+			AvgInTokens:   o.AvgInTokens,
+			AvgOutTokens:  o.AvgOutTokens,
+			MaxBatchSize:  int32(o.MaxBatchSize),
+			MaxQueueSize:  int32(o.MaxQueueSize),
+			ObservedTtft:  o.ObservedTTFT,
+			PredictedTtft: o.PredictedTTFT,
+			ObservedItl:   o.ObservedITL,
+			PredictedItl:  o.PredictedITL,
+			ResidualTtft:  o.ResidualTTFT,
+			ResidualItl:   o.ResidualITL,
+			Evaluated:     o.Evaluated,
+			Outlier:       o.Outlier,
+		}
+	}
+	if id := d.Identifiability; id != nil {
+		out.Identifiability = &tunerv1.Identifiability{
+			SingularValues:  id.SingularValues,
+			SingularVectors: make([]*tunerv1.SingularVector, len(id.SingularVectors)),
+			ConditionNumber: id.ConditionNumber,
+		}
+		for i, v := range id.SingularVectors {
+			out.Identifiability.SingularVectors[i] = &tunerv1.SingularVector{Components: v}
+		}
+	}
+	return out
+}
+
+func shadowComparisonToProto(c *pkgsvc.ShadowComparison) *tunerv1.ShadowComparison {
+	out := &tunerv1.ShadowComparison{Window: int32(c.Window)}
+	for _, e := range c.Estimators {
+		out.Estimators = append(out.Estimators, &tunerv1.EstimatorScore{
+			Config: &tunerv1.EstimatorConfig{
+				Name:              e.Name,
+				Mode:              e.Mode,
+				InitObs:           int32(e.InitObs),
+				WindowSize:        int32(e.WindowSize),
+				ResidualThreshold: e.ResidualThreshold,
+				InitFitThreshold:  e.InitFitThreshold,
+			},
+			Primary:  e.Primary,
+			Cycles:   int32(e.Cycles),
+			RmsError: e.RMSError,
+		})
+	}
+	for _, p := range c.Pairs {
+		pair := &tunerv1.PairComparison{Model: p.Model, Accelerator: p.Accelerator}
+		for _, e := range p.Estimators {
+			pair.Estimators = append(pair.Estimators, &tunerv1.PairScore{
+				Name:      e.Name,
+				HasParams: e.HasParams,
+				Alpha:     e.Alpha,
+				Beta:      e.Beta,
+				Gamma:     e.Gamma,
+				Cycles:    int32(e.Cycles),
+				RmsError:  e.RMSError,
+			})
+		}
+		out.Pairs = append(out.Pairs, pair)
+	}
+	return out
+}
+
+func operatingPointFromProto(p *tunerv1.OperatingPoint) pkgsvc.OperatingPoint {
+	return pkgsvc.OperatingPoint{
+		ArrivalRate:  p.GetArrivalRate(),
+		AvgInTokens:  int(p.GetAvgInTokens()),
+		AvgOutTokens: int(p.GetAvgOutTokens()),
+		MaxBatchSize: int(p.GetMaxBatchSize()),
+		MaxQueueSize: int(p.GetMaxQueueSize()),
+	}
+}
+
+func modelDataFromProto(in *tunerv1.ModelData) *optconfig.ModelData {
+	out := &optconfig.ModelData{PerfData: make([]optconfig.ModelAcceleratorPerfData, len(in.GetModels()))}
+	for i, m := range in.GetModels() {
+		out.PerfData[i] = optconfig.ModelAcceleratorPerfData{
+			Name:         m.GetName(),
+			Acc:          m.GetAcc(),
+			AccCount:     int(m.GetAccCount()),
+			MaxBatchSize: int(m.GetMaxBatchSize()),
+			PerfParms: optconfig.PerfParms{
+				Alpha: m.GetPerfParms().GetAlpha(),
+				Beta:  m.GetPerfParms().GetBeta(),
+				Gamma: m.GetPerfParms().GetGamma(),
+			},
+		}
+	}
+	return out
+}
+
+func modelDataToProto(in *optconfig.ModelData) *tunerv1.ModelData {
+	out := &tunerv1.ModelData{}
+	if in == nil {
+		return out
+	}
+	out.Models = make([]*tunerv1.ModelAcceleratorPerfData, len(in.PerfData))
+	for i, m := range in.PerfData {
+		out.Models[i] = &tunerv1.ModelAcceleratorPerfData{
+			Name:         m.Name,
+			Acc:          m.Acc,
+			AccCount:     int32(m.AccCount),
+			MaxBatchSize: int32(m.MaxBatchSize),
+			PerfParms: &tunerv1.PerfParms{
+				Alpha: m.PerfParms.Alpha,
+				Beta:  m.PerfParms.Beta,
+				Gamma: m.PerfParms.Gamma,
+			},
+		}
+	}
+	return out
+}
+
+func groupOutcomesToProto(in []pkgsvc.GroupOutcome) []*tunerv1.GroupOutcome {
+	out := make([]*tunerv1.GroupOutcome, len(in))
+	for i, o := range in {
+		out[i] = &tunerv1.GroupOutcome{
+			Model:       o.Model,
+			Accelerator: o.Accelerator,
+			Reason:      o.Reason,
+			Message:     o.Message,
+			Estimator:   o.Estimator,
+			EkfFallback: o.EKFFallback,
+			HasParams:   o.HasParams,
+			Fresh:       o.Fresh,
+			UpdateCount: int32(o.UpdateCount),
+			Replicas:    int32(o.Replicas),
+			Accepted:    int32(o.Accepted),
+			Rejected:    int32(o.Rejected),
+			ObsCount:    int32(o.ObsCount),
+			ObsTarget:   int32(o.ObsTarget),
+			WindowCount: int32(o.WindowCount),
+			WindowSize:  int32(o.WindowSize),
+		}
+	}
+	return out
+}
@@ -0,0 +1,232 @@
+package tunerservice
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	tunerv1 "github.com/llm-inferno/model-tuner/api/tuner/v1"
+	pkgsvc "github.com/llm-inferno/model-tuner/pkg/service"
+)
+
+// newTestGRPCClient serves a GRPCServer over an in-memory listener, with a service that tunes
+// from the first observation, and returns a client for it. configure runs on the TunerServer
+// before the GRPCServer is created.
+func newTestGRPCClient(t *testing.T, configure ...func(*TunerServer)) tunerv1.TunerClient {
+	t.Helper()
+	t.Setenv("CONFIG_DATA_DIR", "../config-data")
+	service := pkgsvc.NewTunerService(0, 1, false, false, pkgsvc.DefaultWindowSize, pkgsvc.DefaultResidualThreshold, 0)
+	rest := NewTunerServer(service)
+	for _, c := range configure {
+		c(rest)
+	}
+	gs := NewGRPCServer(rest)
+	lis := bufconn.Listen(1 << 20)
+	go func() { _ = gs.Serve(lis) }()
+	t.Cleanup(gs.GracefulStop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return tunerv1.NewTunerClient(conn)
+}
+
+func testProtoSpec() *tunerv1.ServerSpec {
+	return &tunerv1.ServerSpec{
+		Name:         "llama-0",
+		Model:        "llama",
+		MaxBatchSize: 64,
+		CurrentAlloc: &tunerv1.AllocationData{
+			Accelerator: "H100",
+			MaxBatch:    64,
+			TtftAverage: 50,
+			ItlAverage:  8,
+			Load:        &tunerv1.ServerLoadSpec{ArrivalRate: 30, AvgInTokens: 512, AvgOutTokens: 128},
+		},
+	}
+}
+
+func TestGRPCServer_Shadows(t *testing.T) {
+	client := newTestGRPCClient(t, func(ts *TunerServer) {
+		if err := ts.service.AddShadow(pkgsvc.EstimatorConfig{Name: "wide", Mode: pkgsvc.EstimatorSlidingWindow, InitObs: 2, WindowSize: 5}); err != nil {
+			t.Fatal(err)
+		}
+	})
+	ctx := context.Background()
+	spec := &tunerv1.TuneRequest{ReplicaSpecs: []*tunerv1.ServerSpec{testProtoSpec()}}
+	if _, err := client.Tune(ctx, spec); err != nil {
+		t.Fatalf("Tune: %v", err)
+	}
+	if _, err := client.PromoteShadow(ctx, &tunerv1.PromoteShadowRequest{Name: "wide"}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("promote before the shadow has parameters: %v, want FailedPrecondition", err)
+	}
+	if _, err := client.PromoteShadow(ctx, &tunerv1.PromoteShadowRequest{Name: "narrow"}); status.Code(err) != codes.NotFound {
+		t.Errorf("promote unknown shadow: %v, want NotFound", err)
+	}
+	if _, err := client.Tune(ctx, spec); err != nil {
+		t.Fatalf("Tune: %v", err)
+	}
+	comparison, err := client.CompareShadows(ctx, &tunerv1.CompareShadowsRequest{})
+	if err != nil || len(comparison.GetEstimators()) != 2 || comparison.GetEstimators()[1].GetConfig().GetName() != "wide" {
+		t.Fatalf("CompareShadows = (%v, %v), want the primary and the shadow", comparison, err)
+	}
+	if primary := comparison.GetEstimators()[0]; !primary.GetPrimary() || primary.GetCycles() != 1 {
+		t.Errorf("primary = %v, want one scored cycle", primary)
+	}
+	promoted, err := client.PromoteShadow(ctx, &tunerv1.PromoteShadowRequest{Name: "wide"})
+	if err != nil || promoted.GetEstimators()[0].GetConfig().GetMode() != pkgsvc.EstimatorSlidingWindow {
+		t.Errorf("PromoteShadow = (%v, %v), want the sliding-window estimator primary", promoted, err)
+	}
+}
+
+func TestGRPCServer_Validation(t *testing.T) {
+	client := newTestGRPCClient(t)
+	ctx := context.Background()
+
+	if _, err := client.Tune(ctx, &tunerv1.TuneRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("empty Tune: %v, want InvalidArgument", err)
+	}
+	if _, err := client.Calibrate(ctx, &tunerv1.CalibrateRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("empty Calibrate: %v, want InvalidArgument", err)
+	}
+	if _, err := client.GetParams(ctx, &tunerv1.GetParamsRequest{Model: "llama"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("GetParams without accelerator: %v, want InvalidArgument", err)
+	}
+	if _, err := client.Predict(ctx, &tunerv1.PredictRequest{Model: "llama", Accelerator: "H100"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Predict without points: %v, want InvalidArgument", err)
+	}
+	point := &tunerv1.OperatingPoint{ArrivalRate: 30, AvgOutTokens: 128, MaxBatchSize: 64}
+	if _, err := client.Predict(ctx, &tunerv1.PredictRequest{Model: "llama", Accelerator: "H100", Points: []*tunerv1.OperatingPoint{point}}); status.Code(err) != codes.NotFound {
+		t.Errorf("Predict untuned pair: %v, want NotFound", err)
+	}
+	if _, err := client.Diagnostics(ctx, &tunerv1.DiagnosticsRequest{Model: "llama", Accelerator: "H100"}); status.Code(err) != codes.NotFound {
+		t.Errorf("Diagnostics untuned pair: %v, want NotFound", err)
+	}
+	capacity := &tunerv1.CapacityRequest{Model: "llama", Accelerator: "H100", AvgOutTokens: 128, MaxBatchSize: 64}
+	if _, err := client.Capacity(ctx, capacity); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Capacity without targets: %v, want InvalidArgument", err)
+	}
+	capacity.TargetItl = 30
+	if _, err := client.Capacity(ctx, capacity); status.Code(err) != codes.NotFound {
+		t.Errorf("Capacity untuned pair: %v, want NotFound", err)
+	}
+	if _, err := client.GetParams(ctx, &tunerv1.GetParamsRequest{Model: "llama", Accelerator: "H100"}); status.Code(err) != codes.NotFound {
+		t.Errorf("GetParams before tuning: %v, want NotFound", err)
+	}
+}
+
+func TestGRPCServer_TuneFailureCarriesOutcomes(t *testing.T) {
+	client := newTestGRPCClient(t)
+	spec := testProtoSpec()
+	spec.CurrentAlloc.TtftAverage = 0 // not a usable observation
+	_, err := client.Tune(context.Background(), &tunerv1.TuneRequest{ReplicaSpecs: []*tunerv1.ServerSpec{spec}})
+	st := status.Convert(err)
+	if st.Code() != codes.FailedPrecondition || len(st.Details()) != 1 {
+		t.Fatalf("Tune: %v with details %v, want FailedPrecondition with the outcomes", err, st.Details())
+	}
+	resp, ok := st.Details()[0].(*tunerv1.TuneResponse)
+	if !ok || len(resp.GetGroups()) != 1 || resp.GetGroups()[0].GetReason() != pkgsvc.OutcomeNoValidObservations {
+		t.Errorf("details = %v, want one %s group", st.Details(), pkgsvc.OutcomeNoValidObservations)
+	}
+}
+
+func TestGRPCServer_TuneAndWatch(t *testing.T) {
+	client := newTestGRPCClient(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	stream, err := client.WatchParams(ctx, &tunerv1.WatchParamsRequest{Model: "llama"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Whether the llama update arrives in the snapshot or as an update depends on when the
+	// stream is registered; either way it is the first (and only) message for llama.
+	other := testProtoSpec()
+	other.Model = "granite"
+	if _, err := client.Tune(ctx, &tunerv1.TuneRequest{ReplicaSpecs: []*tunerv1.ServerSpec{other}}); err != nil {
+		t.Fatalf("Tune granite: %v", err)
+	}
+	modelData, err := client.Tune(ctx, &tunerv1.TuneRequest{ReplicaSpecs: []*tunerv1.ServerSpec{testProtoSpec()}})
+	if err != nil {
+		t.Fatalf("Tune: %v", err)
+	}
+	if len(modelData.GetModels()) != 1 || modelData.GetModels()[0].GetPerfParms().GetAlpha() <= 0 {
+		t.Fatalf("Tune returned %v, want one tuned pair", modelData)
+	}
+	if groups := modelData.GetGroups(); len(groups) != 1 || !groups[0].GetFresh() || groups[0].GetReason() != pkgsvc.OutcomeTuned {
+		t.Errorf("Tune groups = %v, want one fresh tuned group", groups)
+	}
+
+	update, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("Recv: %v", err)
+	}
+	if update.GetModel() != "llama" || update.GetAccelerator() != "H100" || update.GetUpdateCount() != 1 {
+		t.Errorf("update = %v, want llama/H100 after one cycle", update)
+	}
+
+	params, err := client.GetParams(ctx, &tunerv1.GetParamsRequest{Model: "llama", Accelerator: "H100"})
+	if err != nil {
+		t.Fatalf("GetParams: %v", err)
+	}
+	if params.GetAlpha() != update.GetAlpha() || params.GetRawAlpha() != params.GetAlpha() {
+		t.Errorf("GetParams alpha %g (raw %g), streamed %g", params.GetAlpha(), params.GetRawAlpha(), update.GetAlpha())
+	}
+	if params.GetPriorSource() != pkgsvc.PriorConfig || params.GetCatalogEntry() != "" {
+		t.Errorf("GetParams prior %q (entry %q), want the config seed", params.GetPriorSource(), params.GetCatalogEntry())
+	}
+	point := &tunerv1.OperatingPoint{ArrivalRate: 300, AvgInTokens: 2048, AvgOutTokens: 128, MaxBatchSize: 64}
+	predicted, err := client.Predict(ctx, &tunerv1.PredictRequest{Model: "llama", Accelerator: "H100", Points: []*tunerv1.OperatingPoint{point}, Confidence: 0.9})
+	if err != nil || len(predicted.GetPredictions()) != 1 || predicted.GetPredictions()[0].GetInterval().GetSource() != pkgsvc.UncertaintyEKF {
+		t.Errorf("Predict = (%v, %v), want one prediction with an EKF interval", predicted, err)
+	}
+	diagnostics, err := client.Diagnostics(ctx, &tunerv1.DiagnosticsRequest{Model: "llama", Accelerator: "H100"})
+	if err != nil || len(diagnostics.GetInit().GetObservations()) == 0 || !diagnostics.GetInit().GetObservations()[0].GetEvaluated() {
+		t.Errorf("Diagnostics = (%v, %v), want the evaluated init observations", diagnostics, err)
+	}
+	capacity, err := client.Capacity(ctx, &tunerv1.CapacityRequest{Model: "llama", Accelerator: "H100", AvgInTokens: 2048,
+		AvgOutTokens: 128, MaxBatchSize: 64, TargetTtft: 1000, TargetItl: 30})
+	if err != nil || capacity.GetMaxRate() <= 0 || capacity.GetBinding() == "" || capacity.GetSensitivity() == nil {
+		t.Errorf("Capacity = (%v, %v), want a positive capacity with its binding and sensitivity", capacity, err)
+	}
+	merged, err := client.Merge(ctx, &tunerv1.ModelData{})
+	if err != nil || len(merged.GetModels()) != 2 || len(merged.GetFreshness()) != 2 || merged.GetFreshness()[0].GetFreshness() != pkgsvc.FreshnessFresh {
+		t.Errorf("Merge = (%v, %v), want both tuned pairs appended, fresh", merged, err)
+	}
+	warm, err := client.WarmUp(ctx, &tunerv1.WarmUpRequest{})
+	if err != nil || warm.GetWarmingUp() {
+		t.Errorf("WarmUp = (%v, %v), want not warming up", warm, err)
+	}
+	statuses, err := client.CalibrationStatus(ctx, &tunerv1.CalibrationStatusRequest{})
+	if err != nil || len(statuses.GetStatuses()) != 2 {
+		t.Errorf("CalibrationStatus = (%v, %v), want two pairs", statuses, err)
+	}
+
+	// A new watch starts with the current parameters.
+	replay, err := client.WatchParams(ctx, &tunerv1.WatchParamsRequest{Accelerator: "H100"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	seen := map[string]bool{}
+	for range 2 {
+		p, err := replay.Recv()
+		if err != nil {
+			t.Fatalf("snapshot Recv: %v", err)
+		}
+		seen[p.GetModel()] = true
+	}
+	if !seen["llama"] || !seen["granite"] {
+		t.Errorf("snapshot models = %v, want llama and granite", seen)
+	}
+}
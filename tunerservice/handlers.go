@@ -1,67 +1,102 @@
 package tunerservice
 
 import (
+	_ "embed"
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	optconfig "github.com/llm-inferno/optimizer-light/pkg/config"
+
+	pkgsvc "github.com/llm-inferno/model-tuner/pkg/service"
 )
 
 // POST /tune
 // Request body: []config.ServerSpec (ReplicaSpecs from the control-loop Collector)
-// Response:     config.ModelData with updated alpha/beta/gamma per model/accelerator pair
+// Response:     TuneResponse: config.ModelData with updated alpha/beta/gamma per model/accelerator
+// pair, plus the outcome of each group
 func (ts *TunerServer) handleTune(c *gin.Context) {
 	var replicaSpecs []optconfig.ServerSpec
 	if err := c.ShouldBindJSON(&replicaSpecs); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body: " + err.Error()})
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid request body: " + err.Error()})
 		return
 	}
 	if len(replicaSpecs) == 0 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "replicaSpecs must not be empty"})
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "replicaSpecs must not be empty"})
 		return
 	}
+	ts.record(pkgsvc.RecordEndpointTune, replicaSpecs)
 
-	modelData, err := ts.service.Tune(replicaSpecs)
+	modelData, groups, err := ts.service.TuneWithOutcomes(replicaSpecs)
 	if err != nil {
-		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		c.JSON(http.StatusUnprocessableEntity, ErrorResponse{Error: err.Error(), Groups: groups})
 		return
 	}
-	c.JSON(http.StatusOK, modelData)
+	c.JSON(http.StatusOK, TuneResponse{ModelData: *modelData, Groups: groups})
 }
 
 // GET /getparams?model=<name>&accelerator=<acc>
-// Response: LearnedParameters for the given model/accelerator pair
+// Response: ParamsResponse for the given model/accelerator pair
 func (ts *TunerServer) handleGetParams(c *gin.Context) {
 	model := c.Query("model")
 	accelerator := c.Query("accelerator")
 
 	if err := validateKey(model, accelerator); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
 		return
 	}
 
 	params := ts.service.GetParams(model, accelerator)
 	if params == nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "no parameters found for model=" + model + " accelerator=" + accelerator})
-		return
-	}
-	c.JSON(http.StatusOK, gin.H{
-		"model":       model,
-		"accelerator": accelerator,
-		"alpha":       params.Alpha,
-		"beta":        params.Beta,
-		"gamma":       params.Gamma,
-		"nis":         params.NIS,
-		"updateCount": params.UpdateCount,
-		"lastUpdated": params.LastUpdated,
-	})
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "no parameters found for model=" + model + " accelerator=" + accelerator})
+		return
+	}
+	resp := ParamsResponse{
+		Model:       model,
+		Accelerator: accelerator,
+		Alpha:       params.Alpha,
+		Beta:        params.Beta,
+		Gamma:       params.Gamma,
+		NIS:         params.NIS,
+		UpdateCount: params.UpdateCount,
+		LastUpdated: params.LastUpdated,
+		Freshness:   ts.service.Freshness(model, accelerator),
+		RawAlpha:    params.RawAlpha,
+		RawBeta:     params.RawBeta,
+		RawGamma:    params.RawGamma,
+	}
+	if prior := ts.service.Prior(model, accelerator); prior != nil {
+		resp.PriorSource, resp.CatalogEntry = prior.Source, prior.CatalogEntry
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// GET /diagnostics?model=<name>&accelerator=<acc>
+// Response: DiagnosticsResponse — the pair's stored parameters evaluated against its retained
+// observations: predicted versus observed TTFT/ITL, residuals, outliers and the Jacobian SVD.
+// Returns 404 if the pair has not been tuned yet.
+func (ts *TunerServer) handleDiagnostics(c *gin.Context) {
+	model := c.Query("model")
+	accelerator := c.Query("accelerator")
+
+	if err := validateKey(model, accelerator); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	diagnostics, err := ts.service.Diagnostics(model, accelerator)
+	if err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, DiagnosticsResponse{Model: model, Accelerator: accelerator, Diagnostics: *diagnostics})
 }
 
 // GET /warmup
 // Response: {"warmingUp": bool} — true if any known (model, accelerator) pair still has
 // UpdateCount < warmUpCycles; false once all pairs have graduated or warmUpCycles is zero.
 func (ts *TunerServer) handleWarmUp(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{"warmingUp": ts.service.IsWarmingUp()})
+	c.JSON(http.StatusOK, WarmUpResponse{WarmingUp: ts.service.IsWarmingUp()})
 }
 
 // POST /calibrate
@@ -74,17 +109,18 @@ func (ts *TunerServer) handleWarmUp(c *gin.Context) {
 func (ts *TunerServer) handleCalibrate(c *gin.Context) {
 	var specs []optconfig.ServerSpec
 	if err := c.ShouldBindJSON(&specs); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body: " + err.Error()})
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid request body: " + err.Error()})
 		return
 	}
 	if len(specs) == 0 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "calibration points must not be empty"})
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "calibration points must not be empty"})
 		return
 	}
+	ts.record(pkgsvc.RecordEndpointCalibrate, specs)
 
 	modelData, err := ts.service.Calibrate(specs)
 	if err != nil {
-		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		c.JSON(http.StatusUnprocessableEntity, ErrorResponse{Error: err.Error()})
 		return
 	}
 	c.JSON(http.StatusOK, modelData)
@@ -94,7 +130,63 @@ func (ts *TunerServer) handleCalibrate(c *gin.Context) {
 // Response: {"statuses": []CalibrationStatus} — per (model, accelerator) pair the tuner has seen,
 // the facts the controller's calibration trigger consumes (NeedsCalibration in particular).
 func (ts *TunerServer) handleCalibrationStatus(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{"statuses": ts.service.CalibrationStatuses()})
+	c.JSON(http.StatusOK, CalibrationStatusResponse{Statuses: ts.service.CalibrationStatuses()})
+}
+
+// GET /shadows
+// Response: pkgsvc.ShadowComparison — the rolling one-step-ahead prediction error of the primary
+// estimator and every shadow, overall and per pair.
+func (ts *TunerServer) handleShadows(c *gin.Context) {
+	c.JSON(http.StatusOK, ts.service.CompareShadows())
+}
+
+// POST /shadows/promote
+// Request body: PromoteShadowRequest
+// Response:     pkgsvc.ShadowComparison after making the named shadow the primary estimator.
+// Returns 404 for an unknown shadow and 409 if it has not tuned every pair the primary has.
+func (ts *TunerServer) handlePromoteShadow(c *gin.Context) {
+	var req PromoteShadowRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid request body: " + err.Error()})
+		return
+	}
+	if req.Name == "" {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "name is required"})
+		return
+	}
+	comparison, err := ts.service.PromoteShadow(req.Name)
+	switch {
+	case errors.Is(err, pkgsvc.ErrUnknownShadow):
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusConflict, ErrorResponse{Error: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, comparison)
+}
+
+// POST /replicate
+// Request body: ReplicationRequest, sent by a peer's Replicator
+// Response:     ReplicationResponse. Each pair state newer than this instance's replaces it.
+func (ts *TunerServer) handleReplicate(c *gin.Context) {
+	var req ReplicationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid request body: " + err.Error()})
+		return
+	}
+	for _, s := range req.Pairs {
+		if err := validateKey(s.Model, s.Accelerator); err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+			return
+		}
+	}
+	applied, err := ts.service.ApplyPairStates(req.Pairs)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, ReplicationResponse{Applied: applied})
 }
 
 // POST /merge
@@ -105,9 +197,105 @@ func (ts *TunerServer) handleCalibrationStatus(c *gin.Context) {
 func (ts *TunerServer) handleMerge(c *gin.Context) {
 	var modelData optconfig.ModelData
 	if err := c.ShouldBindJSON(&modelData); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body: " + err.Error()})
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid request body: " + err.Error()})
+		return
+	}
+	merged, freshness := ts.service.MergeWithFreshness(&modelData)
+	c.JSON(http.StatusOK, MergeResponse{ModelData: *merged, Freshness: freshness})
+}
+
+// POST /predict
+// Request body: PredictRequest — a pair and the operating points to evaluate it at
+// Response:     PredictResponse with TTFT, ITL, wait time, concurrency and utilization per point,
+// from the pair's stored parameters, and TTFT/ITL intervals if a confidence was given. Returns 404
+// if the pair has not been tuned yet.
+func (ts *TunerServer) handlePredict(c *gin.Context) {
+	var req PredictRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid request body: " + err.Error()})
+		return
+	}
+	if err := validatePredictRequest(req.Model, req.Accelerator, req.Points, req.Confidence); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+	predictions, err := ts.service.Predict(req.Model, req.Accelerator, req.Points, req.Confidence)
+	switch {
+	case errors.Is(err, pkgsvc.ErrNoParams):
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusUnprocessableEntity, ErrorResponse{Error: err.Error()})
 		return
 	}
-	merged := ts.service.Merge(&modelData)
-	c.JSON(http.StatusOK, merged)
+	c.JSON(http.StatusOK, PredictResponse{Model: req.Model, Accelerator: req.Accelerator, Predictions: predictions})
+}
+
+// POST /capacity
+// Request body: CapacityRequest — a pair, a token profile, a max batch size and TTFT/ITL targets
+// Response:     CapacityResponse with the max sustainable requests/min per replica, the binding
+// constraint and the capacity's sensitivity to each parameter. Returns 404 if the pair has not
+// been tuned yet.
+func (ts *TunerServer) handleCapacity(c *gin.Context) {
+	var req CapacityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid request body: " + err.Error()})
+		return
+	}
+	if err := validateCapacityRequest(req.Model, req.Accelerator, req.CapacityQuery); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+	capacity, err := ts.service.Capacity(req.Model, req.Accelerator, req.CapacityQuery)
+	switch {
+	case errors.Is(err, pkgsvc.ErrNoParams):
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusUnprocessableEntity, ErrorResponse{Error: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, CapacityResponse{Model: req.Model, Accelerator: req.Accelerator, Capacity: *capacity})
+}
+
+// POST /observe
+// Request body: config.ServerSpec — one replica observation, pushed as it is measured
+// Response:     202 {"pending": n}, the observations buffered for that replica until the next
+// scheduled tune. Returns 429 when the replica's buffer is full until that tune, and 503 when
+// push ingestion is not enabled (TUNER_OBSERVE_INTERVAL).
+func (ts *TunerServer) handleObserve(c *gin.Context) {
+	if ts.ingester == nil {
+		c.JSON(http.StatusServiceUnavailable, ErrorResponse{Error: "push ingestion is not enabled"})
+		return
+	}
+	var spec optconfig.ServerSpec
+	if err := c.ShouldBindJSON(&spec); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid request body: " + err.Error()})
+		return
+	}
+	if err := validateKey(spec.Model, spec.CurrentAlloc.Accelerator); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+	pending, err := ts.ingester.Observe(spec)
+	switch {
+	case errors.Is(err, pkgsvc.ErrObserveBufferFull):
+		c.JSON(http.StatusTooManyRequests, ErrorResponse{Error: err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+	c.JSON(http.StatusAccepted, ObserveResponse{Pending: pending})
+}
+
+// OpenAPI is the OpenAPI 3 document of the REST API.
+//
+//go:embed openapi.yaml
+var OpenAPI []byte
+
+// GET /openapi.yaml
+// Response: the OpenAPI 3 document of this API
+func handleOpenAPI(c *gin.Context) {
+	c.Data(http.StatusOK, "application/yaml", OpenAPI)
 }
@@ -0,0 +1,288 @@
+package tunerservice
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	pkgsvc "github.com/llm-inferno/model-tuner/pkg/service"
+)
+
+func newTestServer(t *testing.T) *TunerServer {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	t.Setenv("CONFIG_DATA_DIR", "../config-data")
+	service := pkgsvc.NewTunerService(3, 5, true, false, pkgsvc.DefaultWindowSize, pkgsvc.DefaultResidualThreshold, 0)
+	return NewTunerServer(service)
+}
+
+func post(ts *TunerServer, path, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	ts.router.ServeHTTP(w, req)
+	return w
+}
+
+const observeBody = `{"name": "llama-0", "model": "llama", "currentAlloc": {"accelerator": "H100", "maxBatch": 64,
+	"ttftAverage": 50, "itlAverage": 8, "load": {"arrivalRate": 30, "avgInTokens": 512, "avgOutTokens": 128}}}`
+
+func TestHandleObserve(t *testing.T) {
+	ts := newTestServer(t)
+	if w := post(ts, "/observe", observeBody); w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("disabled ingestion: status %d, want 503", w.Code)
+	}
+
+	ingester, err := pkgsvc.NewIngester(ts.service, time.Hour, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts.SetIngester(ingester)
+	for i, want := range []string{`{"pending":1}`, `{"pending":2}`} {
+		w := post(ts, "/observe", observeBody)
+		if w.Code != http.StatusAccepted || w.Body.String() != want {
+			t.Fatalf("observe %d: %d %s, want 202 %s", i, w.Code, w.Body.String(), want)
+		}
+	}
+	if w := post(ts, "/observe", `{"model": "llama"}`); w.Code != http.StatusBadRequest {
+		t.Errorf("missing accelerator: status %d, want 400", w.Code)
+	}
+	if w := post(ts, "/observe", strings.Replace(observeBody, `"name": "llama-0", `, "", 1)); w.Code != http.StatusBadRequest {
+		t.Errorf("missing replica name: status %d, want 400", w.Code)
+	}
+	if w := post(ts, "/observe", `[`); w.Code != http.StatusBadRequest {
+		t.Errorf("invalid body: status %d, want 400", w.Code)
+	}
+	if ingester.Pending() != 2 {
+		t.Errorf("Pending = %d, want 2", ingester.Pending())
+	}
+	// the replica's buffer holds three until the next scheduled tune
+	if w := post(ts, "/observe", observeBody); w.Code != http.StatusAccepted {
+		t.Errorf("third observation: status %d, want 202", w.Code)
+	}
+	if w := post(ts, "/observe", observeBody); w.Code != http.StatusTooManyRequests {
+		t.Errorf("full buffer: status %d, want 429", w.Code)
+	}
+}
+
+func TestHandleTune_ReportsGroupOutcomes(t *testing.T) {
+	ts := newTestServer(t)
+	w := post(ts, "/tune", "["+observeBody+"]")
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("first tune: status %d, want 422", w.Code)
+	}
+	var errBody ErrorResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &errBody); err != nil {
+		t.Fatal(err)
+	}
+	if len(errBody.Groups) != 1 {
+		t.Fatalf("422 body %s, want one group outcome", w.Body.String())
+	}
+	if g := errBody.Groups[0]; g.Reason != pkgsvc.OutcomeCollecting || g.ObsCount != 1 || g.ObsTarget != 5 {
+		t.Errorf("group outcome = %+v, want collecting 1/5", g)
+	}
+
+	for range 4 {
+		w = post(ts, "/tune", "["+observeBody+"]")
+	}
+	if w.Code != http.StatusOK {
+		t.Fatalf("fifth tune: status %d %s, want 200", w.Code, w.Body.String())
+	}
+	var resp TuneResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.PerfData) != 1 || len(resp.Groups) != 1 || !resp.Groups[0].Fresh || resp.Groups[0].Reason != pkgsvc.OutcomeTuned {
+		t.Errorf("tune response %s, want one fresh tuned pair", w.Body.String())
+	}
+}
+
+func TestHandlePredict(t *testing.T) {
+	ts := newTestServer(t)
+	const body = `{"model": "llama", "accelerator": "H100",
+		"points": [{"arrivalRate": 300, "avgInTokens": 2048, "avgOutTokens": 128, "maxBatchSize": 64}]}`
+	if w := post(ts, "/predict", body); w.Code != http.StatusNotFound {
+		t.Fatalf("untuned pair: status %d, want 404", w.Code)
+	}
+	if w := post(ts, "/predict", `{"model": "llama", "accelerator": "H100", "points": []}`); w.Code != http.StatusBadRequest {
+		t.Errorf("no points: status %d, want 400", w.Code)
+	}
+	if w := post(ts, "/predict", `{"model": "llama", "accelerator": "H100", "points": [{"arrivalRate": 300}]}`); w.Code != http.StatusBadRequest {
+		t.Errorf("incomplete point: status %d, want 400", w.Code)
+	}
+
+	for range 5 {
+		post(ts, "/tune", "["+observeBody+"]")
+	}
+	w := post(ts, "/predict", body)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status %d %s, want 200", w.Code, w.Body.String())
+	}
+	var resp PredictResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Predictions) != 1 {
+		t.Fatalf("response %s, want one prediction", w.Body.String())
+	}
+	if p := resp.Predictions[0]; p.ArrivalRate != 300 || p.TTFT <= 0 || p.ITL <= 0 || p.Concurrency <= 0 || p.Utilization <= 0 {
+		t.Errorf("prediction = %+v, want positive latencies, concurrency and utilization", p)
+	}
+}
+
+func TestHandleCapacity(t *testing.T) {
+	ts := newTestServer(t)
+	const body = `{"model": "llama", "accelerator": "H100", "avgInTokens": 2048, "avgOutTokens": 128,
+		"maxBatchSize": 64, "targetTTFT": 1000, "targetITL": 30}`
+	if w := post(ts, "/capacity", body); w.Code != http.StatusNotFound {
+		t.Fatalf("untuned pair: status %d, want 404", w.Code)
+	}
+	if w := post(ts, "/capacity", `{"model": "llama", "accelerator": "H100", "avgOutTokens": 128, "maxBatchSize": 64}`); w.Code != http.StatusBadRequest {
+		t.Errorf("no targets: status %d, want 400", w.Code)
+	}
+
+	for range 5 {
+		post(ts, "/tune", "["+observeBody+"]")
+	}
+	w := post(ts, "/capacity", body)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status %d %s, want 200", w.Code, w.Body.String())
+	}
+	var resp CapacityResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Model != "llama" || resp.MaxRate <= 0 || resp.MaxRate > resp.RateStability || resp.Binding == "" {
+		t.Errorf("response %s, want a positive capacity within the stable rate", w.Body.String())
+	}
+}
+
+func TestHandleDiagnostics(t *testing.T) {
+	ts := newTestServer(t)
+	get := func(query string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		ts.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/diagnostics"+query, nil))
+		return w
+	}
+	if w := get("?model=llama"); w.Code != http.StatusBadRequest {
+		t.Errorf("no accelerator: status %d, want 400", w.Code)
+	}
+	if w := get("?model=llama&accelerator=H100"); w.Code != http.StatusNotFound {
+		t.Fatalf("untuned pair: status %d, want 404", w.Code)
+	}
+
+	for range 5 {
+		post(ts, "/tune", "["+observeBody+"]")
+	}
+	w := get("?model=llama&accelerator=H100")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status %d %s, want 200", w.Code, w.Body.String())
+	}
+	var resp DiagnosticsResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Alpha <= 0 || resp.Init == nil || len(resp.Init.Observations) != 5 || resp.Sliding != nil {
+		t.Fatalf("response %s, want the five init observations and no sliding window", w.Body.String())
+	}
+	if o := resp.Init.Observations[0]; !o.Evaluated || o.ObservedTTFT != 50 || o.PredictedTTFT <= 0 {
+		t.Errorf("observation = %+v, want observed TTFT 50 evaluated against the model", o)
+	}
+}
+
+func TestHandleMerge_Freshness(t *testing.T) {
+	ts := newTestServer(t)
+	for range 5 {
+		post(ts, "/tune", "["+observeBody+"]")
+	}
+	if err := ts.service.SetStalenessPolicy(pkgsvc.StalenessPolicy{StaleAfter: time.Nanosecond, ExcludeStale: true}); err != nil {
+		t.Fatal(err)
+	}
+	w := post(ts, "/merge", `{"models": []}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status %d, want 200", w.Code)
+	}
+	var resp MergeResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.PerfData) != 0 || len(resp.Freshness) != 1 || resp.Freshness[0].Freshness != pkgsvc.FreshnessStale || resp.Freshness[0].Merged {
+		t.Errorf("response %s, want the stale pair reported but left out", w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	ts.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/getparams?model=llama&accelerator=H100", nil))
+	var params ParamsResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &params); err != nil || params.Freshness != pkgsvc.FreshnessStale {
+		t.Errorf("getparams %s, want stale", w.Body.String())
+	}
+}
+
+func TestHandleGetParams_CatalogPrior(t *testing.T) {
+	ts := newTestServer(t)
+	path := filepath.Join(t.TempDir(), "catalog.json")
+	catalog := `{"entries": [{"name": "llama-h100", "model": "llama", "accelerator": "H100", "alpha": 7, "beta": 0.06, "gamma": 5e-5}]}`
+	if err := os.WriteFile(path, []byte(catalog), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	c, err := pkgsvc.LoadCatalog(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts.service.SetCatalog(c)
+	for range 5 {
+		post(ts, "/tune", "["+observeBody+"]")
+	}
+
+	w := httptest.NewRecorder()
+	ts.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/getparams?model=llama&accelerator=H100", nil))
+	var params ParamsResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &params); err != nil || params.PriorSource != pkgsvc.PriorCatalog || params.CatalogEntry != "llama-h100" {
+		t.Errorf("getparams %s, want the catalog entry reported", w.Body.String())
+	}
+}
+
+func TestHandleShadows(t *testing.T) {
+	ts := newTestServer(t)
+	if err := ts.service.AddShadow(pkgsvc.EstimatorConfig{Name: "eager", Mode: pkgsvc.EstimatorEKF, InitObs: 1, WindowSize: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if w := post(ts, "/shadows/promote", `{"name": ""}`); w.Code != http.StatusBadRequest {
+		t.Errorf("no name: status %d, want 400", w.Code)
+	}
+	if w := post(ts, "/shadows/promote", `{"name": "lazy"}`); w.Code != http.StatusNotFound {
+		t.Errorf("unknown shadow: status %d, want 404", w.Code)
+	}
+
+	// the shadow needs one observation, the primary five
+	for range 5 {
+		post(ts, "/tune", "["+observeBody+"]")
+	}
+	if w := post(ts, "/shadows/promote", `{"name": "eager"}`); w.Code != http.StatusOK {
+		t.Fatalf("promote: status %d %s, want 200", w.Code, w.Body.String())
+	}
+
+	w := httptest.NewRecorder()
+	ts.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/shadows", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status %d, want 200", w.Code)
+	}
+	var resp pkgsvc.ShadowComparison
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Estimators) != 2 || resp.Estimators[0].Name != "eager" || !resp.Estimators[0].Primary || resp.Estimators[0].Cycles != 4 {
+		t.Errorf("response %s, want the promoted shadow first with four scored cycles", w.Body.String())
+	}
+	if len(resp.Pairs) != 1 || !resp.Pairs[0].Estimators[0].HasParams {
+		t.Errorf("response %s, want the pair's parameters", w.Body.String())
+	}
+}
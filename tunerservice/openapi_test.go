@@ -0,0 +1,158 @@
+package tunerservice
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/goccy/go-yaml"
+	optconfig "github.com/llm-inferno/optimizer-light/pkg/config"
+
+	"github.com/llm-inferno/model-tuner/pkg/estimator"
+	pkgsvc "github.com/llm-inferno/model-tuner/pkg/service"
+)
+
+// openAPIDoc is the part of the OpenAPI document the tests check.
+type openAPIDoc struct {
+	Paths      map[string]map[string]any `yaml:"paths"`
+	Components struct {
+		Schemas map[string]struct {
+			Properties map[string]map[string]any `yaml:"properties"`
+		} `yaml:"schemas"`
+	} `yaml:"components"`
+}
+
+func loadOpenAPI(t *testing.T) *openAPIDoc {
+	t.Helper()
+	doc := &openAPIDoc{}
+	if err := yaml.Unmarshal(OpenAPI, doc); err != nil {
+		t.Fatalf("parsing openapi.yaml: %v", err)
+	}
+	return doc
+}
+
+func TestOpenAPI_MatchesRoutes(t *testing.T) {
+	ts := newTestServer(t)
+	var routes, documented []string
+	for _, r := range ts.router.Routes() {
+		routes = append(routes, r.Method+" "+r.Path)
+	}
+	for path, ops := range loadOpenAPI(t).Paths {
+		for method := range ops {
+			documented = append(documented, strings.ToUpper(method)+" "+path)
+		}
+	}
+	sort.Strings(routes)
+	sort.Strings(documented)
+	if !reflect.DeepEqual(routes, documented) {
+		t.Errorf("routes %v\ndocumented %v", routes, documented)
+	}
+
+	w := httptest.NewRecorder()
+	ts.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/openapi.yaml", nil))
+	if w.Code != http.StatusOK || w.Body.Len() != len(OpenAPI) {
+		t.Errorf("GET /openapi.yaml: %d, %d bytes", w.Code, w.Body.Len())
+	}
+}
+
+func TestOpenAPI_MatchesTypes(t *testing.T) {
+	types := map[string]reflect.Type{
+		"ServerSpec":                reflect.TypeFor[optconfig.ServerSpec](),
+		"AllocationData":            reflect.TypeFor[optconfig.AllocationData](),
+		"ServerLoadSpec":            reflect.TypeFor[optconfig.ServerLoadSpec](),
+		"ModelData":                 reflect.TypeFor[optconfig.ModelData](),
+		"TuneResponse":              reflect.TypeFor[TuneResponse](),
+		"GroupOutcome":              reflect.TypeFor[pkgsvc.GroupOutcome](),
+		"ModelAcceleratorPerfData":  reflect.TypeFor[optconfig.ModelAcceleratorPerfData](),
+		"PerfParms":                 reflect.TypeFor[optconfig.PerfParms](),
+		"ParamsResponse":            reflect.TypeFor[ParamsResponse](),
+		"MergeResponse":             reflect.TypeFor[MergeResponse](),
+		"PairFreshness":             reflect.TypeFor[pkgsvc.PairFreshness](),
+		"WarmUpResponse":            reflect.TypeFor[WarmUpResponse](),
+		"CalibrationStatusResponse": reflect.TypeFor[CalibrationStatusResponse](),
+		"CalibrationStatus":         reflect.TypeFor[pkgsvc.CalibrationStatus](),
+		"ObserveResponse":           reflect.TypeFor[ObserveResponse](),
+		"PredictRequest":            reflect.TypeFor[PredictRequest](),
+		"OperatingPoint":            reflect.TypeFor[pkgsvc.OperatingPoint](),
+		"PredictResponse":           reflect.TypeFor[PredictResponse](),
+		"Prediction":                reflect.TypeFor[pkgsvc.Prediction](),
+		"PredictionInterval":        reflect.TypeFor[pkgsvc.PredictionInterval](),
+		"CapacityRequest":           reflect.TypeFor[CapacityRequest](),
+		"CapacityResponse":          reflect.TypeFor[CapacityResponse](),
+		"ParameterSensitivity":      reflect.TypeFor[pkgsvc.ParameterSensitivity](),
+		"DiagnosticsResponse":       reflect.TypeFor[DiagnosticsResponse](),
+		"FitDiagnostics":            reflect.TypeFor[estimator.FitDiagnostics](),
+		"ObservationFit":            reflect.TypeFor[estimator.ObservationFit](),
+		"Identifiability":           reflect.TypeFor[estimator.Identifiability](),
+		"ShadowComparison":          reflect.TypeFor[pkgsvc.ShadowComparison](),
+		"EstimatorScore":            reflect.TypeFor[pkgsvc.EstimatorScore](),
+		"PairComparison":            reflect.TypeFor[pkgsvc.PairComparison](),
+		"PairScore":                 reflect.TypeFor[pkgsvc.PairScore](),
+		"PromoteShadowRequest":      reflect.TypeFor[PromoteShadowRequest](),
+		"ReplicationRequest":        reflect.TypeFor[ReplicationRequest](),
+		"ReplicationResponse":       reflect.TypeFor[ReplicationResponse](),
+		"PairState":                 reflect.TypeFor[pkgsvc.PairState](),
+		"ErrorResponse":             reflect.TypeFor[ErrorResponse](),
+	}
+	schemas := loadOpenAPI(t).Components.Schemas
+	if len(schemas) != len(types) {
+		t.Errorf("%d schemas documented, %d types checked", len(schemas), len(types))
+	}
+	for name, typ := range types {
+		schema, ok := schemas[name]
+		if !ok {
+			t.Errorf("schema %s is missing", name)
+			continue
+		}
+		fields := map[string]string{}
+		for _, f := range reflect.VisibleFields(typ) {
+			if f.Anonymous {
+				continue // its fields are promoted, and encoded inline
+			}
+			tag, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+			fields[tag] = schemaType(f.Type)
+		}
+		for prop, spec := range schema.Properties {
+			want, ok := fields[prop]
+			if !ok {
+				t.Errorf("%s.%s is documented but not a field", name, prop)
+				continue
+			}
+			got, _ := spec["type"].(string)
+			if _, isRef := spec["$ref"]; isRef {
+				got = "object"
+			}
+			if got != want {
+				t.Errorf("%s.%s: documented as %q, field is %q", name, prop, got, want)
+			}
+			delete(fields, prop)
+		}
+		for prop := range fields {
+			t.Errorf("%s.%s is a field but not documented", name, prop)
+		}
+	}
+}
+
+// schemaType returns the OpenAPI type of a JSON-encoded Go type.
+func schemaType(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice:
+		return "array"
+	}
+	if t == reflect.TypeFor[time.Time]() {
+		return "string"
+	}
+	return "object"
+}
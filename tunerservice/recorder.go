@@ -0,0 +1,125 @@
+package tunerservice
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	optconfig "github.com/llm-inferno/optimizer-light/pkg/config"
+
+	pkgsvc "github.com/llm-inferno/model-tuner/pkg/service"
+)
+
+// Recorder appends /tune and /calibrate request bodies, with a timestamp, to a JSONL file in the
+// format read by pkg/service.ReadRecording, so production traffic can be replayed offline. The
+// file is rotated once it exceeds maxBytes: path becomes path.1, path.1 becomes path.2, and so on,
+// keeping at most maxFiles rotated files. Safe for concurrent use.
+type Recorder struct {
+	mu       sync.Mutex
+	path     string
+	maxBytes int64
+	maxFiles int
+	file     *os.File
+	size     int64
+}
+
+// NewRecorder opens (or creates) the recording file at path for appending. maxBytes <= 0
+// disables rotation; maxFiles <= 0 discards the old file on rotation instead of keeping it.
+func NewRecorder(path string, maxBytes int64, maxFiles int) (*Recorder, error) {
+	if path == "" {
+		return nil, fmt.Errorf("recording path is required")
+	}
+	r := &Recorder{path: path, maxBytes: maxBytes, maxFiles: maxFiles}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Record appends one request to the recording, rotating the file first if it is full.
+func (r *Recorder) Record(endpoint string, specs []optconfig.ServerSpec) error {
+	line, err := json.Marshal(pkgsvc.RecordedRequest{
+		Timestamp: time.Now(),
+		Endpoint:  endpoint,
+		Specs:     specs,
+	})
+	if err != nil {
+		return fmt.Errorf("encode recorded request: %w", err)
+	}
+	line = append(line, '\n')
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.file == nil {
+		return fmt.Errorf("recorder is closed")
+	}
+	if r.maxBytes > 0 && r.size > 0 && r.size+int64(len(line)) > r.maxBytes {
+		if err := r.rotate(); err != nil {
+			return err
+		}
+	}
+	n, err := r.file.Write(line)
+	r.size += int64(n)
+	if err != nil {
+		return fmt.Errorf("write recording %s: %w", r.path, err)
+	}
+	return nil
+}
+
+// Close closes the recording file. Further Record calls return an error.
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.file == nil {
+		return nil
+	}
+	err := r.file.Close()
+	r.file = nil
+	return err
+}
+
+func (r *Recorder) open() error {
+	f, err := os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("open recording %s: %w", r.path, err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return fmt.Errorf("stat recording %s: %w", r.path, err)
+	}
+	r.file = f
+	r.size = info.Size()
+	return nil
+}
+
+// rotate shifts path.(i) to path.(i+1), drops the oldest, moves the current file to path.1 and
+// reopens an empty file. Called with r.mu held.
+func (r *Recorder) rotate() error {
+	if err := r.file.Close(); err != nil {
+		return fmt.Errorf("close recording %s: %w", r.path, err)
+	}
+	r.file = nil
+	if r.maxFiles <= 0 {
+		if err := os.Remove(r.path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("remove recording %s: %w", r.path, err)
+		}
+		return r.open()
+	}
+	_ = os.Remove(rotatedName(r.path, r.maxFiles))
+	for i := r.maxFiles - 1; i >= 1; i-- {
+		if err := os.Rename(rotatedName(r.path, i), rotatedName(r.path, i+1)); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("rotate recording %s: %w", r.path, err)
+		}
+	}
+	if err := os.Rename(r.path, rotatedName(r.path, 1)); err != nil {
+		return fmt.Errorf("rotate recording %s: %w", r.path, err)
+	}
+	return r.open()
+}
+
+func rotatedName(path string, i int) string {
+	return fmt.Sprintf("%s.%d", path, i)
+}
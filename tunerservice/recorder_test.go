@@ -0,0 +1,51 @@
+package tunerservice
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	optconfig "github.com/llm-inferno/optimizer-light/pkg/config"
+
+	pkgsvc "github.com/llm-inferno/model-tuner/pkg/service"
+)
+
+func TestRecorder_AppendsAndRotates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "traffic.jsonl")
+	specs := []optconfig.ServerSpec{{Model: "llama", CurrentAlloc: optconfig.AllocationData{Accelerator: "H100"}}}
+
+	// Small enough that every record after the first triggers a rotation.
+	r, err := NewRecorder(path, 64, 2)
+	if err != nil {
+		t.Fatalf("NewRecorder: %v", err)
+	}
+	for range 4 {
+		if err := r.Record(pkgsvc.RecordEndpointTune, specs); err != nil {
+			t.Fatalf("Record: %v", err)
+		}
+	}
+	if err := r.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	for _, name := range []string{path, path + ".1", path + ".2"} {
+		f, err := os.Open(name)
+		if err != nil {
+			t.Fatalf("expected %s to exist: %v", name, err)
+		}
+		records, err := pkgsvc.ReadRecording(f)
+		_ = f.Close()
+		if err != nil {
+			t.Fatalf("ReadRecording(%s): %v", name, err)
+		}
+		if len(records) != 1 || records[0].Endpoint != pkgsvc.RecordEndpointTune || records[0].Specs[0].Model != "llama" {
+			t.Errorf("%s: unexpected records %+v", name, records)
+		}
+	}
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Errorf("expected at most 2 rotated files, found %s.3", path)
+	}
+	if err := r.Record(pkgsvc.RecordEndpointTune, specs); err == nil {
+		t.Error("expected Record after Close to fail")
+	}
+}
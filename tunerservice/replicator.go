@@ -0,0 +1,144 @@
+package tunerservice
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	pkgsvc "github.com/llm-inferno/model-tuner/pkg/service"
+)
+
+// Replicator pushes the service's pair states (see pkg/service.PairState) to peer tuner instances
+// on POST /replicate, so a standby can take over warm. It pushes the pairs changed by this
+// instance after each tune cycle and every interval, and the state of every pair every full-sync
+// interval, which brings a restarted peer up to date. Peers keep the newer state of each pair, so
+// instances may list each other as peers.
+type Replicator struct {
+	service  *pkgsvc.TunerService
+	peers    []string
+	token    string
+	client   *http.Client
+	interval time.Duration
+	fullSync time.Duration
+	sent     map[string]time.Time // peer -> when the last push to it started
+	lastFull time.Time
+}
+
+// NewReplicator creates a replicator pushing to peers, base URLs such as "http://tuner-1:8081".
+// A non-empty token is sent as a bearer token, and must grant the mutate scope on the peers. The
+// pushes are sent with client, e.g. one with a timeout and a TLS configuration for HTTPS peers;
+// nil uses http.DefaultClient.
+func NewReplicator(service *pkgsvc.TunerService, peers []string, token string, interval, fullSync time.Duration, client *http.Client) (*Replicator, error) {
+	if len(peers) == 0 {
+		return nil, fmt.Errorf("at least one replication peer is required")
+	}
+	if interval <= 0 || fullSync <= 0 {
+		return nil, fmt.Errorf("replication intervals must be positive")
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+	r := &Replicator{
+		service:  service,
+		token:    token,
+		client:   client,
+		interval: interval,
+		fullSync: fullSync,
+		sent:     make(map[string]time.Time, len(peers)),
+	}
+	for _, peer := range peers {
+		r.peers = append(r.peers, strings.TrimSuffix(peer, "/"))
+	}
+	return r, nil
+}
+
+// Run pushes on every publication and every interval until ctx is done, starting with a full
+// sync. Push errors are logged; a failed peer is sent the missed pairs on the next push.
+func (r *Replicator) Run(ctx context.Context) {
+	_, updates, cancel := r.service.WatchParams(64)
+	defer cancel()
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		if err := r.Push(ctx); err != nil {
+			slog.Warn("replication push failed", "err", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-updates:
+			// A tune cycle publishes every pair it tuned; push once for the lot.
+			for drained := false; !drained; {
+				select {
+				case <-updates:
+				default:
+					drained = true
+				}
+			}
+		}
+	}
+}
+
+// Push sends each peer the pairs changed by this instance since the last successful push to it,
+// or every pair when a full sync is due.
+func (r *Replicator) Push(ctx context.Context) error {
+	full := time.Since(r.lastFull) >= r.fullSync
+	started := time.Now()
+	var errs []error
+	for _, peer := range r.peers {
+		since := r.sent[peer]
+		if full {
+			since = time.Time{}
+		}
+		states := r.service.PairStates(since, full)
+		if len(states) > 0 {
+			if err := r.send(ctx, peer, &ReplicationRequest{Pairs: states}); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+		}
+		r.sent[peer] = started
+	}
+	if full && len(errs) == 0 {
+		r.lastFull = started
+	}
+	return errors.Join(errs...)
+}
+
+func (r *Replicator) send(ctx context.Context, peer string, req *ReplicationRequest) error {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, peer+"/replicate", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	if r.token != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+r.token)
+	}
+	resp, err := r.client.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return fmt.Errorf("peer %s returned %s", peer, resp.Status)
+	}
+	var out ReplicationResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return fmt.Errorf("peer %s: decode response: %w", peer, err)
+	}
+	slog.Debug("replicated pair states", "peer", peer, "sent", len(req.Pairs), "applied", out.Applied)
+	return nil
+}
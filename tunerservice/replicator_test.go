@@ -0,0 +1,173 @@
+package tunerservice
+
+import (
+	"context"
+	"crypto/x509"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestReplicator_PushesToPeers(t *testing.T) {
+	active := newTestServer(t)
+	var peers []string
+	var standbys []*TunerServer
+	for range 2 {
+		standby := newTestServer(t)
+		server := httptest.NewServer(standby.Handler())
+		t.Cleanup(server.Close)
+		peers = append(peers, server.URL+"/")
+		standbys = append(standbys, standby)
+	}
+	auth, err := NewAuthorizer(AuthConfig{MutateTokens: []string{"replicate"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	standbys[1].SetAuthorizer(auth)
+
+	if _, err := NewReplicator(active.service, nil, "", time.Second, time.Minute, nil); err == nil {
+		t.Error("expected error without peers")
+	}
+	unauthorized, err := NewReplicator(active.service, peers, "", time.Second, time.Minute, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	replicator, err := NewReplicator(active.service, peers, "replicate", time.Second, time.Minute, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the first cycle only collects, and is replicated as such
+	post(active, "/tune", "["+observeBody+"]")
+	if err := replicator.Push(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	for range 4 {
+		post(active, "/tune", "["+observeBody+"]")
+	}
+	if err := unauthorized.Push(context.Background()); err == nil {
+		t.Error("push without the token succeeded")
+	}
+	if err := replicator.Push(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	want := active.service.GetParams("llama", "H100")
+	for i, standby := range standbys {
+		got := standby.service.GetParams("llama", "H100")
+		if got == nil || got.Alpha != want.Alpha || got.UpdateCount != want.UpdateCount || !got.LastUpdated.Equal(want.LastUpdated) {
+			t.Errorf("standby %d: params %+v, want %+v", i, got, want)
+		}
+		if standby.service.IsWarmingUp() != active.service.IsWarmingUp() {
+			t.Errorf("standby %d: warming up %v, active %v", i, standby.service.IsWarmingUp(), active.service.IsWarmingUp())
+		}
+	}
+
+	// after failover, a standby tunes on where the active left off
+	if w := post(standbys[0], "/tune", "["+observeBody+"]"); w.Code != http.StatusOK {
+		t.Fatalf("standby tune: status %d %s", w.Code, w.Body.String())
+	}
+	if got := standbys[0].service.GetParams("llama", "H100").UpdateCount; got != want.UpdateCount+1 {
+		t.Errorf("standby update count %d, want %d", got, want.UpdateCount+1)
+	}
+}
+
+func TestReplicator_RunPushesOnPublication(t *testing.T) {
+	active, standby := newTestServer(t), newTestServer(t)
+	server := httptest.NewServer(standby.Handler())
+	defer server.Close()
+	replicator, err := NewReplicator(active.service, []string{server.URL}, "", time.Hour, time.Hour, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		replicator.Run(ctx)
+		close(done)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	for range 5 {
+		post(active, "/tune", "["+observeBody+"]")
+	}
+	for deadline := time.Now().Add(5 * time.Second); standby.service.GetParams("llama", "H100") == nil; time.Sleep(10 * time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatal("tuned pair not replicated")
+		}
+	}
+}
+
+func TestReplicator_MutualTLS(t *testing.T) {
+	ca := newTestCA(t)
+	dir := t.TempDir()
+	certFile, keyFile, caFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key"), filepath.Join(dir, "ca.crt")
+	// instances share a certificate serving and calling peers
+	certPEM, keyPEM := ca.issue(t, "model-tuner", 10, x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth)
+	writeFile(t, certFile, certPEM, time.Now())
+	writeFile(t, keyFile, keyPEM, time.Now())
+	writeFile(t, caFile, ca.pem, time.Now())
+	reloader, err := NewTLSReloader(certFile, keyFile, caFile, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	active, standby := newTestServer(t), newTestServer(t)
+	auth, err := NewAuthorizer(AuthConfig{MutateClients: []string{"model-tuner"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	standby.SetAuthorizer(auth)
+	server := httptest.NewUnstartedServer(standby.Handler())
+	server.TLS = reloader.TLSConfig()
+	server.StartTLS()
+	defer server.Close()
+
+	for range 5 {
+		post(active, "/tune", "["+observeBody+"]")
+	}
+	plain, err := NewReplicator(active.service, []string{server.URL}, "", time.Hour, time.Hour, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := plain.Push(context.Background()); err == nil {
+		t.Error("push without the peer CA succeeded")
+	}
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: reloader.ClientTLSConfig()}}
+	replicator, err := NewReplicator(active.service, []string{server.URL}, "", time.Hour, time.Hour, client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := replicator.Push(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if standby.service.GetParams("llama", "H100") == nil {
+		t.Error("tuned pair not replicated over mutual TLS")
+	}
+}
+
+func TestHandleReplicate_Invalid(t *testing.T) {
+	ts := newTestServer(t)
+	if w := post(ts, "/replicate", `{"pairs": [{"model": "llama"}]}`); w.Code != http.StatusBadRequest {
+		t.Errorf("pair without accelerator: status %d, want 400", w.Code)
+	}
+	if w := post(ts, "/replicate", `{"pairs": 1}`); w.Code != http.StatusBadRequest {
+		t.Errorf("invalid body: status %d, want 400", w.Code)
+	}
+	malformed := `{"pairs": [{"model": "llama", "accelerator": "H100", "updatedAt": "2030-01-01T00:00:00Z",
+		"sliding": {"observations": [], "lastFit": [1]}}]}`
+	if w := post(ts, "/replicate", malformed); w.Code != http.StatusBadRequest {
+		t.Errorf("malformed state: status %d, want 400", w.Code)
+	}
+	// nothing of the rejected request was applied: the pair tunes from scratch
+	for range 5 {
+		post(ts, "/tune", "["+observeBody+"]")
+	}
+	if ts.service.GetParams("llama", "H100") == nil {
+		t.Error("pair not tuned after a rejected replication")
+	}
+}
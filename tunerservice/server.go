@@ -1,36 +1,186 @@
 package tunerservice
 
 import (
+	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
 	"log/slog"
+	"net"
+	"net/http"
+	"slices"
+	"sync"
 
 	"github.com/gin-gonic/gin"
 	pkgsvc "github.com/llm-inferno/model-tuner/pkg/service"
+	optconfig "github.com/llm-inferno/optimizer-light/pkg/config"
 )
 
 // TunerServer is the HTTP layer that wraps TunerService and exposes its functionality
 // over a Gin REST API.
 type TunerServer struct {
-	service *pkgsvc.TunerService
-	router  *gin.Engine
+	service  *pkgsvc.TunerService
+	router   *gin.Engine
+	recorder *Recorder
+	ingester *pkgsvc.Ingester
+	auth     *Authorizer
+	tls      *tls.Config
+
+	mu          sync.Mutex
+	httpServer  *http.Server
+	grpcServers []*GRPCServer
+	flushHooks  []func(ctx context.Context) error
 }
 
 // NewTunerServer creates a TunerServer with the given service and registers all routes.
 func NewTunerServer(service *pkgsvc.TunerService) *TunerServer {
 	router := gin.Default()
 	ts := &TunerServer{service: service, router: router}
-	router.POST("/tune", ts.handleTune)
-	router.GET("/getparams", ts.handleGetParams)
-	router.GET("/warmup", ts.handleWarmUp)
-	router.POST("/calibrate", ts.handleCalibrate)
-	router.GET("/calibration-status", ts.handleCalibrationStatus)
-	router.POST("/merge", ts.handleMerge)
+	read, mutate := ts.authorizeHTTP(ScopeRead), ts.authorizeHTTP(ScopeMutate)
+	router.POST("/tune", mutate, ts.handleTune)
+	router.GET("/getparams", read, ts.handleGetParams)
+	router.GET("/warmup", read, ts.handleWarmUp)
+	router.GET("/diagnostics", read, ts.handleDiagnostics)
+	router.POST("/calibrate", mutate, ts.handleCalibrate)
+	router.GET("/calibration-status", read, ts.handleCalibrationStatus)
+	router.POST("/merge", mutate, ts.handleMerge)
+	router.GET("/shadows", read, ts.handleShadows)
+	router.POST("/shadows/promote", mutate, ts.handlePromoteShadow)
+	router.POST("/observe", mutate, ts.handleObserve)
+	router.POST("/replicate", mutate, ts.handleReplicate)
+	router.POST("/predict", read, ts.handlePredict)   // evaluates stored parameters; changes nothing
+	router.POST("/capacity", read, ts.handleCapacity) // likewise
+	router.GET("/openapi.yaml", read, handleOpenAPI)
 	return ts
 }
 
-// Run starts the HTTP server on host:port (blocks until the server stops).
+// SetRecorder enables traffic recording: every valid /tune and /calibrate request body is
+// appended to r before it is processed. nil disables recording.
+func (ts *TunerServer) SetRecorder(r *Recorder) {
+	ts.recorder = r
+}
+
+// SetIngester enables push-style ingestion on POST /observe into in. Scheduled tunes are
+// recorded like /tune requests. nil disables ingestion.
+func (ts *TunerServer) SetIngester(in *pkgsvc.Ingester) {
+	ts.ingester = in
+	if in != nil {
+		in.OnTune(func(specs []optconfig.ServerSpec) { ts.record(pkgsvc.RecordEndpointTune, specs) })
+	}
+}
+
+// SetAuthorizer requires every request, REST and gRPC, to carry credentials a grants the
+// endpoint's scope. nil (the default) admits everything.
+func (ts *TunerServer) SetAuthorizer(a *Authorizer) {
+	ts.auth = a
+}
+
+// SetTLS serves REST, and gRPC servers created afterwards, over TLS with config (e.g.
+// TLSReloader.TLSConfig). nil serves plain HTTP.
+func (ts *TunerServer) SetTLS(config *tls.Config) {
+	ts.tls = config
+}
+
+// SetActiveTuner records the active tuner's cycles like /tune requests, when recording is
+// enabled. The tuner itself is run by the caller.
+func (ts *TunerServer) SetActiveTuner(a *pkgsvc.ActiveTuner) {
+	a.OnTune(func(specs []optconfig.ServerSpec) { ts.record(pkgsvc.RecordEndpointTune, specs) })
+}
+
+// record appends a request to the traffic recording, if enabled. Recording failures are logged
+// and never fail the request.
+func (ts *TunerServer) record(endpoint string, specs []optconfig.ServerSpec) {
+	if ts.recorder == nil {
+		return
+	}
+	if err := ts.recorder.Record(endpoint, specs); err != nil {
+		slog.Warn("failed to record request", "endpoint", endpoint, "err", err)
+	}
+}
+
+// Handler returns the HTTP handler serving the REST API, e.g. to mount it in another server or
+// serve it from httptest.
+func (ts *TunerServer) Handler() http.Handler {
+	return ts.router
+}
+
+// OnShutdown registers a state-flush hook, run by Shutdown once the requests in flight have
+// finished, e.g. to give configured persistence a final snapshot. Hooks run in registration
+// order.
+func (ts *TunerServer) OnShutdown(hook func(ctx context.Context) error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	ts.flushHooks = append(ts.flushHooks, hook)
+}
+
+// Run starts the HTTP server on host:port (blocks until the server stops). It returns nil once
+// Shutdown has stopped the server.
 func (ts *TunerServer) Run(host, port string) error {
 	addr := fmt.Sprintf("%s:%s", host, port)
-	slog.Info("starting TunerServer", "addr", addr)
-	return ts.router.Run(addr)
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	slog.Info("starting TunerServer", "addr", addr, "tls", ts.tls != nil, "auth", ts.auth != nil)
+	return ts.Serve(lis)
+}
+
+// Serve serves the REST API on lis, over TLS if configured (blocks until the server stops). It
+// returns nil once Shutdown has stopped the server.
+func (ts *TunerServer) Serve(lis net.Listener) error {
+	server := ts.server()
+	var err error
+	if ts.tls == nil {
+		err = server.Serve(lis)
+	} else {
+		err = server.ServeTLS(lis, "", "")
+	}
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
+
+// Shutdown stops the REST server, and the gRPC servers created from it, from accepting requests
+// and waits for the requests in flight to finish; those still running when ctx is done are cut
+// off. It then runs the OnShutdown hooks, even if ctx is done, so the state is flushed either
+// way. The errors of both are returned.
+func (ts *TunerServer) Shutdown(ctx context.Context) error {
+	ts.mu.Lock()
+	grpcServers := slices.Clone(ts.grpcServers)
+	hooks := slices.Clone(ts.flushHooks)
+	ts.mu.Unlock()
+
+	errs := make([]error, 1+len(grpcServers))
+	var drain sync.WaitGroup
+	drain.Go(func() {
+		server := ts.server()
+		if err := server.Shutdown(ctx); err != nil {
+			_ = server.Close()
+			errs[0] = fmt.Errorf("drain REST requests: %w", err)
+		}
+	})
+	for i, gs := range grpcServers {
+		drain.Go(func() { errs[1+i] = gs.Shutdown(ctx) })
+	}
+	drain.Wait()
+
+	flushCtx := context.WithoutCancel(ctx)
+	for _, hook := range hooks {
+		if err := hook(flushCtx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// server returns the HTTP server of the REST API, creating it on first use so that Shutdown
+// before Serve makes Serve return at once.
+func (ts *TunerServer) server() *http.Server {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	if ts.httpServer == nil {
+		ts.httpServer = &http.Server{Handler: ts.router, TLSConfig: ts.tls}
+	}
+	return ts.httpServer
 }
@@ -0,0 +1,149 @@
+package tunerservice
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// serveTestServer serves ts on a local port and returns its base URL and Serve's result.
+func serveTestServer(t *testing.T, ts *TunerServer) (string, <-chan error) {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	served := make(chan error, 1)
+	go func() { served <- ts.Serve(lis) }()
+	return "http://" + lis.Addr().String(), served
+}
+
+func TestTunerServer_ShutdownDrainsThenFlushes(t *testing.T) {
+	ts := newTestServer(t)
+	started, release := make(chan struct{}), make(chan struct{})
+	var completed atomic.Bool
+	ts.router.GET("/slow", func(c *gin.Context) {
+		close(started)
+		<-release
+		completed.Store(true)
+		c.Status(http.StatusNoContent)
+	})
+	var flushes atomic.Int32
+	ts.OnShutdown(func(context.Context) error {
+		if !completed.Load() {
+			t.Error("flush hook ran before the request in flight finished")
+		}
+		flushes.Add(1)
+		return nil
+	})
+	gs := NewGRPCServer(ts)
+	grpcServed := make(chan error, 1)
+	go func() { grpcServed <- gs.Serve(bufconn.Listen(1 << 20)) }()
+	url, served := serveTestServer(t, ts)
+
+	status := make(chan int, 1)
+	go func() {
+		resp, err := http.Get(url + "/slow")
+		if err != nil {
+			t.Error(err)
+			status <- 0
+			return
+		}
+		_ = resp.Body.Close()
+		status <- resp.StatusCode
+	}()
+	<-started
+
+	shutdown := make(chan error, 1)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		shutdown <- ts.Shutdown(ctx)
+	}()
+	for deadline := time.Now().Add(5 * time.Second); ; time.Sleep(10 * time.Millisecond) {
+		if _, err := http.Get(url + "/warmup"); err != nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("server still accepting requests after Shutdown")
+		}
+	}
+	if flushes.Load() != 0 {
+		t.Error("flush hook ran while a request was in flight")
+	}
+
+	close(release)
+	if code := <-status; code != http.StatusNoContent {
+		t.Errorf("request in flight: status %d, want %d", code, http.StatusNoContent)
+	}
+	if err := <-shutdown; err != nil {
+		t.Errorf("Shutdown: %v", err)
+	}
+	if n := flushes.Load(); n != 1 {
+		t.Errorf("flush hook ran %d times, want 1", n)
+	}
+	if err := <-served; err != nil {
+		t.Errorf("Serve: %v", err)
+	}
+	if err := <-grpcServed; err != nil {
+		t.Errorf("gRPC Serve: %v", err)
+	}
+}
+
+func TestTunerServer_ShutdownDeadline(t *testing.T) {
+	ts := newTestServer(t)
+	started := make(chan struct{})
+	ts.router.GET("/hang", func(c *gin.Context) {
+		close(started)
+		<-c.Request.Context().Done()
+	})
+	flushErr := errors.New("flush failed")
+	var hookCtxErr error
+	ts.OnShutdown(func(ctx context.Context) error {
+		hookCtxErr = ctx.Err()
+		return flushErr
+	})
+	url, served := serveTestServer(t, ts)
+	go func() {
+		if resp, err := http.Get(url + "/hang"); err == nil {
+			_ = resp.Body.Close()
+		}
+	}()
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err := ts.Shutdown(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) || !errors.Is(err, flushErr) {
+		t.Errorf("Shutdown: %v, want the drain deadline and the flush error", err)
+	}
+	if hookCtxErr != nil {
+		t.Errorf("flush hook context: %v, want live", hookCtxErr)
+	}
+	if err := <-served; err != nil {
+		t.Errorf("Serve: %v", err)
+	}
+}
+
+func TestTunerServer_ShutdownBeforeServe(t *testing.T) {
+	ts := newTestServer(t)
+	if err := ts.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	_, served := serveTestServer(t, ts)
+	select {
+	case err := <-served:
+		if err != nil {
+			t.Errorf("Serve: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Serve after Shutdown did not return")
+	}
+}
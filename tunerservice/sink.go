@@ -0,0 +1,54 @@
+package tunerservice
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	optconfig "github.com/llm-inferno/optimizer-light/pkg/config"
+)
+
+// HTTPSink publishes active-mode results by POSTing the ModelData as JSON to a URL.
+type HTTPSink struct {
+	url    string
+	token  string
+	client *http.Client
+}
+
+// NewHTTPSink creates a sink posting to url. A non-empty token is sent as a bearer token.
+func NewHTTPSink(url, token string, timeout time.Duration) (*HTTPSink, error) {
+	if url == "" {
+		return nil, fmt.Errorf("sink url is required")
+	}
+	return &HTTPSink{url: url, token: token, client: &http.Client{Timeout: timeout}}, nil
+}
+
+// Publish posts modelData to the sink URL; any non-2xx response is an error.
+func (s *HTTPSink) Publish(ctx context.Context, modelData *optconfig.ModelData) error {
+	body, err := json.Marshal(modelData)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.token)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("sink %s returned %s", s.url, resp.Status)
+	}
+	return nil
+}
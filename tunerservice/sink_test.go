@@ -0,0 +1,47 @@
+package tunerservice
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	optconfig "github.com/llm-inferno/optimizer-light/pkg/config"
+)
+
+func TestHTTPSink_Publish(t *testing.T) {
+	var got optconfig.ModelData
+	var auth string
+	status := http.StatusOK
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	sink, err := NewHTTPSink(srv.URL, "secret", time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	modelData := &optconfig.ModelData{PerfData: []optconfig.ModelAcceleratorPerfData{{Name: "llama", Acc: "H100"}}}
+	if err := sink.Publish(context.Background(), modelData); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+	if auth != "Bearer secret" {
+		t.Errorf("Authorization = %q, want bearer token", auth)
+	}
+	if len(got.PerfData) != 1 || got.PerfData[0].Name != "llama" {
+		t.Errorf("posted %+v, want the published ModelData", got)
+	}
+
+	status = http.StatusBadGateway
+	if err := sink.Publish(context.Background(), modelData); err == nil {
+		t.Error("expected error for a non-2xx response")
+	}
+	if _, err := NewHTTPSink("", "", time.Second); err == nil {
+		t.Error("expected error for an empty url")
+	}
+}
@@ -0,0 +1,141 @@
+package tunerservice
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+)
+
+// TLSReloader serves a certificate and key, and optionally a client CA bundle for mTLS, from
+// files, reloading them when their modification time changes (e.g. on cert-manager rotation).
+// Files are checked at each TLS handshake; a reload that fails is logged and the previous
+// material stays in use.
+type TLSReloader struct {
+	certFile, keyFile, clientCAFile string
+	clientAuth                      tls.ClientAuthType
+
+	mu        sync.Mutex
+	config    *tls.Config
+	modTimes  [3]time.Time
+	lastCheck time.Time
+}
+
+// reloadCheckInterval limits how often the files are stat'ed under a burst of handshakes.
+const reloadCheckInterval = time.Second
+
+// NewTLSReloader loads certFile and keyFile, and clientCAFile if not empty. With a client CA,
+// client certificates are verified when presented, or always if requireClientCerts.
+func NewTLSReloader(certFile, keyFile, clientCAFile string, requireClientCerts bool) (*TLSReloader, error) {
+	if certFile == "" || keyFile == "" {
+		return nil, fmt.Errorf("TLS requires both a certificate and a key file")
+	}
+	r := &TLSReloader{certFile: certFile, keyFile: keyFile, clientCAFile: clientCAFile}
+	switch {
+	case clientCAFile == "":
+		r.clientAuth = tls.NoClientCert
+	case requireClientCerts:
+		r.clientAuth = tls.RequireAndVerifyClientCert
+	default:
+		r.clientAuth = tls.VerifyClientCertIfGiven
+	}
+	config, modTimes, err := r.load()
+	if err != nil {
+		return nil, err
+	}
+	r.config, r.modTimes, r.lastCheck = config, modTimes, time.Now()
+	return r, nil
+}
+
+// TLSConfig returns a server configuration that always uses the latest loaded material.
+func (r *TLSReloader) TLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			return r.current(), nil
+		},
+	}
+}
+
+// ClientTLSConfig returns a configuration for calls to peer instances set up like this one, such
+// as replication pushes: it presents the latest loaded certificate when a peer asks for one, and
+// verifies peers against the client CA bundle as loaded now, or the system roots without one. The
+// certificate must then also allow client authentication.
+func (r *TLSReloader) ClientTLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		RootCAs:    r.current().ClientCAs,
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return &r.current().Certificates[0], nil
+		},
+	}
+}
+
+// current returns the loaded configuration, reloading it first if a file changed.
+func (r *TLSReloader) current() *tls.Config {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if time.Since(r.lastCheck) < reloadCheckInterval {
+		return r.config
+	}
+	r.lastCheck = time.Now()
+	modTimes, err := r.stat()
+	if err != nil || modTimes == r.modTimes {
+		return r.config
+	}
+	config, modTimes, err := r.load()
+	if err != nil {
+		slog.Warn("TLS reload failed, keeping previous certificate", "err", err)
+		return r.config
+	}
+	r.config, r.modTimes = config, modTimes
+	slog.Info("reloaded TLS certificate", "cert", r.certFile, "clientCA", r.clientCAFile)
+	return r.config
+}
+
+func (r *TLSReloader) stat() ([3]time.Time, error) {
+	var modTimes [3]time.Time
+	for i, path := range []string{r.certFile, r.keyFile, r.clientCAFile} {
+		if path == "" {
+			continue
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			return modTimes, err
+		}
+		modTimes[i] = info.ModTime()
+	}
+	return modTimes, nil
+}
+
+func (r *TLSReloader) load() (*tls.Config, [3]time.Time, error) {
+	modTimes, err := r.stat()
+	if err != nil {
+		return nil, modTimes, err
+	}
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return nil, modTimes, fmt.Errorf("failed to load TLS certificate: %w", err)
+	}
+	config := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{cert},
+		ClientAuth:   r.clientAuth,
+		NextProtos:   []string{"h2", "http/1.1"},
+	}
+	if r.clientCAFile != "" {
+		pem, err := os.ReadFile(r.clientCAFile)
+		if err != nil {
+			return nil, modTimes, fmt.Errorf("failed to read client CA: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, modTimes, fmt.Errorf("no certificates in client CA %s", r.clientCAFile)
+		}
+		config.ClientCAs = pool
+	}
+	return config, modTimes, nil
+}
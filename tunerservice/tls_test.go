@@ -0,0 +1,173 @@
+package tunerservice
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testCA issues certificates for the TLS tests.
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCA(t *testing.T) *testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+	return &testCA{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+// issue returns PEM certificate and key for commonName with the given serial number.
+func (ca *testCA) issue(t *testing.T, commonName string, serial int64, usages ...x509.ExtKeyUsage) (certPEM, keyPEM []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: commonName},
+		DNSNames:     []string{commonName},
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  usages,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func writeFile(t *testing.T, path string, data []byte, modTime time.Time) {
+	t.Helper()
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTLSReloader_MutualTLSAndReload(t *testing.T) {
+	ca := newTestCA(t)
+	dir := t.TempDir()
+	certFile, keyFile, caFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key"), filepath.Join(dir, "ca.crt")
+	certPEM, keyPEM := ca.issue(t, "model-tuner", 10, x509.ExtKeyUsageServerAuth)
+	start := time.Now().Add(-time.Minute)
+	writeFile(t, certFile, certPEM, start)
+	writeFile(t, keyFile, keyPEM, start)
+	writeFile(t, caFile, ca.pem, start)
+
+	reloader, err := NewTLSReloader(certFile, keyFile, caFile, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := newTestServer(t)
+	auth, err := NewAuthorizer(AuthConfig{MutateClients: []string{"controller"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts.SetAuthorizer(auth)
+	srv := httptest.NewUnstartedServer(ts.Handler())
+	srv.TLS = reloader.TLSConfig()
+	srv.StartTLS()
+	defer srv.Close()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+	clientCertPEM, clientKeyPEM := ca.issue(t, "controller", 20, x509.ExtKeyUsageClientAuth)
+	clientCert, err := tls.X509KeyPair(clientCertPEM, clientKeyPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// post sends POST /merge on a fresh connection and returns the status and server serial.
+	post := func(certs []tls.Certificate) (int, int64) {
+		client := &http.Client{Transport: &http.Transport{
+			TLSClientConfig:   &tls.Config{RootCAs: roots, Certificates: certs},
+			DisableKeepAlives: true,
+		}}
+		resp, err := client.Post(srv.URL+"/merge", "application/json", strings.NewReader(`{"models": []}`))
+		if err != nil {
+			t.Fatalf("POST /merge: %v", err)
+		}
+		defer func() { _ = resp.Body.Close() }()
+		return resp.StatusCode, resp.TLS.PeerCertificates[0].SerialNumber.Int64()
+	}
+
+	if code, serial := post([]tls.Certificate{clientCert}); code != http.StatusOK || serial != 10 {
+		t.Errorf("with client cert: %d from serial %d, want 200 from 10", code, serial)
+	}
+	if code, _ := post(nil); code != http.StatusUnauthorized {
+		t.Errorf("without client cert: %d, want 401", code)
+	}
+
+	// Rotate the server certificate; the next handshake after the check interval serves it.
+	certPEM, keyPEM = ca.issue(t, "model-tuner", 11, x509.ExtKeyUsageServerAuth)
+	writeFile(t, certFile, certPEM, start.Add(30*time.Second))
+	writeFile(t, keyFile, keyPEM, start.Add(30*time.Second))
+	reloader.mu.Lock()
+	reloader.lastCheck = time.Time{}
+	reloader.mu.Unlock()
+	if _, serial := post([]tls.Certificate{clientCert}); serial != 11 {
+		t.Errorf("after rotation: serial %d, want 11", serial)
+	}
+
+	// A broken rotation keeps the last good certificate.
+	writeFile(t, keyFile, []byte("garbage"), start.Add(time.Minute))
+	reloader.mu.Lock()
+	reloader.lastCheck = time.Time{}
+	reloader.mu.Unlock()
+	if _, serial := post([]tls.Certificate{clientCert}); serial != 11 {
+		t.Errorf("after a broken rotation: serial %d, want 11", serial)
+	}
+}
+
+func TestNewTLSReloader_Errors(t *testing.T) {
+	if _, err := NewTLSReloader("", "key", "", false); err == nil {
+		t.Error("expected error without a certificate file")
+	}
+	dir := t.TempDir()
+	if _, err := NewTLSReloader(filepath.Join(dir, "missing.crt"), filepath.Join(dir, "missing.key"), "", false); err == nil {
+		t.Error("expected error for missing files")
+	}
+}
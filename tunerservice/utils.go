@@ -1,6 +1,10 @@
 package tunerservice
 
-import "fmt"
+import (
+	"fmt"
+
+	pkgsvc "github.com/llm-inferno/model-tuner/pkg/service"
+)
 
 // validateKey is used in handler input validation.
 func validateKey(model, accelerator string) error {
@@ -12,3 +16,31 @@ func validateKey(model, accelerator string) error {
 	}
 	return nil
 }
+
+// validatePredictRequest checks the pair, every operating point and the confidence level of a
+// prediction request.
+func validatePredictRequest(model, accelerator string, points []pkgsvc.OperatingPoint, confidence float64) error {
+	if err := validateKey(model, accelerator); err != nil {
+		return err
+	}
+	if err := pkgsvc.ValidateConfidence(confidence); err != nil {
+		return err
+	}
+	if len(points) == 0 {
+		return fmt.Errorf("points must not be empty")
+	}
+	for i, p := range points {
+		if err := p.Validate(); err != nil {
+			return fmt.Errorf("point %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// validateCapacityRequest checks the pair and the query of a capacity request.
+func validateCapacityRequest(model, accelerator string, q pkgsvc.CapacityQuery) error {
+	if err := validateKey(model, accelerator); err != nil {
+		return err
+	}
+	return q.Validate()
+}